@@ -0,0 +1,75 @@
+// Command jwt-keys manages the keys that sign access and refresh tokens.
+//
+//	go run ./cmd/jwt-keys rotate [-alg RS256|EdDSA] [-delay 2m]
+//	go run ./cmd/jwt-keys list
+//	go run ./cmd/jwt-keys prune
+//
+// rotate adds a new key that starts signing after the activation delay.
+// Running API instances reload keys every JWT_KEY_REFRESH_INTERVAL, and older
+// keys keep verifying until every token they signed has expired, so rotating
+// does not log anyone out.
+package main
+
+import (
+	"flag"
+	"fmt"
+	"log"
+	"os"
+	"time"
+
+	"github.com/mohod24/go-project-management/config"
+	"github.com/mohod24/go-project-management/repositories"
+	"github.com/mohod24/go-project-management/services"
+)
+
+func main() {
+	if len(os.Args) < 2 {
+		usage()
+	}
+	config.LoadEnv()
+	config.ConnectDB()
+	keyService := services.NewSigningKeyService(repositories.NewSigningKeyRepository())
+
+	switch os.Args[1] {
+	case "rotate":
+		flags := flag.NewFlagSet("rotate", flag.ExitOnError)
+		alg := flags.String("alg", config.AppConfig.JWTSigningAlg, "signing algorithm: RS256 or EdDSA")
+		delay := flags.String("delay", config.AppConfig.JWTKeyActivationDelay, "time before the new key starts signing")
+		_ = flags.Parse(os.Args[2:])
+
+		activationDelay, err := time.ParseDuration(*delay)
+		if err != nil {
+			log.Fatal("Invalid activation delay: ", err)
+		}
+		key, err := keyService.Rotate(*alg, activationDelay)
+		if err != nil {
+			log.Fatal("Failed to rotate signing key: ", err)
+		}
+		fmt.Printf("Created %s key %s, signing from %s\n", key.Algorithm, key.KID, key.ActivatesAt.Format(time.RFC3339))
+	case "list":
+		keys, err := keyService.List()
+		if err != nil {
+			log.Fatal("Failed to list signing keys: ", err)
+		}
+		for _, key := range keys {
+			expires := "-"
+			if key.ExpiresAt != nil {
+				expires = key.ExpiresAt.Format(time.RFC3339)
+			}
+			fmt.Printf("%s  %-6s  activates %s  expires %s\n", key.KID, key.Algorithm, key.ActivatesAt.Format(time.RFC3339), expires)
+		}
+	case "prune":
+		removed, err := keyService.Prune()
+		if err != nil {
+			log.Fatal("Failed to prune signing keys: ", err)
+		}
+		fmt.Printf("Removed %d expired key(s)\n", removed)
+	default:
+		usage()
+	}
+}
+
+func usage() {
+	fmt.Fprintln(os.Stderr, "usage: jwt-keys rotate [-alg RS256|EdDSA] [-delay 2m] | list | prune")
+	os.Exit(2)
+}
@@ -4,6 +4,7 @@ import (
 	"fmt"
 	"log"
 	"os"
+	"strconv"
 	"time"
 
 	"github.com/joho/godotenv"
@@ -18,6 +19,7 @@ var (
 
 type Config struct {
 	AppPort         string
+	AppEnv          string
 	DBHost          string
 	DBPort          string
 	DBUser          string
@@ -27,6 +29,89 @@ type Config struct {
 	JWTRefreshToken string
 	JWTExpire       string
 	APPURL          string
+
+	// Base URL of the web app; links sent by email open its pages
+	WebAppURL string
+
+	// Asymmetric JWT signing keys
+	JWTSigningAlg         string
+	JWTKeyActivationDelay string
+	JWTKeyRefreshInterval string
+	JWTAcceptLegacyHS256  bool
+	JWTLegacyHS256Until   time.Time
+
+	// Due-date reminders
+	ReminderWindows         string
+	ReminderInterval        string
+	ReminderOverdueLookback string
+
+	// Signing key for links sent by email (invites, verification, ...).
+	// Required unless AppEnv is development.
+	AppSecret string
+
+	// Board invites
+	BoardInviteTTL           string
+	BoardInviteRequireAccept bool
+
+	// Password reset
+	PasswordResetTTL string
+
+	// Password policy
+	PasswordMinLength      int
+	PasswordBreachListFile string
+	PasswordHistoryCount   int
+
+	// Email verification
+	EmailVerificationTTL            string
+	EmailVerificationResendInterval string
+	RequireVerifiedEmailForLogin    bool
+	RequireVerifiedEmailForBoards   bool
+
+	// Two-factor authentication
+	TOTPIssuer             string
+	TwoFactorChallengeTTL         string
+	TwoFactorChallengeMaxAttempts int
+	TwoFactorRequiredRoles        string
+
+	// Login brute-force protection
+	LoginMaxFailuresPerAccount int
+	LoginMaxFailuresPerIP      int
+	LoginFailureWindow         string
+	LoginLockoutDuration       string
+	LoginDelayBase             string
+	LoginDelayMax              string
+
+	// OpenID Connect single sign-on
+	OIDCIssuerURL    string
+	OIDCClientID     string
+	OIDCClientSecret string
+	OIDCRedirectURL  string
+	OIDCScopes       string
+	OIDCAllowSignup  bool
+
+	// User avatars
+	AvatarDir      string
+	AvatarMaxBytes int
+
+	// Account deletion
+	AccountDeletionGracePeriod string
+	AccountPurgeInterval       string
+
+	// Idempotency-Key replay window
+	IdempotencyKeyTTL           string
+	IdempotencyKeyPurgeInterval string
+
+	// Email delivery
+	MailDriver        string
+	MailFrom          string
+	MailFileDir       string
+	MailDefaultLocale string
+	MailQueueSize     int
+	MailWorkers       int
+	SMTPHost          string
+	SMTPPort          string
+	SMTPUsername      string
+	SMTPPassword      string
 }
 
 func LoadEnv() {
@@ -36,6 +121,7 @@ func LoadEnv() {
 	}
 	AppConfig = &Config{
 		AppPort:         getEnv("PORT", "3030"),
+		AppEnv:          getEnv("APP_ENV", "production"),
 		DBHost:          getEnv("DB_HOST", "localhost"),
 		DBPort:          getEnv("DB_PORT", "5432"),
 		DBUser:          getEnv("DB_USER", "postgres"),
@@ -45,6 +131,90 @@ func LoadEnv() {
 		JWTExpire:       getEnv("JWT_EXPIRED", "2h"),
 		JWTRefreshToken: getEnv("REFRESH_TOKEN_EXPIRED", "24h"),
 		APPURL:          getEnv("APP_URL", "http://localhost:3030"),
+		WebAppURL:       getEnv("WEB_APP_URL", "http://localhost:5173"),
+
+		JWTSigningAlg:         getEnv("JWT_SIGNING_ALG", "RS256"),
+		JWTKeyActivationDelay: getEnv("JWT_KEY_ACTIVATION_DELAY", "2m"),
+		JWTKeyRefreshInterval: getEnv("JWT_KEY_REFRESH_INTERVAL", "1m"),
+		JWTAcceptLegacyHS256:  getEnvBool("JWT_ACCEPT_LEGACY_HS256", false),
+
+		ReminderWindows:         getEnv("REMINDER_WINDOWS", "24h,1h"),
+		ReminderInterval:        getEnv("REMINDER_INTERVAL", "1m"),
+		ReminderOverdueLookback: getEnv("REMINDER_OVERDUE_LOOKBACK", "24h"),
+
+		AppSecret: getEnv("APP_SECRET", ""),
+
+		BoardInviteTTL:           getEnv("BOARD_INVITE_TTL", "168h"),
+		BoardInviteRequireAccept: getEnvBool("BOARD_INVITE_REQUIRE_ACCEPT", true),
+
+		PasswordResetTTL: getEnv("PASSWORD_RESET_TTL", "1h"),
+
+		PasswordMinLength:      getEnvInt("PASSWORD_MIN_LENGTH", 8),
+		PasswordBreachListFile: getEnv("PASSWORD_BREACH_LIST_FILE", ""),
+		PasswordHistoryCount:   getEnvInt("PASSWORD_HISTORY_COUNT", 5),
+
+		EmailVerificationTTL:            getEnv("EMAIL_VERIFICATION_TTL", "48h"),
+		EmailVerificationResendInterval: getEnv("EMAIL_VERIFICATION_RESEND_INTERVAL", "1m"),
+		RequireVerifiedEmailForLogin:    getEnvBool("REQUIRE_VERIFIED_EMAIL_FOR_LOGIN", false),
+		RequireVerifiedEmailForBoards:   getEnvBool("REQUIRE_VERIFIED_EMAIL_FOR_BOARDS", true),
+
+		TOTPIssuer:             getEnv("TOTP_ISSUER", "Go Project Management"),
+		TwoFactorChallengeTTL:         getEnv("TWO_FACTOR_CHALLENGE_TTL", "5m"),
+		TwoFactorChallengeMaxAttempts: getEnvInt("TWO_FACTOR_CHALLENGE_MAX_ATTEMPTS", 5),
+		TwoFactorRequiredRoles:        getEnv("TWO_FACTOR_REQUIRED_ROLES", ""),
+
+		LoginMaxFailuresPerAccount: getEnvInt("LOGIN_MAX_FAILURES_ACCOUNT", 5),
+		LoginMaxFailuresPerIP:      getEnvInt("LOGIN_MAX_FAILURES_IP", 50),
+		LoginFailureWindow:         getEnv("LOGIN_FAILURE_WINDOW", "15m"),
+		LoginLockoutDuration:       getEnv("LOGIN_LOCKOUT_DURATION", "15m"),
+		LoginDelayBase:             getEnv("LOGIN_DELAY_BASE", "1s"),
+		LoginDelayMax:              getEnv("LOGIN_DELAY_MAX", "30s"),
+
+		OIDCIssuerURL:    getEnv("OIDC_ISSUER_URL", ""),
+		OIDCClientID:     getEnv("OIDC_CLIENT_ID", ""),
+		OIDCClientSecret: getEnv("OIDC_CLIENT_SECRET", ""),
+		OIDCRedirectURL:  getEnv("OIDC_REDIRECT_URL", getEnv("APP_URL", "http://localhost:3030")+"/v1/auth/oidc/callback"),
+		OIDCScopes:       getEnv("OIDC_SCOPES", "openid email profile"),
+		OIDCAllowSignup:  getEnvBool("OIDC_ALLOW_SIGNUP", true),
+
+		AvatarDir:      getEnv("AVATAR_DIR", "storage/avatars"),
+		AvatarMaxBytes: getEnvInt("AVATAR_MAX_BYTES", 2<<20),
+
+		AccountDeletionGracePeriod: getEnv("ACCOUNT_DELETION_GRACE_PERIOD", "720h"),
+		AccountPurgeInterval:       getEnv("ACCOUNT_PURGE_INTERVAL", "1h"),
+
+		IdempotencyKeyTTL:           getEnv("IDEMPOTENCY_KEY_TTL", "24h"),
+		IdempotencyKeyPurgeInterval: getEnv("IDEMPOTENCY_KEY_PURGE_INTERVAL", "1h"),
+
+		MailDriver:        getEnv("MAIL_DRIVER", "file"),
+		MailFrom:          getEnv("MAIL_FROM", "Go Project Management <no-reply@localhost>"),
+		MailFileDir:       getEnv("MAIL_FILE_DIR", "storage/mail"),
+		MailDefaultLocale: getEnv("MAIL_DEFAULT_LOCALE", "en"),
+		MailQueueSize:     getEnvInt("MAIL_QUEUE_SIZE", 100),
+		MailWorkers:       getEnvInt("MAIL_WORKERS", 2),
+		SMTPHost:          getEnv("SMTP_HOST", "localhost"),
+		SMTPPort:          getEnv("SMTP_PORT", "587"),
+		SMTPUsername:      getEnv("SMTP_USERNAME", ""),
+		SMTPPassword:      getEnv("SMTP_PASSWORD", ""),
+	}
+
+	// Token HS256 lama hanya diterima sampai batas waktu yang jelas
+	if AppConfig.JWTAcceptLegacyHS256 {
+		until, err := time.Parse(time.RFC3339, getEnv("JWT_LEGACY_HS256_UNTIL", ""))
+		if err != nil {
+			log.Fatal("JWT_ACCEPT_LEGACY_HS256 needs a cutoff in JWT_LEGACY_HS256_UNTIL (RFC 3339): ", err)
+		}
+		AppConfig.JWTLegacyHS256Until = until
+	}
+
+	// APP_SECRET menandatangani link reset, verifikasi, restore dan cursor,
+	// jadi di luar development harus diisi sendiri
+	if AppConfig.AppSecret == "" {
+		if AppConfig.AppEnv != "development" {
+			log.Fatal("APP_SECRET must be set unless APP_ENV is development")
+		}
+		log.Println("APP_SECRET is not set, using JWT_SECRET for development")
+		AppConfig.AppSecret = AppConfig.JWTSecret
 	}
 
 }
@@ -58,6 +228,22 @@ func getEnv(key string, fallback string) string {
 	}
 }
 
+func getEnvInt(key string, fallback int) int {
+	value, err := strconv.Atoi(getEnv(key, ""))
+	if err != nil {
+		return fallback
+	}
+	return value
+}
+
+func getEnvBool(key string, fallback bool) bool {
+	value, err := strconv.ParseBool(getEnv(key, ""))
+	if err != nil {
+		return fallback
+	}
+	return value
+}
+
 func ConnectDB() {
 	cfg := AppConfig
 
@@ -0,0 +1,62 @@
+package controllers
+
+import (
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/mohod24/go-project-management/services"
+	"github.com/mohod24/go-project-management/utils"
+)
+
+// AccountController handles HTTP requests for personal data export and
+// account deletion.
+type AccountController struct {
+	service services.AccountService
+}
+
+// NewAccountController creates a new instance of AccountController.
+func NewAccountController(s services.AccountService) *AccountController {
+	return &AccountController{service: s}
+}
+
+// ExportData downloads a zip archive with the current user's personal data.
+func (c *AccountController) ExportData(ctx *fiber.Ctx) error {
+	userID, err := utils.GetUserPublicID(ctx)
+	if err != nil {
+		return utils.Unauthorized(ctx, "Error unauthorized", err.Error())
+	}
+	archive, err := c.service.Export(userID)
+	if err != nil {
+		return utils.InternalServerError(ctx, "Gagal mengekspor data", err.Error())
+	}
+	ctx.Set(fiber.HeaderContentType, "application/zip")
+	ctx.Attachment("account-export-" + time.Now().Format("20060102") + ".zip")
+	return ctx.Send(archive)
+}
+
+// DeleteAccount deletes the current user's account after a grace period.
+func (c *AccountController) DeleteAccount(ctx *fiber.Ctx) error {
+	var body services.AccountDeletionRequest
+	if err := ctx.BodyParser(&body); err != nil {
+		return utils.BadRequest(ctx, "Invalid Request", err.Error())
+	}
+	userID, err := utils.GetUserPublicID(ctx)
+	if err != nil {
+		return utils.Unauthorized(ctx, "Error unauthorized", err.Error())
+	}
+	purgeAt, err := c.service.RequestDeletion(userID, body)
+	if err != nil {
+		return utils.BadRequest(ctx, "Gagal menghapus akun", err.Error())
+	}
+	return utils.Success(ctx, "Akun akan dihapus permanen setelah masa tenggang", fiber.Map{
+		"purge_at": purgeAt,
+	})
+}
+
+// RestoreAccount reactivates a deleted account from a link sent by email.
+func (c *AccountController) RestoreAccount(ctx *fiber.Ctx) error {
+	if err := c.service.Restore(ctx.Query("token")); err != nil {
+		return utils.BadRequest(ctx, "Gagal memulihkan akun", err.Error())
+	}
+	return utils.Success(ctx, "Akun berhasil dipulihkan, silakan login kembali", nil)
+}
@@ -0,0 +1,252 @@
+package controllers
+
+import (
+	"github.com/gofiber/fiber/v2"
+	"github.com/mohod24/go-project-management/services"
+	"github.com/mohod24/go-project-management/utils"
+)
+
+// AuthController handles account recovery and other authentication flows
+// outside of register and login.
+type AuthController struct {
+	passwordResetService     services.PasswordResetService
+	emailVerificationService services.EmailVerificationService
+	twoFactorService         services.TwoFactorService
+	loginThrottleService     services.LoginThrottleService
+}
+
+// NewAuthController creates a new instance of AuthController.
+func NewAuthController(
+	prs services.PasswordResetService,
+	evs services.EmailVerificationService,
+	tfs services.TwoFactorService,
+	lts services.LoginThrottleService,
+) *AuthController {
+	return &AuthController{
+		passwordResetService:     prs,
+		emailVerificationService: evs,
+		twoFactorService:         tfs,
+		loginThrottleService:     lts,
+	}
+}
+
+// RequestPasswordReset sends a password reset link. The response is the same
+// whether or not the email is registered.
+func (c *AuthController) RequestPasswordReset(ctx *fiber.Ctx) error {
+	var body struct {
+		Email string `json:"email"`
+	}
+	if err := ctx.BodyParser(&body); err != nil {
+		return utils.BadRequest(ctx, "Invalid Request", err.Error())
+	}
+	if err := c.passwordResetService.RequestReset(body.Email); err != nil {
+		return utils.InternalServerError(ctx, "Gagal memproses permintaan", err.Error())
+	}
+	return utils.Success(ctx, "Jika email terdaftar, link reset password telah dikirim", nil)
+}
+
+// ConfirmPasswordReset sets a new password using a reset token.
+func (c *AuthController) ConfirmPasswordReset(ctx *fiber.Ctx) error {
+	var body struct {
+		Token    string `json:"token"`
+		Password string `json:"password"`
+	}
+	if err := ctx.BodyParser(&body); err != nil {
+		return utils.BadRequest(ctx, "Invalid Request", err.Error())
+	}
+	if err := c.passwordResetService.ConfirmReset(body.Token, body.Password); err != nil {
+		return utils.BadRequest(ctx, "Gagal reset password", err.Error())
+	}
+	return utils.Success(ctx, "Password berhasil diubah, silakan login kembali", nil)
+}
+
+// VerifyEmail confirms an email address from a signed verification link.
+func (c *AuthController) VerifyEmail(ctx *fiber.Ctx) error {
+	token := ctx.Query("token")
+	if err := c.emailVerificationService.Verify(token); err != nil {
+		return utils.BadRequest(ctx, "Verifikasi email gagal", err.Error())
+	}
+	return utils.Success(ctx, "Email berhasil diverifikasi", nil)
+}
+
+// ResendVerification sends a new verification link, throttled per account.
+// The response is the same whether or not an email was sent.
+func (c *AuthController) ResendVerification(ctx *fiber.Ctx) error {
+	var body struct {
+		Email string `json:"email"`
+	}
+	if err := ctx.BodyParser(&body); err != nil {
+		return utils.BadRequest(ctx, "Invalid Request", err.Error())
+	}
+	if err := c.emailVerificationService.Resend(body.Email); err != nil {
+		return utils.InternalServerError(ctx, "Gagal memproses permintaan", err.Error())
+	}
+	return utils.Success(ctx, "Jika email terdaftar dan belum diverifikasi, link verifikasi telah dikirim", nil)
+}
+
+// SetupTwoFactor starts TOTP enrolment and returns the secret and otpauth URI.
+func (c *AuthController) SetupTwoFactor(ctx *fiber.Ctx) error {
+	userID, err := utils.GetUserPublicID(ctx)
+	if err != nil {
+		return utils.Unauthorized(ctx, "Error unauthorized", err.Error())
+	}
+	setup, err := c.twoFactorService.Setup(userID)
+	if err != nil {
+		return utils.BadRequest(ctx, "Gagal memulai setup 2FA", err.Error())
+	}
+	return utils.Success(ctx, "Scan the otpauth URI with your authenticator app, then confirm with a code", setup)
+}
+
+// ConfirmTwoFactor enables TOTP after checking a code and returns the recovery codes.
+func (c *AuthController) ConfirmTwoFactor(ctx *fiber.Ctx) error {
+	var body struct {
+		Code string `json:"code"`
+	}
+	if err := ctx.BodyParser(&body); err != nil {
+		return utils.BadRequest(ctx, "Invalid Request", err.Error())
+	}
+	userID, err := utils.GetUserPublicID(ctx)
+	if err != nil {
+		return utils.Unauthorized(ctx, "Error unauthorized", err.Error())
+	}
+	codes, err := c.twoFactorService.Confirm(userID, body.Code)
+	if err != nil {
+		return utils.BadRequest(ctx, "Gagal mengaktifkan 2FA", err.Error())
+	}
+	return utils.Success(ctx, "2FA aktif. Simpan recovery code berikut, kode hanya ditampilkan sekali", fiber.Map{
+		"recovery_codes": codes,
+	})
+}
+
+// DisableTwoFactor turns TOTP off after checking the password and a code.
+func (c *AuthController) DisableTwoFactor(ctx *fiber.Ctx) error {
+	var body struct {
+		Password string `json:"password"`
+		Code     string `json:"code"`
+	}
+	if err := ctx.BodyParser(&body); err != nil {
+		return utils.BadRequest(ctx, "Invalid Request", err.Error())
+	}
+	userID, err := utils.GetUserPublicID(ctx)
+	if err != nil {
+		return utils.Unauthorized(ctx, "Error unauthorized", err.Error())
+	}
+	if err := c.twoFactorService.Disable(userID, body.Password, body.Code); err != nil {
+		return utils.BadRequest(ctx, "Gagal menonaktifkan 2FA", err.Error())
+	}
+	return utils.Success(ctx, "2FA dinonaktifkan", nil)
+}
+
+// RegenerateRecoveryCodes replaces the current user's recovery codes.
+func (c *AuthController) RegenerateRecoveryCodes(ctx *fiber.Ctx) error {
+	var body struct {
+		Code string `json:"code"`
+	}
+	if err := ctx.BodyParser(&body); err != nil {
+		return utils.BadRequest(ctx, "Invalid Request", err.Error())
+	}
+	userID, err := utils.GetUserPublicID(ctx)
+	if err != nil {
+		return utils.Unauthorized(ctx, "Error unauthorized", err.Error())
+	}
+	codes, err := c.twoFactorService.RegenerateRecoveryCodes(userID, body.Code)
+	if err != nil {
+		return utils.BadRequest(ctx, "Gagal membuat recovery code", err.Error())
+	}
+	return utils.Success(ctx, "Recovery code baru berhasil dibuat", fiber.Map{
+		"recovery_codes": codes,
+	})
+}
+
+// SetTwoFactorRequired lets an admin enforce or lift 2FA for a user.
+func (c *AuthController) SetTwoFactorRequired(ctx *fiber.Ctx) error {
+	var body struct {
+		Required bool `json:"required"`
+	}
+	if err := ctx.BodyParser(&body); err != nil {
+		return utils.BadRequest(ctx, "Invalid Request", err.Error())
+	}
+	if err := c.twoFactorService.SetRequired(ctx.Params("id"), body.Required); err != nil {
+		return utils.BadRequest(ctx, "Gagal Update Data", err.Error())
+	}
+	return utils.Success(ctx, "Berhasil Update data", fiber.Map{"two_factor_required": body.Required})
+}
+
+// GetLockedLogins lists the accounts and IP addresses that are locked out.
+func (c *AuthController) GetLockedLogins(ctx *fiber.Ctx) error {
+	locked, err := c.loginThrottleService.ListLocked()
+	if err != nil {
+		return utils.InternalServerError(ctx, "Gagal Mengambil Data", err.Error())
+	}
+	return utils.Success(ctx, "Data ditemukan", locked)
+}
+
+// GetUserLockout shows the failed login count and lockout state of a user.
+func (c *AuthController) GetUserLockout(ctx *fiber.Ctx) error {
+	status, err := c.loginThrottleService.AccountStatus(ctx.Params("id"))
+	if err != nil {
+		return utils.NotFound(ctx, "Data Not Found", err.Error())
+	}
+	return utils.Success(ctx, "Data berhasil ditemukan", status)
+}
+
+// UnlockUser lifts a user's login lockout.
+func (c *AuthController) UnlockUser(ctx *fiber.Ctx) error {
+	if err := c.loginThrottleService.UnlockAccount(ctx.Params("id")); err != nil {
+		return utils.BadRequest(ctx, "Gagal membuka kunci akun", err.Error())
+	}
+	return utils.Success(ctx, "Akun berhasil dibuka", nil)
+}
+
+// UnlockIP lifts the login lockout of a client IP address.
+func (c *AuthController) UnlockIP(ctx *fiber.Ctx) error {
+	if err := c.loginThrottleService.UnlockIP(ctx.Params("ip")); err != nil {
+		return utils.InternalServerError(ctx, "Gagal membuka kunci IP", err.Error())
+	}
+	return utils.Success(ctx, "IP berhasil dibuka", nil)
+}
+
+// JWKS publishes the public keys that verify access and refresh tokens.
+func (c *AuthController) JWKS(ctx *fiber.Ctx) error {
+	ctx.Set(fiber.HeaderCacheControl, "public, max-age=300")
+	return ctx.JSON(utils.JWKS())
+}
+
+// RequestEmailChange sends a confirmation link to the current user's new email address.
+func (c *AuthController) RequestEmailChange(ctx *fiber.Ctx) error {
+	var body struct {
+		Email    string `json:"email"`
+		Password string `json:"password"`
+	}
+	if err := ctx.BodyParser(&body); err != nil {
+		return utils.BadRequest(ctx, "Invalid Request", err.Error())
+	}
+	userID, err := utils.GetUserPublicID(ctx)
+	if err != nil {
+		return utils.Unauthorized(ctx, "Error unauthorized", err.Error())
+	}
+	if err := c.emailVerificationService.RequestEmailChange(userID, body.Password, body.Email); err != nil {
+		return utils.BadRequest(ctx, "Gagal mengganti email", err.Error())
+	}
+	return utils.Success(ctx, "Link konfirmasi telah dikirim ke alamat email baru", nil)
+}
+
+// CancelEmailChange discards the current user's pending email change.
+func (c *AuthController) CancelEmailChange(ctx *fiber.Ctx) error {
+	userID, err := utils.GetUserPublicID(ctx)
+	if err != nil {
+		return utils.Unauthorized(ctx, "Error unauthorized", err.Error())
+	}
+	if err := c.emailVerificationService.CancelEmailChange(userID); err != nil {
+		return utils.BadRequest(ctx, "Gagal membatalkan perubahan email", err.Error())
+	}
+	return utils.Success(ctx, "Perubahan email dibatalkan", nil)
+}
+
+// ConfirmEmailChange switches to the new email address from a link sent by email.
+func (c *AuthController) ConfirmEmailChange(ctx *fiber.Ctx) error {
+	if err := c.emailVerificationService.ConfirmEmailChange(ctx.Query("token")); err != nil {
+		return utils.BadRequest(ctx, "Konfirmasi email gagal", err.Error())
+	}
+	return utils.Success(ctx, "Email berhasil diganti", nil)
+}
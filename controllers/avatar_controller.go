@@ -0,0 +1,64 @@
+package controllers
+
+import (
+	"io"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/jinzhu/copier"
+	"github.com/mohod24/go-project-management/config"
+	"github.com/mohod24/go-project-management/models"
+	"github.com/mohod24/go-project-management/services"
+	"github.com/mohod24/go-project-management/utils"
+)
+
+// AvatarController handles HTTP requests for the current user's avatar.
+type AvatarController struct {
+	service services.AvatarService
+}
+
+// NewAvatarController creates a new instance of AvatarController.
+func NewAvatarController(s services.AvatarService) *AvatarController {
+	return &AvatarController{service: s}
+}
+
+// UploadAvatar stores the image sent in the multipart field "avatar".
+func (c *AvatarController) UploadAvatar(ctx *fiber.Ctx) error {
+	userID, err := utils.GetUserPublicID(ctx)
+	if err != nil {
+		return utils.Unauthorized(ctx, "Error unauthorized", err.Error())
+	}
+	fileHeader, err := ctx.FormFile("avatar")
+	if err != nil {
+		return utils.BadRequest(ctx, "File avatar wajib diisi", err.Error())
+	}
+	file, err := fileHeader.Open()
+	if err != nil {
+		return utils.BadRequest(ctx, "Gagal membaca file", err.Error())
+	}
+	defer file.Close()
+	// baca satu byte lebih dari batas agar file yang terlalu besar terdeteksi
+	data, err := io.ReadAll(io.LimitReader(file, int64(config.AppConfig.AvatarMaxBytes)+1))
+	if err != nil {
+		return utils.BadRequest(ctx, "Gagal membaca file", err.Error())
+	}
+
+	user, err := c.service.Upload(userID, data)
+	if err != nil {
+		return utils.BadRequest(ctx, "Gagal mengunggah avatar", err.Error())
+	}
+	var userResp models.UserResponse
+	_ = copier.Copy(&userResp, &user)
+	return utils.Success(ctx, "Avatar berhasil diperbarui", userResp)
+}
+
+// DeleteAvatar removes the current user's avatar.
+func (c *AvatarController) DeleteAvatar(ctx *fiber.Ctx) error {
+	userID, err := utils.GetUserPublicID(ctx)
+	if err != nil {
+		return utils.Unauthorized(ctx, "Error unauthorized", err.Error())
+	}
+	if err := c.service.Delete(userID); err != nil {
+		return utils.BadRequest(ctx, "Gagal menghapus avatar", err.Error())
+	}
+	return utils.Success(ctx, "Avatar berhasil dihapus", nil)
+}
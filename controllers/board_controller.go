@@ -1,9 +1,14 @@
 package controllers
 
 import (
+	"errors"
+	"math"
+	"strconv"
+
 	"github.com/gofiber/fiber/v2"
 	"github.com/golang-jwt/jwt/v4"
 	"github.com/google/uuid"
+	"github.com/jinzhu/copier"
 	"github.com/mohod24/go-project-management/models"
 	"github.com/mohod24/go-project-management/services"
 	"github.com/mohod24/go-project-management/utils"
@@ -43,6 +48,84 @@ func (c *BoardController) CreateBoard(ctx *fiber.Ctx) error {
 	return utils.Success(ctx, "Berhasil membuat board", board)
 }
 
+// GetBoards lists the boards the current user owns or is a member of.
+func (c *BoardController) GetBoards(ctx *fiber.Ctx) error {
+	// /boards?page=1&limit=10&sort=title,-created_at atau /boards?cursor=&limit=10
+	// ditambah ?include=lists,members&fields=title,lists.title
+	userID, err := utils.GetUserPublicID(ctx)
+	if err != nil {
+		return utils.Unauthorized(ctx, "Error unauthorized", err.Error())
+	}
+	includes, err := utils.ParseIncludes(ctx.Query("include"), models.BoardIncludes)
+	if err != nil {
+		return utils.BadRequest(ctx, "Parameter include tidak valid", err.Error())
+	}
+	cursorPage, err := utils.CursorParams(ctx, "boards")
+	if err != nil {
+		return utils.BadRequest(ctx, "Cursor tidak valid", err.Error())
+	}
+	if cursorPage != nil {
+		if ctx.Query("sort") != "" {
+			return utils.BadRequest(ctx, "Gagal Mengambil Data", "sort is not supported with cursor pagination")
+		}
+		boards, result, err := c.service.GetAllCursor(userID, includes, *cursorPage)
+		if err != nil {
+			return utils.BadRequest(ctx, "Gagal Mengambil Data", err.Error())
+		}
+		return utils.SuccessPaginationFields(ctx, "Data ditemukan", boardResponses(boards), utils.CursorMeta("boards", cursorPage.Limit, result))
+	}
+
+	page, _ := strconv.Atoi(ctx.Query("page", "1"))
+	limit, _ := strconv.Atoi(ctx.Query("limit", "10"))
+	offset := (page - 1) * limit
+	sort := ctx.Query("sort")
+	boards, total, err := c.service.GetAllPagination(userID, sort, includes, limit, offset)
+	if err != nil {
+		return utils.BadRequest(ctx, "Gagal Mengambil Data", err.Error())
+	}
+	meta := utils.PaginationMeta{
+		Page:      page,
+		Limit:     limit,
+		Total:     int(total),
+		TotalPage: int(math.Ceil(float64(total) / float64(limit))),
+		Sort:      sort,
+	}
+	return utils.SuccessPaginationFields(ctx, "Data ditemukan", boardResponses(boards), meta)
+}
+
+// GetBoard retrieves a board the current user has access to.
+func (c *BoardController) GetBoard(ctx *fiber.Ctx) error {
+	// /boards/:id?include=lists,lists.cards,members&fields=title,lists.title
+	userID, err := utils.GetUserPublicID(ctx)
+	if err != nil {
+		return utils.Unauthorized(ctx, "Error unauthorized", err.Error())
+	}
+	includes, err := utils.ParseIncludes(ctx.Query("include"), models.BoardIncludes)
+	if err != nil {
+		return utils.BadRequest(ctx, "Parameter include tidak valid", err.Error())
+	}
+	board, err := c.service.GetDetail(ctx.Params("id"), userID, includes)
+	if err != nil {
+		return utils.NotFound(ctx, "Board tidak ditemukan", err.Error())
+	}
+	return utils.SuccessETag(ctx, "Data berhasil ditemukan", boardResponse(*board), board.Version)
+}
+
+// boardResponse converts a board and its loaded members to their public form.
+func boardResponse(board models.Board) models.BoardResponse {
+	resp := models.BoardResponse{Board: board}
+	_ = copier.Copy(&resp.Members, &board.Members)
+	return resp
+}
+
+func boardResponses(boards []models.Board) []models.BoardResponse {
+	resp := make([]models.BoardResponse, len(boards))
+	for i, board := range boards {
+		resp[i] = boardResponse(board)
+	}
+	return resp
+}
+
 // UpdateBoard handles the updating of an existing board.
 func (c *BoardController) UpdateBoard(ctx *fiber.Ctx) error {
 	// Get the board public ID from the URL parameters
@@ -56,6 +139,11 @@ func (c *BoardController) UpdateBoard(ctx *fiber.Ctx) error {
 	if _, err := uuid.Parse(publicID); err != nil {
 		return utils.BadRequest(ctx, "Public ID tidak valid", err.Error())
 	}
+	// The update must be made against the current version (If-Match: <ETag>)
+	version, err := utils.IfMatchVersion(ctx)
+	if err != nil {
+		return utils.VersionConflict(ctx, "Board telah diubah oleh pengguna lain", err)
+	}
 	// Retrieve the existing board to ensure it exists
 	existingBoard, err := c.service.GetByPublicID(publicID)
 	if err != nil {
@@ -67,11 +155,22 @@ func (c *BoardController) UpdateBoard(ctx *fiber.Ctx) error {
 	board.OwnerPublicID = existingBoard.OwnerPublicID
 	board.CreatedAt = existingBoard.CreatedAt
 	board.OwnerID = existingBoard.OwnerID
+	board.Version = version
+	actorID, err := utils.GetUserPublicID(ctx)
+	if err != nil {
+		return utils.Unauthorized(ctx, "Error unauthorized", err.Error())
+	}
 	// Proceed to update the board
-	if err := c.service.Update(board); err != nil {
+	if err := c.service.Update(board, actorID); err != nil {
+		if errors.Is(err, models.ErrVersionConflict) {
+			return utils.VersionConflict(ctx, "Board telah diubah oleh pengguna lain", err)
+		}
+		if errors.Is(err, services.ErrBoardAccessDenied) {
+			return utils.Forbidden(ctx, "Tidak memiliki akses ke board", err.Error())
+		}
 		return utils.BadRequest(ctx, "Gagal update board", err.Error())
 	}
-	return utils.Success(ctx, "Berhasil update board", board)
+	return utils.SuccessETag(ctx, "Berhasil update board", board, board.Version)
 }
 
 func (c *BoardController) AddBoardMember(ctx *fiber.Ctx) error {
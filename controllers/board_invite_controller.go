@@ -0,0 +1,117 @@
+package controllers
+
+import (
+	"github.com/gofiber/fiber/v2"
+	"github.com/mohod24/go-project-management/services"
+	"github.com/mohod24/go-project-management/utils"
+)
+
+// BoardInviteController handles HTTP requests related to board invites.
+type BoardInviteController struct {
+	service services.BoardInviteService
+}
+
+// NewBoardInviteController creates a new instance of BoardInviteController.
+func NewBoardInviteController(s services.BoardInviteService) *BoardInviteController {
+	return &BoardInviteController{service: s}
+}
+
+// InviteBoardMembers invites people to a board by email.
+func (c *BoardInviteController) InviteBoardMembers(ctx *fiber.Ctx) error {
+	var body struct {
+		Emails []string `json:"emails"`
+	}
+	if err := ctx.BodyParser(&body); err != nil {
+		return utils.BadRequest(ctx, "Gagal memparsing permintaan", err.Error())
+	}
+	actorID, err := utils.GetUserPublicID(ctx)
+	if err != nil {
+		return utils.Unauthorized(ctx, "Error unauthorized", err.Error())
+	}
+	invites, err := c.service.Invite(ctx.Params("id"), body.Emails, actorID)
+	if err != nil {
+		return utils.BadRequest(ctx, "Gagal mengirim undangan", err.Error())
+	}
+	return utils.Success(ctx, "Berhasil mengirim undangan", invites)
+}
+
+// GetBoardInvites lists the pending invites of a board.
+func (c *BoardInviteController) GetBoardInvites(ctx *fiber.Ctx) error {
+	actorID, err := utils.GetUserPublicID(ctx)
+	if err != nil {
+		return utils.Unauthorized(ctx, "Error unauthorized", err.Error())
+	}
+	invites, err := c.service.ListPending(ctx.Params("id"), actorID)
+	if err != nil {
+		return utils.BadRequest(ctx, "Gagal Mengambil Data", err.Error())
+	}
+	return utils.Success(ctx, "Data ditemukan", invites)
+}
+
+// RevokeBoardInvite cancels a pending invite of a board.
+func (c *BoardInviteController) RevokeBoardInvite(ctx *fiber.Ctx) error {
+	actorID, err := utils.GetUserPublicID(ctx)
+	if err != nil {
+		return utils.Unauthorized(ctx, "Error unauthorized", err.Error())
+	}
+	if err := c.service.Revoke(ctx.Params("id"), ctx.Params("inviteId"), actorID); err != nil {
+		return utils.BadRequest(ctx, "Gagal membatalkan undangan", err.Error())
+	}
+	return utils.Success(ctx, "Berhasil membatalkan undangan", nil)
+}
+
+// GetMyInvites lists the pending invites addressed to the current user.
+func (c *BoardInviteController) GetMyInvites(ctx *fiber.Ctx) error {
+	userID, err := utils.GetUserPublicID(ctx)
+	if err != nil {
+		return utils.Unauthorized(ctx, "Error unauthorized", err.Error())
+	}
+	invites, err := c.service.ListMine(userID)
+	if err != nil {
+		return utils.BadRequest(ctx, "Gagal Mengambil Data", err.Error())
+	}
+	return utils.Success(ctx, "Data ditemukan", invites)
+}
+
+// AcceptInvite joins the current user to the board of an invite.
+func (c *BoardInviteController) AcceptInvite(ctx *fiber.Ctx) error {
+	userID, err := utils.GetUserPublicID(ctx)
+	if err != nil {
+		return utils.Unauthorized(ctx, "Error unauthorized", err.Error())
+	}
+	if err := c.service.Accept(ctx.Params("id"), userID); err != nil {
+		return utils.BadRequest(ctx, "Gagal menerima undangan", err.Error())
+	}
+	return utils.Success(ctx, "Berhasil bergabung ke board", nil)
+}
+
+// AcceptInviteToken joins the current user to the board of a signed invite
+// link, as sent in invite emails.
+func (c *BoardInviteController) AcceptInviteToken(ctx *fiber.Ctx) error {
+	var body struct {
+		Token string `json:"token"`
+	}
+	if err := ctx.BodyParser(&body); err != nil {
+		return utils.BadRequest(ctx, "Gagal memparsing permintaan", err.Error())
+	}
+	userID, err := utils.GetUserPublicID(ctx)
+	if err != nil {
+		return utils.Unauthorized(ctx, "Error unauthorized", err.Error())
+	}
+	if err := c.service.AcceptToken(body.Token, userID); err != nil {
+		return utils.BadRequest(ctx, "Gagal menerima undangan", err.Error())
+	}
+	return utils.Success(ctx, "Berhasil bergabung ke board", nil)
+}
+
+// DeclineInvite rejects an invite addressed to the current user.
+func (c *BoardInviteController) DeclineInvite(ctx *fiber.Ctx) error {
+	userID, err := utils.GetUserPublicID(ctx)
+	if err != nil {
+		return utils.Unauthorized(ctx, "Error unauthorized", err.Error())
+	}
+	if err := c.service.Decline(ctx.Params("id"), userID); err != nil {
+		return utils.BadRequest(ctx, "Gagal menolak undangan", err.Error())
+	}
+	return utils.Success(ctx, "Berhasil menolak undangan", nil)
+}
@@ -0,0 +1,232 @@
+package controllers
+
+import (
+	"errors"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+	"github.com/mohod24/go-project-management/models"
+	"github.com/mohod24/go-project-management/services"
+	"github.com/mohod24/go-project-management/utils"
+)
+
+// CardController handles HTTP requests related to cards.
+type CardController struct {
+	service services.CardService
+}
+
+// NewCardController creates a new instance of CardController.
+func NewCardController(s services.CardService) *CardController {
+	return &CardController{service: s}
+}
+
+// CreateCard handles the creation of a new card in a list.
+func (c *CardController) CreateCard(ctx *fiber.Ctx) error {
+	listPublicID := ctx.Params("id")
+	card := new(models.Card)
+	if err := ctx.BodyParser(card); err != nil {
+		return utils.BadRequest(ctx, "Gagal memparsing permintaan", err.Error())
+	}
+	actorID, err := utils.GetUserPublicID(ctx)
+	if err != nil {
+		return utils.Unauthorized(ctx, "Error unauthorized", err.Error())
+	}
+	if err := c.service.Create(listPublicID, card, actorID); err != nil {
+		return utils.BadRequest(ctx, "Gagal menyimpan data", err.Error())
+	}
+	return utils.Created(ctx, "Berhasil membuat card", card)
+}
+
+// GetCard retrieves a card by its public ID.
+func (c *CardController) GetCard(ctx *fiber.Ctx) error {
+	actorID, err := utils.GetUserPublicID(ctx)
+	if err != nil {
+		return utils.Unauthorized(ctx, "Error unauthorized", err.Error())
+	}
+	// tanpa ?include= assignee tetap dimuat seperti sebelumnya
+	includes, err := utils.ParseIncludes(ctx.Query("include", "assignees,custom_fields"), models.CardIncludes)
+	if err != nil {
+		return utils.BadRequest(ctx, "Parameter include tidak valid", err.Error())
+	}
+	card, err := c.service.GetWithIncludes(ctx.Params("id"), includes, actorID)
+	if err != nil {
+		return utils.NotFound(ctx, "Card tidak ditemukan", err.Error())
+	}
+	return utils.SuccessETag(ctx, "Data berhasil ditemukan", card, card.Version)
+}
+
+// UpdateCard handles the updating of an existing card.
+func (c *CardController) UpdateCard(ctx *fiber.Ctx) error {
+	publicID, err := uuid.Parse(ctx.Params("id"))
+	if err != nil {
+		return utils.BadRequest(ctx, "Public ID tidak valid", err.Error())
+	}
+	version, err := utils.IfMatchVersion(ctx)
+	if err != nil {
+		return utils.VersionConflict(ctx, "Card telah diubah oleh pengguna lain", err)
+	}
+	card := new(models.Card)
+	if err := ctx.BodyParser(card); err != nil {
+		return utils.BadRequest(ctx, "Gagal memparsing permintaan", err.Error())
+	}
+	card.PublicID = publicID
+	card.Version = version
+
+	actorID, err := utils.GetUserPublicID(ctx)
+	if err != nil {
+		return utils.Unauthorized(ctx, "Error unauthorized", err.Error())
+	}
+	if err := c.service.Update(card, actorID); err != nil {
+		if errors.Is(err, models.ErrVersionConflict) {
+			return utils.VersionConflict(ctx, "Card telah diubah oleh pengguna lain", err)
+		}
+		return utils.BadRequest(ctx, "Gagal update card", err.Error())
+	}
+
+	updated, err := c.service.GetByPublicID(publicID.String())
+	if err != nil {
+		return utils.InternalServerError(ctx, "Gagal Ambil Data", err.Error())
+	}
+	return utils.SuccessETag(ctx, "Berhasil update card", updated, updated.Version)
+}
+
+// AddCardAssignees assigns board members to a card.
+func (c *CardController) AddCardAssignees(ctx *fiber.Ctx) error {
+	var userIDs []string
+	if err := ctx.BodyParser(&userIDs); err != nil {
+		return utils.BadRequest(ctx, "Gagal memparsing permintaan", err.Error())
+	}
+	actorID, err := utils.GetUserPublicID(ctx)
+	if err != nil {
+		return utils.Unauthorized(ctx, "Error unauthorized", err.Error())
+	}
+	if err := c.service.AddAssignees(ctx.Params("id"), userIDs, actorID); err != nil {
+		return utils.BadRequest(ctx, "Gagal menambahkan assignee", err.Error())
+	}
+	return utils.Success(ctx, "Berhasil menambahkan assignee", nil)
+}
+
+// AddComment posts a comment on a card.
+func (c *CardController) AddComment(ctx *fiber.Ctx) error {
+	var body struct {
+		Message string `json:"message"`
+	}
+	if err := ctx.BodyParser(&body); err != nil {
+		return utils.BadRequest(ctx, "Gagal memparsing permintaan", err.Error())
+	}
+	actorID, err := utils.GetUserPublicID(ctx)
+	if err != nil {
+		return utils.Unauthorized(ctx, "Error unauthorized", err.Error())
+	}
+	comment, err := c.service.AddComment(ctx.Params("id"), actorID, body.Message)
+	if err != nil {
+		return utils.BadRequest(ctx, "Gagal menambahkan komentar", err.Error())
+	}
+	return utils.Created(ctx, "Berhasil menambahkan komentar", comment)
+}
+
+// GetComments retrieves all comments of a card.
+func (c *CardController) GetComments(ctx *fiber.Ctx) error {
+	actorID, err := utils.GetUserPublicID(ctx)
+	if err != nil {
+		return utils.Unauthorized(ctx, "Error unauthorized", err.Error())
+	}
+	listing := "comments:" + ctx.Params("id")
+	cursorPage, err := utils.CursorParams(ctx, listing)
+	if err != nil {
+		return utils.BadRequest(ctx, "Cursor tidak valid", err.Error())
+	}
+	if cursorPage != nil {
+		comments, result, err := c.service.GetCommentsCursor(ctx.Params("id"), actorID, *cursorPage)
+		if err != nil {
+			return utils.NotFound(ctx, "Card tidak ditemukan", err.Error())
+		}
+		return utils.SuccessPagination(ctx, "Data berhasil ditemukan", comments, utils.CursorMeta(listing, cursorPage.Limit, result))
+	}
+
+	comments, err := c.service.GetComments(ctx.Params("id"), actorID)
+	if err != nil {
+		return utils.NotFound(ctx, "Card tidak ditemukan", err.Error())
+	}
+	return utils.Success(ctx, "Data berhasil ditemukan", comments)
+}
+
+// GetBoardCards lists the cards of a board, filtered by the card query in ?q=
+// and ordered by ?sort=.
+func (c *CardController) GetBoardCards(ctx *fiber.Ctx) error {
+	return c.listCards(ctx, "board-cards:"+ctx.Params("id"), c.service.FindByBoard)
+}
+
+// GetListCards lists the cards of a list, filtered by the card query in ?q=
+// and ordered by ?sort=.
+func (c *CardController) GetListCards(ctx *fiber.Ctx) error {
+	return c.listCards(ctx, "list-cards:"+ctx.Params("id"), c.service.FindByList)
+}
+
+// listCards responds with all matching cards, or with one keyset page of
+// them when ?cursor= is given. Keyset pages keep their own order, so ?sort=
+// only applies without a cursor.
+func (c *CardController) listCards(ctx *fiber.Ctx, listing string,
+	find func(publicID, query, sort, actorPublicID string, includes []string, page *models.CursorPage) ([]models.Card, models.CursorResult, error)) error {
+	actorID, err := utils.GetUserPublicID(ctx)
+	if err != nil {
+		return utils.Unauthorized(ctx, "Error unauthorized", err.Error())
+	}
+	includes, err := utils.ParseIncludes(ctx.Query("include", "assignees,labels,custom_fields"), models.CardIncludes)
+	if err != nil {
+		return utils.BadRequest(ctx, "Parameter include tidak valid", err.Error())
+	}
+	cursorPage, err := utils.CursorParams(ctx, listing)
+	if err != nil {
+		return utils.BadRequest(ctx, "Cursor tidak valid", err.Error())
+	}
+	if cursorPage != nil && ctx.Query("sort") != "" {
+		return utils.BadRequest(ctx, "Gagal Mengambil Data", "sort is not supported with cursor pagination")
+	}
+	cards, result, err := find(ctx.Params("id"), ctx.Query("q"), ctx.Query("sort"), actorID, includes, cursorPage)
+	if err != nil {
+		return cardQueryError(ctx, err)
+	}
+	if cursorPage != nil {
+		meta := utils.CursorMeta(listing, cursorPage.Limit, result)
+		meta.Filter = ctx.Query("q")
+		return utils.SuccessPaginationFields(ctx, "Data berhasil ditemukan", cards, meta)
+	}
+	return utils.SuccessFields(ctx, "Data berhasil ditemukan", cards)
+}
+
+// cardQueryError responds to a failed card listing.
+func cardQueryError(ctx *fiber.Ctx, err error) error {
+	var queryErr *utils.CardQueryError
+	if errors.As(err, &queryErr) {
+		return utils.BadRequest(ctx, "Query card tidak valid", err.Error())
+	}
+	var sortErr *utils.SortError
+	if errors.As(err, &sortErr) {
+		return utils.BadRequest(ctx, "Parameter sort tidak valid", err.Error())
+	}
+	return utils.BadRequest(ctx, "Gagal Mengambil Data", err.Error())
+}
+
+// BulkCards applies one operation to many cards of a board.
+func (c *CardController) BulkCards(ctx *fiber.Ctx) error {
+	var req services.CardBulkRequest
+	if err := ctx.BodyParser(&req); err != nil {
+		return utils.BadRequest(ctx, "Gagal memparsing permintaan", err.Error())
+	}
+	actorID, err := utils.GetUserPublicID(ctx)
+	if err != nil {
+		return utils.Unauthorized(ctx, "Error unauthorized", err.Error())
+	}
+	resp, err := c.service.Bulk(ctx.Params("id"), req, actorID)
+	if err != nil {
+		return utils.BadRequest(ctx, "Gagal memproses card", err.Error())
+	}
+	switch {
+	case resp.Failed == 0:
+		return utils.Success(ctx, "Berhasil memproses card", resp)
+	case resp.Atomic:
+		return utils.UnprocessableEntityResult(ctx, "Tidak ada card yang diproses", "one or more cards failed, no changes were made", resp)
+	}
+	return utils.Success(ctx, "Sebagian card berhasil diproses", resp)
+}
@@ -0,0 +1,170 @@
+package controllers
+
+import (
+	"github.com/gofiber/fiber/v2"
+	"github.com/mohod24/go-project-management/services"
+	"github.com/mohod24/go-project-management/utils"
+)
+
+// ChecklistController handles HTTP requests related to card checklists.
+type ChecklistController struct {
+	service services.ChecklistService
+}
+
+// NewChecklistController creates a new instance of ChecklistController.
+func NewChecklistController(s services.ChecklistService) *ChecklistController {
+	return &ChecklistController{service: s}
+}
+
+// CreateChecklist adds a checklist to a card.
+func (c *ChecklistController) CreateChecklist(ctx *fiber.Ctx) error {
+	var body struct {
+		Title string `json:"title"`
+	}
+	if err := ctx.BodyParser(&body); err != nil {
+		return utils.BadRequest(ctx, "Gagal memparsing permintaan", err.Error())
+	}
+	actorID, err := utils.GetUserPublicID(ctx)
+	if err != nil {
+		return utils.Unauthorized(ctx, "Error unauthorized", err.Error())
+	}
+	checklist, err := c.service.Create(ctx.Params("id"), body.Title, actorID)
+	if err != nil {
+		return utils.BadRequest(ctx, "Gagal membuat checklist", err.Error())
+	}
+	return utils.Created(ctx, "Berhasil membuat checklist", checklist)
+}
+
+// GetChecklists retrieves the checklists of a card with their items.
+func (c *ChecklistController) GetChecklists(ctx *fiber.Ctx) error {
+	actorID, err := utils.GetUserPublicID(ctx)
+	if err != nil {
+		return utils.Unauthorized(ctx, "Error unauthorized", err.Error())
+	}
+	checklists, err := c.service.GetByCard(ctx.Params("id"), actorID)
+	if err != nil {
+		return utils.BadRequest(ctx, "Gagal Mengambil Data", err.Error())
+	}
+	return utils.Success(ctx, "Data berhasil ditemukan", checklists)
+}
+
+// UpdateChecklist renames a checklist or moves it to another position.
+func (c *ChecklistController) UpdateChecklist(ctx *fiber.Ctx) error {
+	var body struct {
+		Title    string `json:"title"`
+		Position int    `json:"position"`
+	}
+	if err := ctx.BodyParser(&body); err != nil {
+		return utils.BadRequest(ctx, "Gagal memparsing permintaan", err.Error())
+	}
+	actorID, err := utils.GetUserPublicID(ctx)
+	if err != nil {
+		return utils.Unauthorized(ctx, "Error unauthorized", err.Error())
+	}
+	checklist, err := c.service.Update(ctx.Params("id"), body.Title, body.Position, actorID)
+	if err != nil {
+		return utils.BadRequest(ctx, "Gagal update checklist", err.Error())
+	}
+	return utils.Success(ctx, "Berhasil update checklist", checklist)
+}
+
+// DeleteChecklist removes a checklist and its items.
+func (c *ChecklistController) DeleteChecklist(ctx *fiber.Ctx) error {
+	actorID, err := utils.GetUserPublicID(ctx)
+	if err != nil {
+		return utils.Unauthorized(ctx, "Error unauthorized", err.Error())
+	}
+	if err := c.service.Delete(ctx.Params("id"), actorID); err != nil {
+		return utils.BadRequest(ctx, "Gagal menghapus checklist", err.Error())
+	}
+	return utils.Success(ctx, "Berhasil menghapus checklist", nil)
+}
+
+// AddItem adds an item to a checklist.
+func (c *ChecklistController) AddItem(ctx *fiber.Ctx) error {
+	var req services.ChecklistItemRequest
+	if err := ctx.BodyParser(&req); err != nil {
+		return utils.BadRequest(ctx, "Gagal memparsing permintaan", err.Error())
+	}
+	actorID, err := utils.GetUserPublicID(ctx)
+	if err != nil {
+		return utils.Unauthorized(ctx, "Error unauthorized", err.Error())
+	}
+	item, err := c.service.AddItem(ctx.Params("id"), req, actorID)
+	if err != nil {
+		return utils.BadRequest(ctx, "Gagal menambahkan item", err.Error())
+	}
+	return utils.Created(ctx, "Berhasil menambahkan item", item)
+}
+
+// UpdateItem changes a checklist item or moves it to another position.
+func (c *ChecklistController) UpdateItem(ctx *fiber.Ctx) error {
+	var req services.ChecklistItemRequest
+	if err := ctx.BodyParser(&req); err != nil {
+		return utils.BadRequest(ctx, "Gagal memparsing permintaan", err.Error())
+	}
+	actorID, err := utils.GetUserPublicID(ctx)
+	if err != nil {
+		return utils.Unauthorized(ctx, "Error unauthorized", err.Error())
+	}
+	item, err := c.service.UpdateItem(ctx.Params("id"), req, actorID)
+	if err != nil {
+		return utils.BadRequest(ctx, "Gagal update item", err.Error())
+	}
+	return utils.Success(ctx, "Berhasil update item", item)
+}
+
+// CheckItem marks a checklist item as done.
+func (c *ChecklistController) CheckItem(ctx *fiber.Ctx) error {
+	return c.setItemChecked(ctx, true)
+}
+
+// UncheckItem marks a checklist item as not done.
+func (c *ChecklistController) UncheckItem(ctx *fiber.Ctx) error {
+	return c.setItemChecked(ctx, false)
+}
+
+func (c *ChecklistController) setItemChecked(ctx *fiber.Ctx, checked bool) error {
+	actorID, err := utils.GetUserPublicID(ctx)
+	if err != nil {
+		return utils.Unauthorized(ctx, "Error unauthorized", err.Error())
+	}
+	item, err := c.service.SetItemChecked(ctx.Params("id"), checked, actorID)
+	if err != nil {
+		return utils.BadRequest(ctx, "Gagal update item", err.Error())
+	}
+	return utils.Success(ctx, "Berhasil update item", item)
+}
+
+// DeleteItem removes a checklist item.
+func (c *ChecklistController) DeleteItem(ctx *fiber.Ctx) error {
+	actorID, err := utils.GetUserPublicID(ctx)
+	if err != nil {
+		return utils.Unauthorized(ctx, "Error unauthorized", err.Error())
+	}
+	if err := c.service.DeleteItem(ctx.Params("id"), actorID); err != nil {
+		return utils.BadRequest(ctx, "Gagal menghapus item", err.Error())
+	}
+	return utils.Success(ctx, "Berhasil menghapus item", nil)
+}
+
+// ConvertItem turns a checklist item into a card.
+func (c *ChecklistController) ConvertItem(ctx *fiber.Ctx) error {
+	var body struct {
+		ListID string `json:"list_id"`
+	}
+	if len(ctx.Body()) > 0 {
+		if err := ctx.BodyParser(&body); err != nil {
+			return utils.BadRequest(ctx, "Gagal memparsing permintaan", err.Error())
+		}
+	}
+	actorID, err := utils.GetUserPublicID(ctx)
+	if err != nil {
+		return utils.Unauthorized(ctx, "Error unauthorized", err.Error())
+	}
+	card, err := c.service.ConvertItem(ctx.Params("id"), body.ListID, actorID)
+	if err != nil {
+		return utils.BadRequest(ctx, "Gagal mengubah item menjadi card", err.Error())
+	}
+	return utils.Created(ctx, "Berhasil mengubah item menjadi card", card)
+}
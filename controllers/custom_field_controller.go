@@ -0,0 +1,96 @@
+package controllers
+
+import (
+	"github.com/gofiber/fiber/v2"
+	"github.com/mohod24/go-project-management/services"
+	"github.com/mohod24/go-project-management/utils"
+)
+
+// CustomFieldController handles HTTP requests related to board custom fields.
+type CustomFieldController struct {
+	service services.CustomFieldService
+}
+
+// NewCustomFieldController creates a new instance of CustomFieldController.
+func NewCustomFieldController(s services.CustomFieldService) *CustomFieldController {
+	return &CustomFieldController{service: s}
+}
+
+// CreateCustomField adds a custom field to a board.
+func (c *CustomFieldController) CreateCustomField(ctx *fiber.Ctx) error {
+	var req services.CustomFieldRequest
+	if err := ctx.BodyParser(&req); err != nil {
+		return utils.BadRequest(ctx, "Gagal memparsing permintaan", err.Error())
+	}
+	actorID, err := utils.GetUserPublicID(ctx)
+	if err != nil {
+		return utils.Unauthorized(ctx, "Error unauthorized", err.Error())
+	}
+	field, err := c.service.Create(ctx.Params("id"), req, actorID)
+	if err != nil {
+		return utils.BadRequest(ctx, "Gagal membuat custom field", err.Error())
+	}
+	return utils.Created(ctx, "Berhasil membuat custom field", field)
+}
+
+// GetCustomFields retrieves the custom fields of a board.
+func (c *CustomFieldController) GetCustomFields(ctx *fiber.Ctx) error {
+	actorID, err := utils.GetUserPublicID(ctx)
+	if err != nil {
+		return utils.Unauthorized(ctx, "Error unauthorized", err.Error())
+	}
+	fields, err := c.service.GetByBoard(ctx.Params("id"), actorID)
+	if err != nil {
+		return utils.BadRequest(ctx, "Gagal Mengambil Data", err.Error())
+	}
+	return utils.Success(ctx, "Data berhasil ditemukan", fields)
+}
+
+// UpdateCustomField renames a custom field or changes its dropdown options.
+func (c *CustomFieldController) UpdateCustomField(ctx *fiber.Ctx) error {
+	var req services.CustomFieldRequest
+	if err := ctx.BodyParser(&req); err != nil {
+		return utils.BadRequest(ctx, "Gagal memparsing permintaan", err.Error())
+	}
+	actorID, err := utils.GetUserPublicID(ctx)
+	if err != nil {
+		return utils.Unauthorized(ctx, "Error unauthorized", err.Error())
+	}
+	field, err := c.service.Update(ctx.Params("id"), req, actorID)
+	if err != nil {
+		return utils.BadRequest(ctx, "Gagal update custom field", err.Error())
+	}
+	return utils.Success(ctx, "Berhasil update custom field", field)
+}
+
+// DeleteCustomField removes a custom field and its values.
+func (c *CustomFieldController) DeleteCustomField(ctx *fiber.Ctx) error {
+	actorID, err := utils.GetUserPublicID(ctx)
+	if err != nil {
+		return utils.Unauthorized(ctx, "Error unauthorized", err.Error())
+	}
+	if err := c.service.Delete(ctx.Params("id"), actorID); err != nil {
+		return utils.BadRequest(ctx, "Gagal menghapus custom field", err.Error())
+	}
+	return utils.Success(ctx, "Berhasil menghapus custom field", nil)
+}
+
+// SetCardValue sets the value of a custom field on a card. A null value
+// clears it.
+func (c *CustomFieldController) SetCardValue(ctx *fiber.Ctx) error {
+	var body struct {
+		Value interface{} `json:"value"`
+	}
+	if err := ctx.BodyParser(&body); err != nil {
+		return utils.BadRequest(ctx, "Gagal memparsing permintaan", err.Error())
+	}
+	actorID, err := utils.GetUserPublicID(ctx)
+	if err != nil {
+		return utils.Unauthorized(ctx, "Error unauthorized", err.Error())
+	}
+	value, err := c.service.SetValue(ctx.Params("id"), ctx.Params("fieldId"), body.Value, actorID)
+	if err != nil {
+		return utils.BadRequest(ctx, "Gagal menyimpan nilai custom field", err.Error())
+	}
+	return utils.Success(ctx, "Berhasil menyimpan nilai custom field", value)
+}
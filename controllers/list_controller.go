@@ -0,0 +1,86 @@
+package controllers
+
+import (
+	"errors"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+	"github.com/mohod24/go-project-management/models"
+	"github.com/mohod24/go-project-management/services"
+	"github.com/mohod24/go-project-management/utils"
+)
+
+// ListController handles HTTP requests related to lists.
+type ListController struct {
+	service services.ListService
+}
+
+// NewListController creates a new instance of ListController.
+func NewListController(s services.ListService) *ListController {
+	return &ListController{service: s}
+}
+
+// CreateList handles the creation of a new list on a board.
+func (c *ListController) CreateList(ctx *fiber.Ctx) error {
+	boardPublicID := ctx.Params("id")
+	list := new(models.List)
+	if err := ctx.BodyParser(list); err != nil {
+		return utils.BadRequest(ctx, "Gagal memparsing permintaan", err.Error())
+	}
+	actorID, err := utils.GetUserPublicID(ctx)
+	if err != nil {
+		return utils.Unauthorized(ctx, "Error unauthorized", err.Error())
+	}
+	if err := c.service.Create(boardPublicID, list, actorID); err != nil {
+		return utils.BadRequest(ctx, "Gagal menyimpan data", err.Error())
+	}
+	return utils.Created(ctx, "Berhasil membuat list", list)
+}
+
+// GetList retrieves a list by its public ID.
+func (c *ListController) GetList(ctx *fiber.Ctx) error {
+	actorID, err := utils.GetUserPublicID(ctx)
+	if err != nil {
+		return utils.Unauthorized(ctx, "Error unauthorized", err.Error())
+	}
+	includes, err := utils.ParseIncludes(ctx.Query("include"), models.ListIncludes)
+	if err != nil {
+		return utils.BadRequest(ctx, "Parameter include tidak valid", err.Error())
+	}
+	list, err := c.service.GetWithIncludes(ctx.Params("id"), includes, actorID)
+	if err != nil {
+		return utils.NotFound(ctx, "List tidak ditemukan", err.Error())
+	}
+	return utils.SuccessETag(ctx, "Data berhasil ditemukan", list, list.Version)
+}
+
+// UpdateList handles renaming a list. The request must carry the list's ETag
+// in If-Match.
+func (c *ListController) UpdateList(ctx *fiber.Ctx) error {
+	publicID, err := uuid.Parse(ctx.Params("id"))
+	if err != nil {
+		return utils.BadRequest(ctx, "Public ID tidak valid", err.Error())
+	}
+	version, err := utils.IfMatchVersion(ctx)
+	if err != nil {
+		return utils.VersionConflict(ctx, "List telah diubah oleh pengguna lain", err)
+	}
+	list := new(models.List)
+	if err := ctx.BodyParser(list); err != nil {
+		return utils.BadRequest(ctx, "Gagal memparsing permintaan", err.Error())
+	}
+	list.PublicID = publicID
+	list.Version = version
+
+	actorID, err := utils.GetUserPublicID(ctx)
+	if err != nil {
+		return utils.Unauthorized(ctx, "Error unauthorized", err.Error())
+	}
+	if err := c.service.Update(list, actorID); err != nil {
+		if errors.Is(err, models.ErrVersionConflict) {
+			return utils.VersionConflict(ctx, "List telah diubah oleh pengguna lain", err)
+		}
+		return utils.BadRequest(ctx, "Gagal update list", err.Error())
+	}
+	return utils.SuccessETag(ctx, "Berhasil update list", list, list.Version)
+}
@@ -0,0 +1,82 @@
+package controllers
+
+import (
+	"math"
+	"strconv"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/mohod24/go-project-management/services"
+	"github.com/mohod24/go-project-management/utils"
+)
+
+// NotificationController handles HTTP requests for the current user's notifications.
+type NotificationController struct {
+	service services.NotificationService
+}
+
+// NewNotificationController creates a new instance of NotificationController.
+func NewNotificationController(s services.NotificationService) *NotificationController {
+	return &NotificationController{service: s}
+}
+
+// GetNotifications lists the current user's notifications with pagination.
+func (c *NotificationController) GetNotifications(ctx *fiber.Ctx) error {
+	// /notifications?page=1&limit=10&unread=true
+	page, _ := strconv.Atoi(ctx.Query("page", "1"))
+	limit, _ := strconv.Atoi(ctx.Query("limit", "10"))
+	offset := (page - 1) * limit
+	unreadOnly := ctx.QueryBool("unread", false)
+
+	userID, err := utils.GetUserPublicID(ctx)
+	if err != nil {
+		return utils.Unauthorized(ctx, "Error unauthorized", err.Error())
+	}
+	cursorPage, err := utils.CursorParams(ctx, "notifications")
+	if err != nil {
+		return utils.BadRequest(ctx, "Cursor tidak valid", err.Error())
+	}
+	if cursorPage != nil {
+		notifications, result, err := c.service.GetAllCursor(userID, unreadOnly, *cursorPage)
+		if err != nil {
+			return utils.BadRequest(ctx, "Gagal Mengambil Data", err.Error())
+		}
+		return utils.SuccessPagination(ctx, "Data ditemukan", notifications, utils.CursorMeta("notifications", cursorPage.Limit, result))
+	}
+
+	notifications, total, err := c.service.GetAllPagination(userID, unreadOnly, limit, offset)
+	if err != nil {
+		return utils.BadRequest(ctx, "Gagal Mengambil Data", err.Error())
+	}
+
+	meta := utils.PaginationMeta{
+		Page:      page,
+		Limit:     limit,
+		Total:     int(total),
+		TotalPage: int(math.Ceil(float64(total) / float64(limit))),
+	}
+	return utils.SuccessPagination(ctx, "Data ditemukan", notifications, meta)
+}
+
+// MarkNotificationRead marks one notification as read.
+func (c *NotificationController) MarkNotificationRead(ctx *fiber.Ctx) error {
+	userID, err := utils.GetUserPublicID(ctx)
+	if err != nil {
+		return utils.Unauthorized(ctx, "Error unauthorized", err.Error())
+	}
+	if err := c.service.MarkRead(userID, ctx.Params("id")); err != nil {
+		return utils.BadRequest(ctx, "Gagal update notifikasi", err.Error())
+	}
+	return utils.Success(ctx, "Notifikasi ditandai sudah dibaca", nil)
+}
+
+// MarkAllNotificationsRead marks every notification of the current user as read.
+func (c *NotificationController) MarkAllNotificationsRead(ctx *fiber.Ctx) error {
+	userID, err := utils.GetUserPublicID(ctx)
+	if err != nil {
+		return utils.Unauthorized(ctx, "Error unauthorized", err.Error())
+	}
+	if err := c.service.MarkAllRead(userID); err != nil {
+		return utils.BadRequest(ctx, "Gagal update notifikasi", err.Error())
+	}
+	return utils.Success(ctx, "Semua notifikasi ditandai sudah dibaca", nil)
+}
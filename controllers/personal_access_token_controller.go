@@ -0,0 +1,68 @@
+package controllers
+
+import (
+	"github.com/gofiber/fiber/v2"
+	"github.com/mohod24/go-project-management/services"
+	"github.com/mohod24/go-project-management/utils"
+)
+
+// PersonalAccessTokenController handles HTTP requests for managing the
+// current user's personal access tokens.
+type PersonalAccessTokenController struct {
+	service services.PersonalAccessTokenService
+}
+
+// NewPersonalAccessTokenController creates a new instance of PersonalAccessTokenController.
+func NewPersonalAccessTokenController(s services.PersonalAccessTokenService) *PersonalAccessTokenController {
+	return &PersonalAccessTokenController{service: s}
+}
+
+// CreateToken issues a new personal access token. The token itself is only
+// shown in this response.
+func (c *PersonalAccessTokenController) CreateToken(ctx *fiber.Ctx) error {
+	var body struct {
+		Name          string   `json:"name"`
+		Scopes        []string `json:"scopes"`
+		ExpiresInDays int      `json:"expires_in_days"`
+	}
+	if err := ctx.BodyParser(&body); err != nil {
+		return utils.BadRequest(ctx, "Gagal memparsing permintaan", err.Error())
+	}
+	userID, err := utils.GetUserPublicID(ctx)
+	if err != nil {
+		return utils.Unauthorized(ctx, "Error unauthorized", err.Error())
+	}
+	token, raw, err := c.service.Create(userID, body.Name, body.Scopes, body.ExpiresInDays)
+	if err != nil {
+		return utils.BadRequest(ctx, "Gagal membuat token", err.Error())
+	}
+	return utils.Created(ctx, "Token berhasil dibuat. Simpan token ini, token hanya ditampilkan sekali", fiber.Map{
+		"token":   raw,
+		"details": token,
+	})
+}
+
+// GetTokens lists the current user's active personal access tokens.
+func (c *PersonalAccessTokenController) GetTokens(ctx *fiber.Ctx) error {
+	userID, err := utils.GetUserPublicID(ctx)
+	if err != nil {
+		return utils.Unauthorized(ctx, "Error unauthorized", err.Error())
+	}
+	tokens, err := c.service.List(userID)
+	if err != nil {
+		return utils.BadRequest(ctx, "Gagal Mengambil Data", err.Error())
+	}
+	return utils.Success(ctx, "Data ditemukan", tokens)
+}
+
+// RevokeToken revokes one of the current user's personal access tokens.
+func (c *PersonalAccessTokenController) RevokeToken(ctx *fiber.Ctx) error {
+	userID, err := utils.GetUserPublicID(ctx)
+	if err != nil {
+		return utils.Unauthorized(ctx, "Error unauthorized", err.Error())
+	}
+	if err := c.service.Revoke(userID, ctx.Params("id")); err != nil {
+		return utils.NotFound(ctx, "Token tidak ditemukan", err.Error())
+	}
+	return utils.Success(ctx, "Token berhasil dicabut", nil)
+}
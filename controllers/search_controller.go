@@ -0,0 +1,67 @@
+package controllers
+
+import (
+	"math"
+	"strconv"
+	"strings"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/mohod24/go-project-management/services"
+	"github.com/mohod24/go-project-management/utils"
+)
+
+// maxSearchLimit caps the page size of search results.
+const maxSearchLimit = 50
+
+// SearchController handles HTTP requests for full-text search.
+type SearchController struct {
+	service services.SearchService
+}
+
+// NewSearchController creates a new instance of SearchController.
+func NewSearchController(s services.SearchService) *SearchController {
+	return &SearchController{service: s}
+}
+
+// Search finds boards, cards and comments the current user can access.
+func (c *SearchController) Search(ctx *fiber.Ctx) error {
+	// /search?q="sprint planning" -draft&type=card,comment&page=1&limit=10
+	page, _ := strconv.Atoi(ctx.Query("page", "1"))
+	limit, _ := strconv.Atoi(ctx.Query("limit", "10"))
+	if page < 1 {
+		page = 1
+	}
+	if limit < 1 {
+		limit = 10
+	} else if limit > maxSearchLimit {
+		limit = maxSearchLimit
+	}
+	offset := (page - 1) * limit
+
+	query := ctx.Query("q")
+	var types []string
+	if t := ctx.Query("type"); t != "" {
+		types = strings.Split(t, ",")
+	}
+
+	userID, err := utils.GetUserPublicID(ctx)
+	if err != nil {
+		return utils.Unauthorized(ctx, "Error unauthorized", err.Error())
+	}
+	results, total, err := c.service.Search(userID, query, types, limit, offset)
+	if err != nil {
+		return utils.BadRequest(ctx, "Pencarian gagal", err.Error())
+	}
+
+	meta := utils.PaginationMeta{
+		Page:      page,
+		Limit:     limit,
+		Total:     int(total),
+		TotalPage: int(math.Ceil(float64(total) / float64(limit))),
+		Filter:    query,
+	}
+	if total == 0 {
+		return utils.NotFoundPagination(ctx, "Data tidak ditemukan", results, meta)
+	}
+	return utils.SuccessPagination(ctx, "Data ditemukan", results, meta)
+}
@@ -0,0 +1,80 @@
+package controllers
+
+import (
+	"github.com/gofiber/fiber/v2"
+	"github.com/mohod24/go-project-management/services"
+	"github.com/mohod24/go-project-management/utils"
+)
+
+// SessionController handles HTTP requests for login sessions.
+type SessionController struct {
+	service services.SessionService
+}
+
+// NewSessionController creates a new instance of SessionController.
+func NewSessionController(s services.SessionService) *SessionController {
+	return &SessionController{service: s}
+}
+
+// RefreshToken exchanges a refresh token for a new access and refresh token.
+func (c *SessionController) RefreshToken(ctx *fiber.Ctx) error {
+	var body struct {
+		RefreshToken string `json:"refresh_token"`
+	}
+	if err := ctx.BodyParser(&body); err != nil {
+		return utils.BadRequest(ctx, "Invalid Request", err.Error())
+	}
+	tokens, err := c.service.Refresh(body.RefreshToken, ctx.Get(fiber.HeaderUserAgent), ctx.IP())
+	if err != nil {
+		return utils.Unauthorized(ctx, "Error unauthorized", err.Error())
+	}
+	return utils.Success(ctx, "Token berhasil diperbarui", fiber.Map{
+		"access_token":              tokens.AccessToken,
+		"refresh_token":             tokens.RefreshToken,
+		"two_factor_setup_required": tokens.TwoFactorSetupRequired,
+	})
+}
+
+// GetMySessions lists the devices the current user is logged in on.
+func (c *SessionController) GetMySessions(ctx *fiber.Ctx) error {
+	claims, err := utils.GetClaims(ctx)
+	if err != nil {
+		return utils.Unauthorized(ctx, "Error unauthorized", err.Error())
+	}
+	userID, _ := claims["pub_id"].(string)
+	currentSessionID, _ := claims["sid"].(string)
+	sessions, err := c.service.List(userID, currentSessionID)
+	if err != nil {
+		return utils.BadRequest(ctx, "Gagal Mengambil Data", err.Error())
+	}
+	return utils.Success(ctx, "Data ditemukan", sessions)
+}
+
+// RevokeMySession logs the current user out of one device.
+func (c *SessionController) RevokeMySession(ctx *fiber.Ctx) error {
+	userID, err := utils.GetUserPublicID(ctx)
+	if err != nil {
+		return utils.Unauthorized(ctx, "Error unauthorized", err.Error())
+	}
+	if err := c.service.Revoke(userID, ctx.Params("id")); err != nil {
+		return utils.NotFound(ctx, "Sesi tidak ditemukan", err.Error())
+	}
+	return utils.Success(ctx, "Sesi berhasil diakhiri", nil)
+}
+
+// GetUserSessions lets an admin list the sessions of any user.
+func (c *SessionController) GetUserSessions(ctx *fiber.Ctx) error {
+	sessions, err := c.service.List(ctx.Params("id"), "")
+	if err != nil {
+		return utils.NotFound(ctx, "Data Not Found", err.Error())
+	}
+	return utils.Success(ctx, "Data ditemukan", sessions)
+}
+
+// RevokeUserSession lets an admin end a session of any user.
+func (c *SessionController) RevokeUserSession(ctx *fiber.Ctx) error {
+	if err := c.service.Revoke(ctx.Params("id"), ctx.Params("sessionId")); err != nil {
+		return utils.NotFound(ctx, "Sesi tidak ditemukan", err.Error())
+	}
+	return utils.Success(ctx, "Sesi berhasil diakhiri", nil)
+}
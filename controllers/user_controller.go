@@ -1,6 +1,7 @@
 package controllers
 
 import (
+	"errors"
 	"math"
 	"strconv"
 
@@ -14,12 +15,24 @@ import (
 
 // UserController handles user-related HTTP requests
 type UserController struct {
-	service services.UserService
+	service          services.UserService
+	twoFactorService services.TwoFactorService
+	oidcService      services.OIDCService
+	sessionService   services.SessionService
 }
 
+// oidcFlowCookie carries the encrypted OIDC state, nonce and PKCE verifier
+// between the redirect to the identity provider and the callback
+const oidcFlowCookie = "oidc_flow"
+
 // NewUserController creates a new instance of UserController
-func NewUserController(s services.UserService) *UserController {
-	return &UserController{service: s}
+func NewUserController(
+	s services.UserService,
+	tfs services.TwoFactorService,
+	oidc services.OIDCService,
+	ss services.SessionService,
+) *UserController {
+	return &UserController{service: s, twoFactorService: tfs, oidcService: oidc, sessionService: ss}
 }
 
 // Register handles user registration
@@ -31,8 +44,15 @@ func (c *UserController) Register(ctx *fiber.Ctx) error {
 		return utils.BadRequest(ctx, "Gagal Parsing Data", err.Error())
 	}
 
+	// Invite token from a board invite link, either as ?invite= or in the body
+	var body struct {
+		InviteToken string `json:"invite_token"`
+	}
+	_ = ctx.BodyParser(&body)
+	inviteToken := ctx.Query("invite", body.InviteToken)
+
 	// Call the service to register the user
-	if err := c.service.Register(user); err != nil {
+	if err := c.service.Register(user, inviteToken); err != nil {
 		return utils.BadRequest(ctx, "Registrasi Gagal", err.Error())
 	}
 
@@ -52,20 +72,112 @@ func (c *UserController) Login(ctx *fiber.Ctx) error {
 		return utils.BadRequest(ctx, "Invalid Request", err.Error())
 	}
 
-	user, err := c.service.Login(body.Email, body.Password)
+	user, err := c.service.Login(body.Email, body.Password, ctx.IP())
 	if err != nil {
+		var throttled *services.LoginThrottledError
+		if errors.As(err, &throttled) {
+			ctx.Set(fiber.HeaderRetryAfter, strconv.Itoa(int(math.Ceil(throttled.RetryAfter.Seconds()))))
+			return utils.TooManyRequests(ctx, "Terlalu banyak percobaan login", err.Error())
+		}
 		return utils.Unauthorized(ctx, "Login Failed", err.Error())
 	}
+	return c.completeLogin(ctx, user)
+}
+
+// OIDCLogin redirects to the identity provider to start single sign-on
+func (c *UserController) OIDCLogin(ctx *fiber.Ctx) error {
+	redirectURL, flowState, err := c.oidcService.AuthorizationURL()
+	if err != nil {
+		if errors.Is(err, services.ErrOIDCDisabled) {
+			return utils.NotFound(ctx, "SSO tidak tersedia", err.Error())
+		}
+		return utils.InternalServerError(ctx, "Gagal memulai SSO", err.Error())
+	}
+	ctx.Cookie(&fiber.Cookie{
+		Name:     oidcFlowCookie,
+		Value:    flowState,
+		Path:     "/v1/auth/oidc",
+		MaxAge:   600,
+		HTTPOnly: true,
+		Secure:   ctx.Protocol() == "https",
+		SameSite: fiber.CookieSameSiteLaxMode,
+	})
+	return ctx.Redirect(redirectURL, fiber.StatusFound)
+}
+
+// OIDCCallback completes single sign-on and issues the API's own tokens
+func (c *UserController) OIDCCallback(ctx *fiber.Ctx) error {
+	if errCode := ctx.Query("error"); errCode != "" {
+		return utils.Unauthorized(ctx, "Login Failed", errCode+": "+ctx.Query("error_description"))
+	}
+	flowState := ctx.Cookies(oidcFlowCookie)
+	ctx.ClearCookie(oidcFlowCookie)
 
-	token, _ := utils.GenerateToken(user.InternalID, user.Role, user.Email, user.PublicID)
-	refreshToken, _ := utils.GenerateRefreshToken(user.InternalID)
+	user, err := c.oidcService.Exchange(ctx.Query("code"), ctx.Query("state"), flowState)
+	if err != nil {
+		if errors.Is(err, services.ErrOIDCDisabled) {
+			return utils.NotFound(ctx, "SSO tidak tersedia", err.Error())
+		}
+		return utils.Unauthorized(ctx, "Login Failed", err.Error())
+	}
+	return c.completeLogin(ctx, user)
+}
+
+// completeLogin issues tokens for an authenticated user, or a two-factor
+// challenge when the user has TOTP enabled
+func (c *UserController) completeLogin(ctx *fiber.Ctx, user *models.User) error {
+	// Step one of a two-step login: the client exchanges the challenge
+	// token and a TOTP or recovery code at /v1/auth/login/2fa
+	if user.TOTPEnabled {
+		challenge, err := c.twoFactorService.CreateChallenge(user)
+		if err != nil {
+			return utils.InternalServerError(ctx, "Login Failed", err.Error())
+		}
+		return utils.Success(ctx, "Two-factor authentication required", fiber.Map{
+			"two_factor_required": true,
+			"challenge_token":     challenge,
+		})
+	}
+	return c.issueTokens(ctx, user)
+}
+
+// LoginTwoFactor completes a two-step login with a TOTP or recovery code
+func (c *UserController) LoginTwoFactor(ctx *fiber.Ctx) error {
+	var body struct {
+		ChallengeToken string `json:"challenge_token"`
+		Code           string `json:"code"`
+	}
+	if err := ctx.BodyParser(&body); err != nil {
+		return utils.BadRequest(ctx, "Invalid Request", err.Error())
+	}
+
+	user, err := c.twoFactorService.VerifyChallenge(body.ChallengeToken, body.Code, ctx.IP())
+	if err != nil {
+		var throttled *services.LoginThrottledError
+		if errors.As(err, &throttled) {
+			ctx.Set(fiber.HeaderRetryAfter, strconv.Itoa(int(math.Ceil(throttled.RetryAfter.Seconds()))))
+			return utils.TooManyRequests(ctx, "Terlalu banyak percobaan login", err.Error())
+		}
+		return utils.Unauthorized(ctx, "Login Failed", err.Error())
+	}
+	return c.issueTokens(ctx, user)
+}
+
+// issueTokens starts a session for the current device and responds with its
+// access and refresh token
+func (c *UserController) issueTokens(ctx *fiber.Ctx, user *models.User) error {
+	tokens, err := c.sessionService.Start(user, ctx.Get(fiber.HeaderUserAgent), ctx.IP())
+	if err != nil {
+		return utils.InternalServerError(ctx, "Login Failed", err.Error())
+	}
 
 	var userResp models.UserResponse
 	_ = copier.Copy(&userResp, &user)
 	return utils.Success(ctx, "Login Succesful", fiber.Map{
-		"access_token":  token,
-		"refresh_token": refreshToken,
-		"user":          userResp,
+		"access_token":              tokens.AccessToken,
+		"refresh_token":             tokens.RefreshToken,
+		"two_factor_setup_required": tokens.TwoFactorSetupRequired,
+		"user":                      userResp,
 	})
 }
 
@@ -96,6 +208,26 @@ func (c *UserController) GetUserPagination(ctx *fiber.Ctx) error {
 	filter := ctx.Query("filter", "")
 	sort := ctx.Query("sort", "")
 
+	// /users/page?cursor=&limit=10 memakai keyset cursor, bukan nomor halaman
+	cursorPage, err := utils.CursorParams(ctx, "users")
+	if err != nil {
+		return utils.BadRequest(ctx, "Cursor tidak valid", err.Error())
+	}
+	if cursorPage != nil {
+		if sort != "" {
+			return utils.BadRequest(ctx, "Gagal Mengambil Data", "sort is not supported with cursor pagination")
+		}
+		users, result, err := c.service.GetAllCursor(filter, *cursorPage)
+		if err != nil {
+			return utils.BadRequest(ctx, "Gagal Mengambil Data", err.Error())
+		}
+		var userResp []models.UserResponse
+		_ = copier.Copy(&userResp, &users)
+		meta := utils.CursorMeta("users", cursorPage.Limit, result)
+		meta.Filter = filter
+		return utils.SuccessPagination(ctx, "Data ditemukan", userResp, meta)
+	}
+
 	users, total, err := c.service.GetAllPagination(filter, sort, limit, offset)
 	if err != nil {
 		return utils.BadRequest(ctx, "Gagal Mengambil Data", err.Error())
@@ -150,6 +282,66 @@ func (c *UserController) UpdateUser(ctx *fiber.Ctx) error {
 	return utils.Success(ctx, "Berhasil Update data", userResp)
 }
 
+// UpdatePreferences updates the current user's notification preferences
+func (c *UserController) UpdatePreferences(ctx *fiber.Ctx) error {
+	var body struct {
+		ReminderPreference string `json:"reminder_preference"`
+		Locale             string `json:"locale"`
+	}
+	if err := ctx.BodyParser(&body); err != nil {
+		return utils.BadRequest(ctx, "Gagal Parsing Data", err.Error())
+	}
+	publicID, err := utils.GetUserPublicID(ctx)
+	if err != nil {
+		return utils.Unauthorized(ctx, "Error unauthorized", err.Error())
+	}
+	if err := c.service.UpdatePreferences(publicID, body.ReminderPreference, body.Locale); err != nil {
+		return utils.BadRequest(ctx, "Gagal Update Data", err.Error())
+	}
+
+	user, err := c.service.GetByPublicID(publicID)
+	if err != nil {
+		return utils.InternalServerError(ctx, "Gagal Ambil Data", err.Error())
+	}
+	var userResp models.UserResponse
+	_ = copier.Copy(&userResp, &user)
+	return utils.Success(ctx, "Berhasil Update data", userResp)
+}
+
+// ChangePassword changes the current user's password and logs out every
+// session and personal access token. The current device gets new tokens.
+func (c *UserController) ChangePassword(ctx *fiber.Ctx) error {
+	var body struct {
+		CurrentPassword string `json:"current_password"`
+		NewPassword     string `json:"new_password"`
+	}
+	if err := ctx.BodyParser(&body); err != nil {
+		return utils.BadRequest(ctx, "Gagal Parsing Data", err.Error())
+	}
+	publicID, err := utils.GetUserPublicID(ctx)
+	if err != nil {
+		return utils.Unauthorized(ctx, "Error unauthorized", err.Error())
+	}
+	user, err := c.service.ChangePassword(publicID, body.CurrentPassword, body.NewPassword, ctx.IP())
+	if err != nil {
+		var throttled *services.LoginThrottledError
+		if errors.As(err, &throttled) {
+			ctx.Set(fiber.HeaderRetryAfter, strconv.Itoa(int(math.Ceil(throttled.RetryAfter.Seconds()))))
+			return utils.TooManyRequests(ctx, "Terlalu banyak percobaan", err.Error())
+		}
+		return utils.BadRequest(ctx, "Gagal mengubah password", err.Error())
+	}
+	tokens, err := c.sessionService.Start(user, ctx.Get(fiber.HeaderUserAgent), ctx.IP())
+	if err != nil {
+		return utils.InternalServerError(ctx, "Gagal membuat sesi baru", err.Error())
+	}
+	return utils.Success(ctx, "Password berhasil diubah", fiber.Map{
+		"access_token":              tokens.AccessToken,
+		"refresh_token":             tokens.RefreshToken,
+		"two_factor_setup_required": tokens.TwoFactorSetupRequired,
+	})
+}
+
 // DeleteUser deletes a user by their internal ID
 func (c *UserController) DeleteUser(ctx *fiber.Ctx) error {
 	id, _ := strconv.Atoi(ctx.Params("id"))
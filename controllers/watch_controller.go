@@ -0,0 +1,63 @@
+package controllers
+
+import (
+	"github.com/gofiber/fiber/v2"
+	"github.com/mohod24/go-project-management/services"
+	"github.com/mohod24/go-project-management/utils"
+)
+
+// WatchController handles watching and unwatching boards, lists and cards.
+type WatchController struct {
+	service services.WatchService
+}
+
+// NewWatchController creates a new instance of WatchController.
+func NewWatchController(s services.WatchService) *WatchController {
+	return &WatchController{service: s}
+}
+
+// Watch returns a handler that subscribes the current user to the entity
+// identified by the :id route parameter.
+func (c *WatchController) Watch(entityType string) fiber.Handler {
+	return func(ctx *fiber.Ctx) error {
+		userID, err := utils.GetUserPublicID(ctx)
+		if err != nil {
+			return utils.Unauthorized(ctx, "Error unauthorized", err.Error())
+		}
+		if err := c.service.Watch(userID, entityType, ctx.Params("id")); err != nil {
+			return utils.BadRequest(ctx, "Gagal watch "+entityType, err.Error())
+		}
+		return utils.Success(ctx, "Berhasil watch "+entityType, fiber.Map{"watching": true})
+	}
+}
+
+// Unwatch returns a handler that removes the current user's subscription to
+// the entity identified by the :id route parameter.
+func (c *WatchController) Unwatch(entityType string) fiber.Handler {
+	return func(ctx *fiber.Ctx) error {
+		userID, err := utils.GetUserPublicID(ctx)
+		if err != nil {
+			return utils.Unauthorized(ctx, "Error unauthorized", err.Error())
+		}
+		if err := c.service.Unwatch(userID, entityType, ctx.Params("id")); err != nil {
+			return utils.BadRequest(ctx, "Gagal unwatch "+entityType, err.Error())
+		}
+		return utils.Success(ctx, "Berhasil unwatch "+entityType, fiber.Map{"watching": false})
+	}
+}
+
+// WatchStatus returns a handler that reports whether the current user watches
+// the entity identified by the :id route parameter.
+func (c *WatchController) WatchStatus(entityType string) fiber.Handler {
+	return func(ctx *fiber.Ctx) error {
+		userID, err := utils.GetUserPublicID(ctx)
+		if err != nil {
+			return utils.Unauthorized(ctx, "Error unauthorized", err.Error())
+		}
+		watching, err := c.service.IsWatching(userID, entityType, ctx.Params("id"))
+		if err != nil {
+			return utils.BadRequest(ctx, "Gagal Mengambil Data", err.Error())
+		}
+		return utils.Success(ctx, "Data ditemukan", fiber.Map{"watching": watching})
+	}
+}
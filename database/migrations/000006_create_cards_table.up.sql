@@ -0,0 +1,18 @@
+CREATE TABLE cards (
+    internal_id      BIGSERIAL PRIMARY KEY,
+    public_id        UUID NOT NULL DEFAULT gen_random_uuid(),
+    list_internal_id BIGINT NOT NULL REFERENCES lists(internal_id) ON DELETE CASCADE,
+    title            VARCHAR(255) NOT NULL,
+    description      TEXT,
+    due_date         TIMESTAMP WITH TIME ZONE,
+    position         INT NOT NULL DEFAULT 0,
+    created_at       TIMESTAMP NOT NULL DEFAULT NOW(),
+
+    CONSTRAINT cards_public_id_unique UNIQUE (public_id)
+);
+
+CREATE TABLE card_assignees (
+    card_internal_id BIGINT NOT NULL REFERENCES cards(internal_id) ON DELETE CASCADE,
+    user_internal_id BIGINT NOT NULL REFERENCES users(internal_id) ON DELETE CASCADE,
+    PRIMARY KEY (card_internal_id, user_internal_id)
+);
@@ -0,0 +1,12 @@
+CREATE TABLE comments (
+    internal_id      BIGSERIAL PRIMARY KEY,
+    public_id        UUID NOT NULL DEFAULT gen_random_uuid(),
+    card_internal_id BIGINT NOT NULL REFERENCES cards(internal_id) ON DELETE CASCADE,
+    card_public_id   UUID NOT NULL,
+    user_internal_id BIGINT NOT NULL REFERENCES users(internal_id) ON DELETE CASCADE,
+    user_public_id   UUID NOT NULL,
+    message          TEXT NOT NULL,
+    created_at       TIMESTAMP NOT NULL DEFAULT NOW(),
+
+    CONSTRAINT comments_public_id_unique UNIQUE (public_id)
+);
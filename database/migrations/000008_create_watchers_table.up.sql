@@ -0,0 +1,9 @@
+CREATE TABLE watchers (
+    user_internal_id   BIGINT NOT NULL REFERENCES users(internal_id) ON DELETE CASCADE,
+    entity_type        VARCHAR(20) NOT NULL,
+    entity_internal_id BIGINT NOT NULL,
+    created_at         TIMESTAMP NOT NULL DEFAULT NOW(),
+    PRIMARY KEY (user_internal_id, entity_type, entity_internal_id)
+);
+
+CREATE INDEX idx_watchers_entity ON watchers (entity_type, entity_internal_id);
@@ -0,0 +1,16 @@
+CREATE TABLE notifications (
+    internal_id       BIGSERIAL PRIMARY KEY,
+    public_id         UUID NOT NULL DEFAULT gen_random_uuid(),
+    user_internal_id  BIGINT NOT NULL REFERENCES users(internal_id) ON DELETE CASCADE,
+    actor_internal_id BIGINT REFERENCES users(internal_id) ON DELETE SET NULL,
+    entity_type       VARCHAR(20) NOT NULL,
+    entity_public_id  UUID NOT NULL,
+    action            VARCHAR(50) NOT NULL,
+    message           TEXT NOT NULL,
+    read_at           TIMESTAMP WITH TIME ZONE,
+    created_at        TIMESTAMP NOT NULL DEFAULT NOW(),
+
+    CONSTRAINT notifications_public_id_unique UNIQUE (public_id)
+);
+
+CREATE INDEX idx_notifications_user ON notifications (user_internal_id, created_at DESC);
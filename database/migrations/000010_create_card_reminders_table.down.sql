@@ -0,0 +1,2 @@
+DROP INDEX IF EXISTS idx_cards_due_date;
+DROP TABLE IF EXISTS card_reminders;
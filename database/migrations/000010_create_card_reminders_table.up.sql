@@ -0,0 +1,10 @@
+CREATE TABLE card_reminders (
+    card_internal_id BIGINT NOT NULL REFERENCES cards(internal_id) ON DELETE CASCADE,
+    user_internal_id BIGINT NOT NULL REFERENCES users(internal_id) ON DELETE CASCADE,
+    kind             VARCHAR(50) NOT NULL,
+    due_date         TIMESTAMP WITH TIME ZONE NOT NULL,
+    sent_at          TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    PRIMARY KEY (card_internal_id, user_internal_id, kind, due_date)
+);
+
+CREATE INDEX idx_cards_due_date ON cards (due_date) WHERE due_date IS NOT NULL;
@@ -0,0 +1,2 @@
+ALTER TABLE users
+ADD COLUMN reminder_preference VARCHAR(20) NOT NULL DEFAULT 'all';
@@ -0,0 +1,17 @@
+CREATE TABLE board_invites (
+    internal_id            BIGSERIAL PRIMARY KEY,
+    public_id              UUID NOT NULL DEFAULT gen_random_uuid(),
+    board_internal_id      BIGINT NOT NULL REFERENCES boards(internal_id) ON DELETE CASCADE,
+    email                  VARCHAR(255) NOT NULL,
+    invited_by_internal_id BIGINT NOT NULL REFERENCES users(internal_id) ON DELETE CASCADE,
+    user_internal_id       BIGINT REFERENCES users(internal_id) ON DELETE CASCADE,
+    status                 VARCHAR(20) NOT NULL DEFAULT 'pending',
+    expires_at             TIMESTAMP WITH TIME ZONE NOT NULL,
+    accepted_at            TIMESTAMP WITH TIME ZONE,
+    created_at             TIMESTAMP NOT NULL DEFAULT NOW(),
+
+    CONSTRAINT board_invites_public_id_unique UNIQUE (public_id)
+);
+
+-- hanya boleh ada satu undangan pending per board dan email
+CREATE UNIQUE INDEX idx_board_invites_pending ON board_invites (board_internal_id, email) WHERE status = 'pending';
@@ -0,0 +1,4 @@
+ALTER TABLE users
+DROP COLUMN IF EXISTS token_version;
+
+DROP TABLE IF EXISTS password_reset_tokens;
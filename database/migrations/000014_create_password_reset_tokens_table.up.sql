@@ -0,0 +1,13 @@
+CREATE TABLE password_reset_tokens (
+    internal_id      BIGSERIAL PRIMARY KEY,
+    user_internal_id BIGINT NOT NULL REFERENCES users(internal_id) ON DELETE CASCADE,
+    token_hash       VARCHAR(64) NOT NULL,
+    expires_at       TIMESTAMP WITH TIME ZONE NOT NULL,
+    used_at          TIMESTAMP WITH TIME ZONE,
+    created_at       TIMESTAMP NOT NULL DEFAULT NOW(),
+
+    CONSTRAINT password_reset_tokens_hash_unique UNIQUE (token_hash)
+);
+
+ALTER TABLE users
+ADD COLUMN token_version INT NOT NULL DEFAULT 0;
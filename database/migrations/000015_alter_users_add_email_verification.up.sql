@@ -0,0 +1,7 @@
+ALTER TABLE users
+ADD COLUMN email_verified BOOLEAN NOT NULL DEFAULT FALSE,
+ADD COLUMN email_verified_at TIMESTAMP WITH TIME ZONE,
+ADD COLUMN verification_sent_at TIMESTAMP WITH TIME ZONE;
+
+-- akun yang sudah ada sebelum fitur verifikasi dianggap terverifikasi
+UPDATE users SET email_verified = TRUE, email_verified_at = NOW();
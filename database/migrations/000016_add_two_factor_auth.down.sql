@@ -0,0 +1,7 @@
+DROP TABLE IF EXISTS recovery_codes;
+
+ALTER TABLE users
+DROP COLUMN IF EXISTS two_factor_required,
+DROP COLUMN IF EXISTS totp_last_step,
+DROP COLUMN IF EXISTS totp_enabled,
+DROP COLUMN IF EXISTS totp_secret;
@@ -0,0 +1,15 @@
+ALTER TABLE users
+ADD COLUMN totp_secret TEXT,
+ADD COLUMN totp_enabled BOOLEAN NOT NULL DEFAULT FALSE,
+ADD COLUMN totp_last_step BIGINT NOT NULL DEFAULT 0,
+ADD COLUMN two_factor_required BOOLEAN NOT NULL DEFAULT FALSE;
+
+CREATE TABLE recovery_codes (
+    internal_id      BIGSERIAL PRIMARY KEY,
+    user_internal_id BIGINT NOT NULL REFERENCES users(internal_id) ON DELETE CASCADE,
+    code_hash        VARCHAR(64) NOT NULL,
+    used_at          TIMESTAMP WITH TIME ZONE,
+    created_at       TIMESTAMP NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX idx_recovery_codes_user ON recovery_codes (user_internal_id);
@@ -0,0 +1,11 @@
+CREATE TABLE login_throttles (
+    scope          VARCHAR(16) NOT NULL,
+    subject        VARCHAR(255) NOT NULL,
+    failures       INT NOT NULL DEFAULT 0,
+    last_failed_at TIMESTAMP WITH TIME ZONE NOT NULL,
+    locked_until   TIMESTAMP WITH TIME ZONE,
+
+    PRIMARY KEY (scope, subject)
+);
+
+CREATE INDEX idx_login_throttles_locked_until ON login_throttles (locked_until);
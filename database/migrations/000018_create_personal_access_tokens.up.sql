@@ -0,0 +1,18 @@
+CREATE TABLE personal_access_tokens (
+    internal_id      BIGSERIAL PRIMARY KEY,
+    public_id        UUID NOT NULL DEFAULT gen_random_uuid(),
+    user_internal_id BIGINT NOT NULL REFERENCES users(internal_id) ON DELETE CASCADE,
+    name             VARCHAR(100) NOT NULL,
+    token_hash       VARCHAR(64) NOT NULL,
+    token_prefix     VARCHAR(16) NOT NULL,
+    scopes           TEXT[] NOT NULL DEFAULT '{}',
+    expires_at       TIMESTAMP WITH TIME ZONE,
+    last_used_at     TIMESTAMP WITH TIME ZONE,
+    revoked_at       TIMESTAMP WITH TIME ZONE,
+    created_at       TIMESTAMP NOT NULL DEFAULT NOW(),
+
+    CONSTRAINT personal_access_tokens_public_id_unique UNIQUE (public_id),
+    CONSTRAINT personal_access_tokens_hash_unique UNIQUE (token_hash)
+);
+
+CREATE INDEX idx_personal_access_tokens_user ON personal_access_tokens (user_internal_id);
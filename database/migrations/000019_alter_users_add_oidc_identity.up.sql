@@ -0,0 +1,6 @@
+ALTER TABLE users
+ADD COLUMN oidc_issuer VARCHAR(255),
+ADD COLUMN oidc_subject VARCHAR(255);
+
+CREATE UNIQUE INDEX idx_users_oidc_identity ON users (oidc_issuer, oidc_subject)
+WHERE oidc_subject IS NOT NULL;
@@ -0,0 +1,16 @@
+CREATE TABLE sessions (
+    internal_id        BIGSERIAL PRIMARY KEY,
+    public_id          UUID NOT NULL DEFAULT gen_random_uuid(),
+    user_internal_id   BIGINT NOT NULL REFERENCES users(internal_id) ON DELETE CASCADE,
+    refresh_token_hash VARCHAR(64) NOT NULL,
+    user_agent         VARCHAR(255) NOT NULL DEFAULT '',
+    ip_address         VARCHAR(45) NOT NULL DEFAULT '',
+    created_at         TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    last_used_at       TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    expires_at         TIMESTAMP WITH TIME ZONE NOT NULL,
+    revoked_at         TIMESTAMP WITH TIME ZONE,
+
+    CONSTRAINT sessions_public_id_unique UNIQUE (public_id)
+);
+
+CREATE INDEX idx_sessions_user ON sessions (user_internal_id);
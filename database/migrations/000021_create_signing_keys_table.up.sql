@@ -0,0 +1,11 @@
+CREATE TABLE signing_keys (
+    internal_id  BIGSERIAL PRIMARY KEY,
+    kid          VARCHAR(64) NOT NULL,
+    algorithm    VARCHAR(16) NOT NULL,
+    private_key  TEXT NOT NULL,
+    created_at   TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    activates_at TIMESTAMP WITH TIME ZONE NOT NULL,
+    expires_at   TIMESTAMP WITH TIME ZONE,
+
+    CONSTRAINT signing_keys_kid_unique UNIQUE (kid)
+);
@@ -0,0 +1,8 @@
+CREATE TABLE password_histories (
+    internal_id      BIGSERIAL PRIMARY KEY,
+    user_internal_id BIGINT NOT NULL REFERENCES users(internal_id) ON DELETE CASCADE,
+    password_hash    VARCHAR(255) NOT NULL,
+    created_at       TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX idx_password_history_user ON password_histories (user_internal_id, created_at DESC);
@@ -0,0 +1,3 @@
+ALTER TABLE users
+ADD COLUMN pending_email VARCHAR(255),
+ADD COLUMN avatar_key VARCHAR(255);
@@ -0,0 +1,8 @@
+DELETE FROM comments WHERE user_internal_id IS NULL;
+
+ALTER TABLE comments
+DROP CONSTRAINT IF EXISTS comments_user_internal_id_fkey,
+ADD CONSTRAINT comments_user_internal_id_fkey
+    FOREIGN KEY (user_internal_id) REFERENCES users(internal_id) ON DELETE CASCADE,
+ALTER COLUMN user_internal_id SET NOT NULL,
+ALTER COLUMN user_public_id SET NOT NULL;
@@ -0,0 +1,8 @@
+-- Comments outlive their author: when a user is purged the comment stays,
+-- anonymized, instead of being deleted with the account.
+ALTER TABLE comments
+ALTER COLUMN user_internal_id DROP NOT NULL,
+ALTER COLUMN user_public_id DROP NOT NULL,
+DROP CONSTRAINT IF EXISTS comments_user_internal_id_fkey,
+ADD CONSTRAINT comments_user_internal_id_fkey
+    FOREIGN KEY (user_internal_id) REFERENCES users(internal_id) ON DELETE SET NULL;
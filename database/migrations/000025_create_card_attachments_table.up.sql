@@ -0,0 +1,12 @@
+CREATE TABLE card_attachments (
+    internal_id      BIGSERIAL PRIMARY KEY,
+    public_id        UUID NOT NULL DEFAULT gen_random_uuid(),
+    card_internal_id BIGINT NOT NULL REFERENCES cards(internal_id) ON DELETE CASCADE,
+    user_internal_id BIGINT NOT NULL REFERENCES users(internal_id) ON DELETE CASCADE,
+    file             TEXT NOT NULL,
+    created_at       TIMESTAMP NOT NULL DEFAULT NOW(),
+
+    CONSTRAINT card_attachments_public_id_unique UNIQUE (public_id)
+);
+
+CREATE INDEX idx_card_attachments_card ON card_attachments (card_internal_id);
@@ -0,0 +1,7 @@
+DROP INDEX IF EXISTS idx_comments_search_vector;
+DROP INDEX IF EXISTS idx_cards_search_vector;
+DROP INDEX IF EXISTS idx_boards_search_vector;
+
+ALTER TABLE comments DROP COLUMN IF EXISTS search_vector;
+ALTER TABLE cards DROP COLUMN IF EXISTS search_vector;
+ALTER TABLE boards DROP COLUMN IF EXISTS search_vector;
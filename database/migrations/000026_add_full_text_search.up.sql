@@ -0,0 +1,22 @@
+-- 'simple' configuration: content is written in both English and Indonesian,
+-- so words are matched without language specific stemming.
+ALTER TABLE boards
+ADD COLUMN search_vector TSVECTOR GENERATED ALWAYS AS (
+    setweight(to_tsvector('simple', coalesce(title, '')), 'A') ||
+    setweight(to_tsvector('simple', coalesce(description, '')), 'B')
+) STORED;
+
+ALTER TABLE cards
+ADD COLUMN search_vector TSVECTOR GENERATED ALWAYS AS (
+    setweight(to_tsvector('simple', coalesce(title, '')), 'A') ||
+    setweight(to_tsvector('simple', coalesce(description, '')), 'B')
+) STORED;
+
+ALTER TABLE comments
+ADD COLUMN search_vector TSVECTOR GENERATED ALWAYS AS (
+    to_tsvector('simple', coalesce(message, ''))
+) STORED;
+
+CREATE INDEX idx_boards_search_vector ON boards USING GIN (search_vector);
+CREATE INDEX idx_cards_search_vector ON cards USING GIN (search_vector);
+CREATE INDEX idx_comments_search_vector ON comments USING GIN (search_vector);
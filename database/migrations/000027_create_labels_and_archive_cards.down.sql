@@ -0,0 +1,5 @@
+ALTER TABLE cards
+DROP COLUMN IF EXISTS archived_at;
+
+DROP TABLE IF EXISTS card_labels;
+DROP TABLE IF EXISTS labels;
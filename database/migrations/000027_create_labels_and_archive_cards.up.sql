@@ -0,0 +1,20 @@
+CREATE TABLE labels (
+    internal_id       BIGSERIAL PRIMARY KEY,
+    public_id         UUID NOT NULL DEFAULT gen_random_uuid(),
+    board_internal_id BIGINT NOT NULL REFERENCES boards(internal_id) ON DELETE CASCADE,
+    name              VARCHAR(100) NOT NULL,
+    color             VARCHAR(20) NOT NULL DEFAULT '',
+
+    CONSTRAINT labels_public_id_unique UNIQUE (public_id)
+);
+
+CREATE UNIQUE INDEX idx_labels_board_name ON labels (board_internal_id, lower(name));
+
+CREATE TABLE card_labels (
+    card_internal_id  BIGINT NOT NULL REFERENCES cards(internal_id) ON DELETE CASCADE,
+    label_internal_id BIGINT NOT NULL REFERENCES labels(internal_id) ON DELETE CASCADE,
+    PRIMARY KEY (card_internal_id, label_internal_id)
+);
+
+ALTER TABLE cards
+ADD COLUMN archived_at TIMESTAMP WITH TIME ZONE;
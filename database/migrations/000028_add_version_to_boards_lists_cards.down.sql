@@ -0,0 +1,8 @@
+ALTER TABLE cards
+DROP COLUMN IF EXISTS version;
+
+ALTER TABLE lists
+DROP COLUMN IF EXISTS version;
+
+ALTER TABLE boards
+DROP COLUMN IF EXISTS version;
@@ -0,0 +1,8 @@
+ALTER TABLE boards
+ADD COLUMN version BIGINT NOT NULL DEFAULT 1;
+
+ALTER TABLE lists
+ADD COLUMN version BIGINT NOT NULL DEFAULT 1;
+
+ALTER TABLE cards
+ADD COLUMN version BIGINT NOT NULL DEFAULT 1;
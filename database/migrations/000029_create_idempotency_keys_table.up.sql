@@ -0,0 +1,15 @@
+CREATE TABLE idempotency_keys (
+    internal_id      BIGSERIAL PRIMARY KEY,
+    user_internal_id BIGINT NOT NULL REFERENCES users(internal_id) ON DELETE CASCADE,
+    key              VARCHAR(255) NOT NULL,
+    request_hash     VARCHAR(64) NOT NULL,
+    status_code      INT,
+    content_type     VARCHAR(255) NOT NULL DEFAULT '',
+    response_body    BYTEA,
+    expires_at       TIMESTAMP WITH TIME ZONE NOT NULL,
+    created_at       TIMESTAMP NOT NULL DEFAULT NOW(),
+
+    CONSTRAINT idempotency_keys_user_key_unique UNIQUE (user_internal_id, key)
+);
+
+CREATE INDEX idx_idempotency_keys_expires_at ON idempotency_keys (expires_at);
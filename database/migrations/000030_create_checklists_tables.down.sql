@@ -0,0 +1,6 @@
+ALTER TABLE cards
+DROP COLUMN IF EXISTS checklist_checked,
+DROP COLUMN IF EXISTS checklist_total;
+
+DROP TABLE IF EXISTS checklist_items;
+DROP TABLE IF EXISTS checklists;
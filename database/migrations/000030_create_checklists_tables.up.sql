@@ -0,0 +1,35 @@
+CREATE TABLE checklists (
+    internal_id      BIGSERIAL PRIMARY KEY,
+    public_id        UUID NOT NULL DEFAULT gen_random_uuid(),
+    card_internal_id BIGINT NOT NULL REFERENCES cards(internal_id) ON DELETE CASCADE,
+    title            VARCHAR(255) NOT NULL,
+    position         INT NOT NULL DEFAULT 0,
+    created_at       TIMESTAMP NOT NULL DEFAULT NOW(),
+
+    CONSTRAINT checklists_public_id_unique UNIQUE (public_id)
+);
+
+CREATE INDEX idx_checklists_card ON checklists (card_internal_id, position);
+
+CREATE TABLE checklist_items (
+    internal_id           BIGSERIAL PRIMARY KEY,
+    public_id             UUID NOT NULL DEFAULT gen_random_uuid(),
+    checklist_internal_id BIGINT NOT NULL REFERENCES checklists(internal_id) ON DELETE CASCADE,
+    title                 VARCHAR(255) NOT NULL,
+    position              INT NOT NULL DEFAULT 0,
+    assignee_internal_id  BIGINT REFERENCES users(internal_id) ON DELETE SET NULL,
+    assignee_public_id    UUID,
+    due_date              TIMESTAMP WITH TIME ZONE,
+    checked_at            TIMESTAMP WITH TIME ZONE,
+    checked_by_public_id  UUID,
+    created_at            TIMESTAMP NOT NULL DEFAULT NOW(),
+
+    CONSTRAINT checklist_items_public_id_unique UNIQUE (public_id)
+);
+
+CREATE INDEX idx_checklist_items_checklist ON checklist_items (checklist_internal_id, position);
+
+-- progres checklist disimpan di card agar setiap payload card memuatnya tanpa query tambahan
+ALTER TABLE cards
+ADD COLUMN checklist_total INT NOT NULL DEFAULT 0,
+ADD COLUMN checklist_checked INT NOT NULL DEFAULT 0;
@@ -0,0 +1,2 @@
+DROP TABLE IF EXISTS card_custom_field_values;
+DROP TABLE IF EXISTS custom_fields;
@@ -0,0 +1,29 @@
+CREATE TABLE custom_fields (
+    internal_id       BIGSERIAL PRIMARY KEY,
+    public_id         UUID NOT NULL DEFAULT gen_random_uuid(),
+    board_internal_id BIGINT NOT NULL REFERENCES boards(internal_id) ON DELETE CASCADE,
+    name              VARCHAR(100) NOT NULL,
+    key               VARCHAR(100) NOT NULL,
+    type              VARCHAR(20) NOT NULL,
+    options           JSONB NOT NULL DEFAULT '[]',
+    position          INT NOT NULL DEFAULT 0,
+    created_at        TIMESTAMP NOT NULL DEFAULT NOW(),
+
+    CONSTRAINT custom_fields_public_id_unique UNIQUE (public_id),
+    CONSTRAINT custom_fields_board_key_unique UNIQUE (board_internal_id, key),
+    CONSTRAINT custom_fields_type_check CHECK (type IN ('text', 'number', 'date', 'dropdown', 'checkbox'))
+);
+
+-- satu kolom per tipe supaya filter dan sort memakai perbandingan yang benar
+CREATE TABLE card_custom_field_values (
+    card_internal_id         BIGINT NOT NULL REFERENCES cards(internal_id) ON DELETE CASCADE,
+    custom_field_internal_id BIGINT NOT NULL REFERENCES custom_fields(internal_id) ON DELETE CASCADE,
+    custom_field_public_id   UUID NOT NULL,
+    text_value               TEXT,
+    number_value             DOUBLE PRECISION,
+    date_value               TIMESTAMP WITH TIME ZONE,
+    bool_value               BOOLEAN,
+    PRIMARY KEY (card_internal_id, custom_field_internal_id)
+);
+
+CREATE INDEX idx_card_custom_field_values_field ON card_custom_field_values (custom_field_internal_id);
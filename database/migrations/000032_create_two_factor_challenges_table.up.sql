@@ -0,0 +1,14 @@
+-- challenge login dua langkah disimpan supaya percobaan kode bisa dihitung
+CREATE TABLE two_factor_challenges (
+    internal_id      BIGSERIAL PRIMARY KEY,
+    user_internal_id BIGINT NOT NULL REFERENCES users(internal_id) ON DELETE CASCADE,
+    token_hash       VARCHAR(64) NOT NULL,
+    token_version    INT NOT NULL,
+    failures         INT NOT NULL DEFAULT 0,
+    expires_at       TIMESTAMP WITH TIME ZONE NOT NULL,
+    created_at       TIMESTAMP NOT NULL DEFAULT NOW(),
+
+    CONSTRAINT two_factor_challenges_hash_unique UNIQUE (token_hash)
+);
+
+CREATE INDEX idx_two_factor_challenges_expires_at ON two_factor_challenges (expires_at);
@@ -13,15 +13,16 @@ func SeedAdmin() {
 	password, _ := utils.HashPassword("admin123")
 
 	admin := models.User{
-		Name:     "Super admin",
-		Email:    "admin@example.com",
-		Password: password,
-		Role:     "admin",
-		PublicID: uuid.New(),
+		Name:          "Super admin",
+		Email:         "admin@example.com",
+		Password:      password,
+		Role:          "admin",
+		PublicID:      uuid.New(),
+		EmailVerified: true,
 	}
 	if err := config.DB.FirstOrCreate(&admin, models.User{Email: admin.Email}).Error; err != nil {
 		log.Println("Failed too seed admin", err)
 	} else {
 		log.Println("Admin user seeded")
 	}
-}
\ No newline at end of file
+}
@@ -0,0 +1,78 @@
+package mailer
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+)
+
+// FileMailer writes every message as an .eml file into a directory instead of
+// sending it. Intended for local development.
+type FileMailer struct {
+	dir  string
+	from string
+}
+
+// NewFileMailer creates a new FileMailer, creating the directory if needed.
+func NewFileMailer(dir, from string) (*FileMailer, error) {
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return nil, err
+	}
+	return &FileMailer{dir: dir, from: from}, nil
+}
+
+// Send writes the message to <dir>/<timestamp>-<random>.eml.
+func (m *FileMailer) Send(msg *Message) error {
+	if msg.From == "" {
+		msg.From = m.from
+	}
+	body, err := buildMIME(msg)
+	if err != nil {
+		return err
+	}
+	suffix, err := randomToken(4)
+	if err != nil {
+		return err
+	}
+	name := fmt.Sprintf("%s-%s.eml", time.Now().Format("20060102-150405"), suffix)
+	return os.WriteFile(filepath.Join(m.dir, name), body, 0o644)
+}
+
+// MemoryMailer keeps sent messages in memory. Intended for tests.
+type MemoryMailer struct {
+	mu       sync.Mutex
+	from     string
+	messages []Message
+}
+
+// NewMemoryMailer creates a new MemoryMailer.
+func NewMemoryMailer(from string) *MemoryMailer {
+	return &MemoryMailer{from: from}
+}
+
+// Send stores a copy of the message.
+func (m *MemoryMailer) Send(msg *Message) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if msg.From == "" {
+		msg.From = m.from
+	}
+	m.messages = append(m.messages, *msg)
+	return nil
+}
+
+// Messages returns every message sent so far.
+func (m *MemoryMailer) Messages() []Message {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return append([]Message(nil), m.messages...)
+}
+
+// Reset forgets all stored messages.
+func (m *MemoryMailer) Reset() {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.messages = nil
+}
@@ -0,0 +1,36 @@
+package mailer
+
+import (
+	"errors"
+	"strings"
+
+	"github.com/mohod24/go-project-management/config"
+)
+
+// Message is a single email with an HTML and a plain-text body.
+type Message struct {
+	From    string
+	To      []string
+	Subject string
+	HTML    string
+	Text    string
+}
+
+// Mailer delivers email messages.
+type Mailer interface {
+	Send(msg *Message) error
+}
+
+// New creates the Mailer selected by MAIL_DRIVER: "smtp", "file" or "memory".
+func New(cfg *config.Config) (Mailer, error) {
+	switch strings.ToLower(cfg.MailDriver) {
+	case "smtp":
+		return NewSMTPMailer(cfg.SMTPHost, cfg.SMTPPort, cfg.SMTPUsername, cfg.SMTPPassword, cfg.MailFrom), nil
+	case "file", "":
+		return NewFileMailer(cfg.MailFileDir, cfg.MailFrom)
+	case "memory":
+		return NewMemoryMailer(cfg.MailFrom), nil
+	default:
+		return nil, errors.New("unsupported mail driver: " + cfg.MailDriver)
+	}
+}
@@ -0,0 +1,81 @@
+package mailer
+
+import (
+	"errors"
+	"log"
+	"sync"
+	"time"
+)
+
+var (
+	// ErrQueueFull is returned when the queue cannot accept more messages.
+	ErrQueueFull = errors.New("mail queue is full")
+	// ErrQueueClosed is returned when sending on a closed queue.
+	ErrQueueClosed = errors.New("mail queue is closed")
+)
+
+// Queue is a Mailer that hands messages to background workers so callers do
+// not block on the underlying Mailer. Failed deliveries are retried with a
+// growing delay.
+type Queue struct {
+	mailer  Mailer
+	jobs    chan *Message
+	retries int
+	wg      sync.WaitGroup
+	mu      sync.RWMutex
+	closed  bool
+}
+
+// NewQueue starts workers that deliver queued messages through m.
+func NewQueue(m Mailer, size, workers, retries int) *Queue {
+	q := &Queue{mailer: m, jobs: make(chan *Message, size), retries: retries}
+	for i := 0; i < workers; i++ {
+		q.wg.Add(1)
+		go q.work()
+	}
+	return q
+}
+
+// Send enqueues the message without waiting for it to be delivered.
+func (q *Queue) Send(msg *Message) error {
+	q.mu.RLock()
+	defer q.mu.RUnlock()
+	if q.closed {
+		return ErrQueueClosed
+	}
+	select {
+	case q.jobs <- msg:
+		return nil
+	default:
+		return ErrQueueFull
+	}
+}
+
+// Close stops accepting messages and waits until the queue is drained.
+func (q *Queue) Close() {
+	q.mu.Lock()
+	if !q.closed {
+		q.closed = true
+		close(q.jobs)
+	}
+	q.mu.Unlock()
+	q.wg.Wait()
+}
+
+func (q *Queue) work() {
+	defer q.wg.Done()
+	for msg := range q.jobs {
+		var err error
+		for attempt := 0; attempt <= q.retries; attempt++ {
+			if attempt > 0 {
+				time.Sleep(time.Duration(attempt) * time.Second)
+			}
+			if err = q.mailer.Send(msg); err == nil {
+				break
+			}
+		}
+		if err != nil {
+			log.Println("Failed to send email to", msg.To, err)
+		}
+	}
+}
@@ -0,0 +1,27 @@
+package mailer
+
+// Sender renders templated emails and hands them to a Mailer.
+type Sender interface {
+	SendTemplate(to, locale, name string, data interface{}) error
+}
+
+// templateSender implements the Sender interface.
+type templateSender struct {
+	mailer   Mailer
+	renderer *Renderer
+}
+
+// NewSender creates a new Sender.
+func NewSender(m Mailer, r *Renderer) Sender {
+	return &templateSender{mailer: m, renderer: r}
+}
+
+// SendTemplate renders the named template for the recipient's locale and sends it.
+func (s *templateSender) SendTemplate(to, locale, name string, data interface{}) error {
+	msg, err := s.renderer.Render(name, locale, data)
+	if err != nil {
+		return err
+	}
+	msg.To = []string{to}
+	return s.mailer.Send(msg)
+}
@@ -0,0 +1,41 @@
+package mailer
+
+import (
+	"net"
+	"net/mail"
+	"net/smtp"
+)
+
+// SMTPMailer sends email through an SMTP server, using STARTTLS when the
+// server supports it.
+type SMTPMailer struct {
+	addr string
+	auth smtp.Auth
+	from string
+}
+
+// NewSMTPMailer creates a new SMTPMailer. Authentication is skipped when no
+// username is configured.
+func NewSMTPMailer(host, port, username, password, from string) *SMTPMailer {
+	var auth smtp.Auth
+	if username != "" {
+		auth = smtp.PlainAuth("", username, password, host)
+	}
+	return &SMTPMailer{addr: net.JoinHostPort(host, port), auth: auth, from: from}
+}
+
+// Send delivers the message to the SMTP server.
+func (m *SMTPMailer) Send(msg *Message) error {
+	if msg.From == "" {
+		msg.From = m.from
+	}
+	envelopeFrom := msg.From
+	if addr, err := mail.ParseAddress(msg.From); err == nil {
+		envelopeFrom = addr.Address
+	}
+	body, err := buildMIME(msg)
+	if err != nil {
+		return err
+	}
+	return smtp.SendMail(m.addr, m.auth, envelopeFrom, msg.To, body)
+}
@@ -0,0 +1,101 @@
+package mailer
+
+import (
+	"bytes"
+	"embed"
+	"fmt"
+	htmltemplate "html/template"
+	"io/fs"
+	"strings"
+	texttemplate "text/template"
+)
+
+//go:embed templates
+var templateFS embed.FS
+
+// Renderer renders the embedded email templates. Every email has a
+// templates/<locale>/<name>.txt file that defines a "subject" block and the
+// plain-text body, and a templates/<locale>/<name>.html file that fills the
+// "content" block of templates/layout.html.
+type Renderer struct {
+	defaultLocale string
+	html          map[string]*htmltemplate.Template
+	text          map[string]*texttemplate.Template
+}
+
+// NewRenderer parses all embedded templates.
+func NewRenderer(defaultLocale string) (*Renderer, error) {
+	r := &Renderer{
+		defaultLocale: defaultLocale,
+		html:          make(map[string]*htmltemplate.Template),
+		text:          make(map[string]*texttemplate.Template),
+	}
+	layout, err := htmltemplate.ParseFS(templateFS, "templates/layout.html")
+	if err != nil {
+		return nil, err
+	}
+
+	err = fs.WalkDir(templateFS, "templates", func(path string, d fs.DirEntry, err error) error {
+		if err != nil || d.IsDir() || path == "templates/layout.html" {
+			return err
+		}
+		// templates/<locale>/<name>.<ext>
+		key := strings.TrimPrefix(path, "templates/")
+		switch {
+		case strings.HasSuffix(key, ".html"):
+			t, err := layout.Clone()
+			if err != nil {
+				return err
+			}
+			if _, err := t.ParseFS(templateFS, path); err != nil {
+				return err
+			}
+			r.html[strings.TrimSuffix(key, ".html")] = t
+		case strings.HasSuffix(key, ".txt"):
+			t, err := texttemplate.ParseFS(templateFS, path)
+			if err != nil {
+				return err
+			}
+			r.text[strings.TrimSuffix(key, ".txt")] = t
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return r, nil
+}
+
+// Render renders the named email in the given locale, falling back to the
+// default locale when no translation exists.
+func (r *Renderer) Render(name, locale string, data interface{}) (*Message, error) {
+	key := locale + "/" + name
+	if _, ok := r.text[key]; !ok {
+		key = r.defaultLocale + "/" + name
+	}
+	text, ok := r.text[key]
+	if !ok {
+		return nil, fmt.Errorf("email template %q not found", name)
+	}
+
+	var subject, body bytes.Buffer
+	if err := text.ExecuteTemplate(&subject, "subject", data); err != nil {
+		return nil, err
+	}
+	if err := text.Execute(&body, data); err != nil {
+		return nil, err
+	}
+	msg := &Message{
+		Subject: strings.TrimSpace(subject.String()),
+		Text:    strings.TrimSpace(body.String()) + "\n",
+	}
+
+	if html, ok := r.html[key]; ok {
+		var buf bytes.Buffer
+		if err := html.ExecuteTemplate(&buf, "layout", data); err != nil {
+			return nil, err
+		}
+		msg.HTML = buf.String()
+	}
+	return msg, nil
+}
@@ -6,14 +6,21 @@ package main
 // @termsOfService http://swagger.io/terms/
 import (
 	"log"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
 
 	"github.com/gofiber/fiber/v2"
 	"github.com/mohod24/go-project-management/config"
 	"github.com/mohod24/go-project-management/controllers"
 	"github.com/mohod24/go-project-management/database/seed"
+	"github.com/mohod24/go-project-management/mailer"
+	"github.com/mohod24/go-project-management/middleware"
 	"github.com/mohod24/go-project-management/repositories"
 	"github.com/mohod24/go-project-management/routes"
 	"github.com/mohod24/go-project-management/services"
+	"github.com/mohod24/go-project-management/utils"
 )
 
 // @contact.name API Support
@@ -26,21 +33,160 @@ func main() {
 	seed.SeedAdmin()
 	app := fiber.New()
 
-	// Initialize repositories, services, and controllers
-	userRepo := repositories.NewUserRepository()
-	userService := services.NewUserService(userRepo)
-	userController := controllers.NewUserController(userService)
+	// Initialize email delivery
+	baseMailer, err := mailer.New(config.AppConfig)
+	if err != nil {
+		log.Fatal("Failed to initialize mailer: ", err)
+	}
+	mailQueue := mailer.NewQueue(baseMailer, config.AppConfig.MailQueueSize, config.AppConfig.MailWorkers, 3)
+	defer mailQueue.Close()
+	mailRenderer, err := mailer.NewRenderer(config.AppConfig.MailDefaultLocale)
+	if err != nil {
+		log.Fatal("Failed to parse email templates: ", err)
+	}
+	mailSender := mailer.NewSender(mailQueue, mailRenderer)
 
-	// Initialize Board components
+	// Load JWT signing keys
+	signingKeyService := services.NewSigningKeyService(repositories.NewSigningKeyRepository())
+	if err := signingKeyService.EnsureKey(); err != nil {
+		log.Fatal("Failed to create JWT signing key: ", err)
+	}
+	if err := signingKeyService.Load(); err != nil {
+		log.Fatal("Failed to load JWT signing keys: ", err)
+	}
+	keyRefreshInterval, err := time.ParseDuration(config.AppConfig.JWTKeyRefreshInterval)
+	if err != nil {
+		log.Fatal("Invalid JWT_KEY_REFRESH_INTERVAL: ", err)
+	}
+	stopKeyRefresh := signingKeyService.Start(keyRefreshInterval)
+	defer stopKeyRefresh()
+
+	// Initialize repositories
+	userRepo := repositories.NewUserRepository()
 	boardRepo := repositories.NewBoardRepository()
 	boardMemberRepo := repositories.NewBoardMemberRepository()
-	boardService := services.NewBoardService(boardRepo, userRepo, boardMemberRepo)
+	boardInviteRepo := repositories.NewBoardInviteRepository()
+	listRepo := repositories.NewListRepository()
+	cardRepo := repositories.NewCardRepository()
+	commentRepo := repositories.NewCommentRepository()
+	watcherRepo := repositories.NewWatcherRepository()
+	notificationRepo := repositories.NewNotificationRepository()
+
+	// Initialize Watch & Notification components
+	watchService := services.NewWatchService(watcherRepo, notificationRepo, userRepo, boardRepo, listRepo, cardRepo, boardMemberRepo)
+	watchController := controllers.NewWatchController(watchService)
+	notificationService := services.NewNotificationService(notificationRepo, userRepo)
+	notificationController := controllers.NewNotificationController(notificationService)
+
+	// Initialize User components
+	boardInviteService := services.NewBoardInviteService(boardInviteRepo, boardRepo, userRepo, boardMemberRepo, mailSender)
+	boardInviteController := controllers.NewBoardInviteController(boardInviteService)
+	emailVerificationService := services.NewEmailVerificationService(userRepo, mailSender)
+	loginThrottleRepo := repositories.NewLoginThrottleRepository()
+	loginThrottleService := services.NewLoginThrottleService(loginThrottleRepo, userRepo)
+	passwordPolicy, err := services.NewPasswordPolicy(repositories.NewPasswordHistoryRepository())
+	if err != nil {
+		log.Fatal("Failed to load password breach list: ", err)
+	}
+	userService := services.NewUserService(userRepo, boardInviteService, emailVerificationService, loginThrottleService, passwordPolicy)
+	recoveryCodeRepo := repositories.NewRecoveryCodeRepository()
+	twoFactorService := services.NewTwoFactorService(userRepo, recoveryCodeRepo, repositories.NewTwoFactorChallengeRepository(), loginThrottleService)
+	oidcService := services.NewOIDCService(userRepo)
+	sessionRepo := repositories.NewSessionRepository()
+	sessionService := services.NewSessionService(sessionRepo, userRepo, twoFactorService)
+	sessionController := controllers.NewSessionController(sessionService)
+	avatarController := controllers.NewAvatarController(services.NewAvatarService(userRepo))
+	userController := controllers.NewUserController(userService, twoFactorService, oidcService, sessionService)
+
+	// Initialize Auth components
+	passwordResetRepo := repositories.NewPasswordResetRepository()
+	passwordResetService := services.NewPasswordResetService(passwordResetRepo, userRepo, mailSender, passwordPolicy)
+	authController := controllers.NewAuthController(passwordResetService, emailVerificationService, twoFactorService, loginThrottleService)
+	authGuards := []fiber.Handler{
+		middleware.TokenVersion(userRepo),
+		middleware.Session(sessionService),
+		middleware.TwoFactorSetup("/api/v1/me/2fa"),
+	}
+
+	// Initialize Personal Access Token components
+	personalAccessTokenRepo := repositories.NewPersonalAccessTokenRepository()
+	personalAccessTokenService := services.NewPersonalAccessTokenService(personalAccessTokenRepo, userRepo)
+	personalAccessTokenController := controllers.NewPersonalAccessTokenController(personalAccessTokenService)
+	tokenAuth := middleware.PersonalAccessToken(personalAccessTokenService)
+
+	// Initialize Board components
+	boardService := services.NewBoardService(boardRepo, userRepo, boardMemberRepo, watchService)
 	boardController := controllers.NewBoardController(boardService)
 
+	// Initialize List & Card components
+	listService := services.NewListService(listRepo, boardRepo, userRepo, boardMemberRepo, watchService)
+	listController := controllers.NewListController(listService)
+	customFieldRepo := repositories.NewCustomFieldRepository()
+	cardService := services.NewCardService(cardRepo, listRepo, boardRepo, userRepo, boardMemberRepo, commentRepo, customFieldRepo, watchService)
+	cardController := controllers.NewCardController(cardService)
+	checklistService := services.NewChecklistService(repositories.NewChecklistRepository(), cardRepo, listRepo, boardRepo, userRepo, boardMemberRepo, watchService)
+	checklistController := controllers.NewChecklistController(checklistService)
+	customFieldService := services.NewCustomFieldService(customFieldRepo, cardRepo, listRepo, boardRepo, userRepo, boardMemberRepo, watchService)
+	customFieldController := controllers.NewCustomFieldController(customFieldService)
+
+	// Initialize Search components
+	searchService := services.NewSearchService(repositories.NewSearchRepository(), userRepo)
+	searchController := controllers.NewSearchController(searchService)
+
+	// Initialize Account components and start purging deleted accounts
+	accountPurgeInterval, err := time.ParseDuration(config.AppConfig.AccountPurgeInterval)
+	if err != nil {
+		log.Fatal("Invalid ACCOUNT_PURGE_INTERVAL: ", err)
+	}
+	accountService := services.NewAccountService(repositories.NewAccountRepository(), userRepo, boardMemberRepo, mailSender)
+	accountController := controllers.NewAccountController(accountService)
+	stopAccountPurge := accountService.Start(accountPurgeInterval)
+	defer stopAccountPurge()
+
+	// Start due-date reminder scheduler
+	reminderWindows, err := utils.ParseDurations(config.AppConfig.ReminderWindows)
+	if err != nil {
+		log.Fatal("Invalid REMINDER_WINDOWS: ", err)
+	}
+	reminderInterval, err := time.ParseDuration(config.AppConfig.ReminderInterval)
+	if err != nil {
+		log.Fatal("Invalid REMINDER_INTERVAL: ", err)
+	}
+	reminderOverdueLookback, err := time.ParseDuration(config.AppConfig.ReminderOverdueLookback)
+	if err != nil {
+		log.Fatal("Invalid REMINDER_OVERDUE_LOOKBACK: ", err)
+	}
+	reminderRepo := repositories.NewReminderRepository()
+	reminderService := services.NewReminderService(reminderRepo, mailSender, reminderWindows, reminderOverdueLookback)
+	stopReminders := reminderService.Start(reminderInterval)
+	defer stopReminders()
+
+	// Start purging expired idempotency keys
+	idempotencyPurgeInterval, err := time.ParseDuration(config.AppConfig.IdempotencyKeyPurgeInterval)
+	if err != nil {
+		log.Fatal("Invalid IDEMPOTENCY_KEY_PURGE_INTERVAL: ", err)
+	}
+	idempotencyService := services.NewIdempotencyService(repositories.NewIdempotencyKeyRepository(), userRepo)
+	stopIdempotencyPurge := idempotencyService.Start(idempotencyPurgeInterval)
+	defer stopIdempotencyPurge()
+
 	// Setup routes
-	routes.Setup(app, userController, boardController)
+	routes.Setup(app, tokenAuth, authGuards, middleware.Idempotency(idempotencyService), userController, boardController, listController, cardController, watchController, notificationController, boardInviteController, authController, personalAccessTokenController, sessionController, avatarController, accountController, searchController, checklistController, customFieldController)
 	port := config.AppConfig.AppPort
 	log.Println("Server running on port " + port)
-	app.Listen(":" + port)
-	log.Fatal(app.Listen(":" + port))
+
+	// Shut down on SIGINT/SIGTERM and return from main, so the deferred stops
+	// above run and queued emails are delivered before the process exits.
+	go func() {
+		quit := make(chan os.Signal, 1)
+		signal.Notify(quit, os.Interrupt, syscall.SIGTERM)
+		<-quit
+		log.Println("Shutting down server")
+		if err := app.Shutdown(); err != nil {
+			log.Println("Failed to shut down server", err)
+		}
+	}()
+	if err := app.Listen(":" + port); err != nil {
+		log.Println("Server stopped", err)
+	}
 }
\ No newline at end of file
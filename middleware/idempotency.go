@@ -0,0 +1,69 @@
+package middleware
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"log"
+	"strings"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/mohod24/go-project-management/services"
+	"github.com/mohod24/go-project-management/utils"
+)
+
+// HeaderIdempotencyKey is the request header that makes a POST safe to retry.
+const HeaderIdempotencyKey = "Idempotency-Key"
+
+// Idempotency replays the first response to a request carrying an
+// Idempotency-Key header when the same user retries it with the same key.
+// Reusing a key for a different method, path or body is rejected with 422.
+// Failed requests (5xx) are not stored, so they can be retried for real.
+// Requests without the header pass through.
+func Idempotency(idempotencyService services.IdempotencyService) fiber.Handler {
+	return func(ctx *fiber.Ctx) error {
+		key := strings.TrimSpace(ctx.Get(HeaderIdempotencyKey))
+		if key == "" {
+			return ctx.Next()
+		}
+		if len(key) > 255 {
+			return utils.BadRequest(ctx, "Idempotency-Key tidak valid", "idempotency key must be at most 255 characters")
+		}
+		userID, err := utils.GetUserPublicID(ctx)
+		if err != nil {
+			return utils.Unauthorized(ctx, "Error unauthorized", err.Error())
+		}
+
+		hash := sha256.New()
+		hash.Write([]byte(ctx.Method() + " " + ctx.Path() + "\n"))
+		hash.Write(ctx.Body())
+		record, replay, err := idempotencyService.Begin(userID, key, hex.EncodeToString(hash.Sum(nil)), time.Now())
+		switch {
+		case errors.Is(err, services.ErrIdempotencyKeyReused):
+			return utils.UnprocessableEntity(ctx, "Idempotency-Key sudah dipakai untuk permintaan lain", err.Error())
+		case errors.Is(err, services.ErrIdempotencyInProgress):
+			return utils.Conflict(ctx, "Permintaan sedang diproses", err.Error())
+		case err != nil:
+			return utils.InternalServerError(ctx, "Internal Server Error", err.Error())
+		}
+		if replay {
+			ctx.Set("Idempotent-Replayed", "true")
+			ctx.Set(fiber.HeaderContentType, record.ContentType)
+			return ctx.Status(*record.StatusCode).Send(record.ResponseBody)
+		}
+
+		err = ctx.Next()
+		status := ctx.Response().StatusCode()
+		if err != nil || status >= fiber.StatusInternalServerError {
+			if abandonErr := idempotencyService.Abandon(record); abandonErr != nil {
+				log.Println("Failed to release idempotency key", abandonErr)
+			}
+			return err
+		}
+		if err := idempotencyService.Complete(record, status, string(ctx.Response().Header.ContentType()), ctx.Response().Body()); err != nil {
+			log.Println("Failed to store idempotent response", err)
+		}
+		return nil
+	}
+}
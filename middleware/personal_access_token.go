@@ -0,0 +1,40 @@
+package middleware
+
+import (
+	"strings"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/golang-jwt/jwt/v4"
+	"github.com/mohod24/go-project-management/services"
+	"github.com/mohod24/go-project-management/utils"
+)
+
+// PersonalAccessToken authenticates requests that carry a personal access
+// token instead of a JWT. It stores claims shaped like those of an access
+// token, plus the token's scopes, so the JWT middleware can be skipped and
+// handlers work unchanged. Requests with any other bearer token pass through.
+func PersonalAccessToken(patService services.PersonalAccessTokenService) fiber.Handler {
+	return func(ctx *fiber.Ctx) error {
+		raw, ok := strings.CutPrefix(ctx.Get(fiber.HeaderAuthorization), "Bearer ")
+		if !ok || !strings.HasPrefix(raw, services.PersonalAccessTokenPrefix) {
+			return ctx.Next()
+		}
+		user, token, err := patService.Authenticate(raw)
+		if err != nil {
+			return utils.Unauthorized(ctx, "Error unauthorized", err.Error())
+		}
+		ctx.Locals("user", &jwt.Token{
+			Valid: true,
+			Claims: jwt.MapClaims{
+				"user_id": user.InternalID,
+				"role":    user.Role,
+				"email":   user.Email,
+				"pub_id":  user.PublicID.String(),
+				"ver":     float64(user.TokenVersion),
+				"scopes":  []string(token.Scopes),
+				"pat_id":  token.PublicID.String(),
+			},
+		})
+		return ctx.Next()
+	}
+}
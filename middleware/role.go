@@ -0,0 +1,23 @@
+package middleware
+
+import (
+	"slices"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/mohod24/go-project-management/utils"
+)
+
+// RequireRole only lets through users whose token carries one of the roles.
+func RequireRole(roles ...string) fiber.Handler {
+	return func(ctx *fiber.Ctx) error {
+		claims, err := utils.GetClaims(ctx)
+		if err != nil {
+			return utils.Unauthorized(ctx, "Error unauthorized", err.Error())
+		}
+		role, _ := claims["role"].(string)
+		if !slices.Contains(roles, role) {
+			return utils.Forbidden(ctx, "Akses ditolak", "insufficient role")
+		}
+		return ctx.Next()
+	}
+}
@@ -0,0 +1,62 @@
+package middleware
+
+import (
+	"slices"
+	"strings"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/mohod24/go-project-management/models"
+	"github.com/mohod24/go-project-management/utils"
+)
+
+// RequireScopes checks the scopes of personal access tokens: safe methods
+// need readScope and everything else needs writeScope. Interactive JWTs are
+// not scoped and always pass.
+func RequireScopes(readScope, writeScope string) fiber.Handler {
+	return func(ctx *fiber.Ctx) error {
+		scopes, scoped := utils.GetScopes(ctx)
+		if !scoped {
+			return ctx.Next()
+		}
+		required := writeScope
+		if ctx.Method() == fiber.MethodGet || ctx.Method() == fiber.MethodHead {
+			required = readScope
+		}
+		if !scopeAllows(scopes, required) {
+			return utils.Forbidden(ctx, "Akses ditolak", "token is missing the "+required+" scope")
+		}
+		return ctx.Next()
+	}
+}
+
+// RequireScope checks that personal access tokens carry scope for every
+// method. Admin routes use it, since even reading lockouts or sessions of
+// other users needs admin:users.
+func RequireScope(scope string) fiber.Handler {
+	return RequireScopes(scope, scope)
+}
+
+// InteractiveOnly rejects personal access tokens, for routes such as token
+// management that scripts should never reach.
+func InteractiveOnly() fiber.Handler {
+	return func(ctx *fiber.Ctx) error {
+		if _, scoped := utils.GetScopes(ctx); scoped {
+			return utils.Forbidden(ctx, "Akses ditolak", "personal access tokens cannot be used here")
+		}
+		return ctx.Next()
+	}
+}
+
+// scopeAllows reports whether the granted scopes include required. A write
+// or admin scope includes the read scope of the same resource.
+func scopeAllows(granted []string, required string) bool {
+	if slices.Contains(granted, required) {
+		return true
+	}
+	resource, ok := strings.CutPrefix(required, "read:")
+	if !ok {
+		return false
+	}
+	return slices.Contains(granted, "write:"+resource) ||
+		(resource == "users" && slices.Contains(granted, models.ScopeAdminUsers))
+}
@@ -0,0 +1,30 @@
+package middleware
+
+import (
+	"github.com/gofiber/fiber/v2"
+	"github.com/mohod24/go-project-management/services"
+	"github.com/mohod24/go-project-management/utils"
+)
+
+// Session rejects access tokens whose login session has been revoked or has
+// expired. Tokens without a session, such as personal access tokens, pass.
+func Session(sessionService services.SessionService) fiber.Handler {
+	return func(ctx *fiber.Ctx) error {
+		claims, err := utils.GetClaims(ctx)
+		if err != nil {
+			return utils.Unauthorized(ctx, "Error unauthorized", err.Error())
+		}
+		sessionID, _ := claims["sid"].(string)
+		if sessionID == "" {
+			return ctx.Next()
+		}
+		active, err := sessionService.IsActive(sessionID)
+		if err != nil {
+			return utils.InternalServerError(ctx, "Internal Server Error", err.Error())
+		}
+		if !active {
+			return utils.Unauthorized(ctx, "Error unauthorized", "session has been revoked")
+		}
+		return ctx.Next()
+	}
+}
@@ -0,0 +1,29 @@
+package middleware
+
+import (
+	"github.com/gofiber/fiber/v2"
+	"github.com/mohod24/go-project-management/repositories"
+	"github.com/mohod24/go-project-management/utils"
+)
+
+// TokenVersion rejects access tokens issued before the user's sessions were
+// revoked, e.g. by a password reset. It must run after the JWT middleware.
+func TokenVersion(userRepo repositories.UserRepository) fiber.Handler {
+	return func(ctx *fiber.Ctx) error {
+		claims, err := utils.GetClaims(ctx)
+		if err != nil {
+			return utils.Unauthorized(ctx, "Error unauthorized", err.Error())
+		}
+		publicID, _ := claims["pub_id"].(string)
+		user, err := userRepo.FindByPublicID(publicID)
+		if err != nil {
+			return utils.Unauthorized(ctx, "Error unauthorized", "user not found")
+		}
+		// token lama tanpa klaim "ver" dianggap versi 0
+		version, _ := claims["ver"].(float64)
+		if int(version) != user.TokenVersion {
+			return utils.Unauthorized(ctx, "Error unauthorized", "session has been revoked")
+		}
+		return ctx.Next()
+	}
+}
@@ -0,0 +1,23 @@
+package middleware
+
+import (
+	"strings"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/mohod24/go-project-management/utils"
+)
+
+// TwoFactorSetup restricts tokens issued to users who must enrol in
+// two-factor authentication to the enrolment endpoints under setupPrefix.
+func TwoFactorSetup(setupPrefix string) fiber.Handler {
+	return func(ctx *fiber.Ctx) error {
+		claims, err := utils.GetClaims(ctx)
+		if err != nil {
+			return utils.Unauthorized(ctx, "Error unauthorized", err.Error())
+		}
+		if pending, _ := claims["mfa_setup"].(bool); pending && !strings.HasPrefix(ctx.Path(), setupPrefix) {
+			return utils.Forbidden(ctx, "Two-factor authentication required", "set up two-factor authentication first")
+		}
+		return ctx.Next()
+	}
+}
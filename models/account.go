@@ -0,0 +1,26 @@
+package models
+
+import "time"
+
+// AccountExport is everything a user can take out of the application with a
+// personal data export.
+type AccountExport struct {
+	ExportedAt  time.Time        `json:"exported_at"`
+	Profile     UserResponse     `json:"profile"`
+	Boards      []ExportedBoard  `json:"boards"`      // boards the user owns
+	Cards       []Card           `json:"cards"`       // cards assigned to the user
+	Comments    []Comment        `json:"comments"`    // comments written by the user
+	Attachments []CardAttachment `json:"attachments"` // files uploaded by the user
+}
+
+// ExportedBoard is an owned board with its lists and their cards.
+type ExportedBoard struct {
+	Board
+	Lists []ExportedList `json:"lists"`
+}
+
+// ExportedList is a list of an exported board with its cards.
+type ExportedList struct {
+	List
+	Cards []Card `json:"cards"`
+}
@@ -15,4 +15,18 @@ type Board struct {
 	OwnerPublicID uuid.UUID  `json:"owner_public_id" db:"owner_public_id"`
 	CreatedAt     time.Time  `json:"created_at" db:"created_at"`
 	DueDate       *time.Time `json:"due_date,omitempty" db:"due_date"`
-}
\ No newline at end of file
+	Version       int64      `json:"version" db:"version" gorm:"default:1"`
+
+	// relasi, hanya dimuat lewat ?include=
+	Lists   []List `json:"lists,omitempty" gorm:"foreignKey:BoardInternalID;references:InternalID"`
+	Members []User `json:"-" gorm:"many2many:board_members;joinForeignKey:board_internal_id;joinReferences:user_internal_id"`
+}
+
+// Relations of a board that can be requested with ?include=.
+var BoardIncludes = []string{"lists", "lists.cards", "members"}
+
+// BoardResponse is a board with its members in their public form.
+type BoardResponse struct {
+	Board
+	Members []UserResponse `json:"members,omitempty"`
+}
@@ -0,0 +1,31 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Status of a board invite.
+const (
+	InviteStatusPending  = "pending"
+	InviteStatusAccepted = "accepted"
+	InviteStatusDeclined = "declined"
+	InviteStatusRevoked  = "revoked"
+)
+
+type BoardInvite struct {
+	InternalID  int64      `json:"internal_id" db:"internal_id" gorm:"primaryKey;autoIncrement"`
+	PublicID    uuid.UUID  `json:"public_id" db:"public_id"`
+	BoardID     int64      `json:"-" db:"board_internal_id" gorm:"column:board_internal_id"`
+	Email       string     `json:"email" db:"email"`
+	InvitedByID int64      `json:"-" db:"invited_by_internal_id" gorm:"column:invited_by_internal_id"`
+	UserID      *int64     `json:"-" db:"user_internal_id" gorm:"column:user_internal_id"`
+	Status      string     `json:"status" db:"status"`
+	ExpiresAt   time.Time  `json:"expires_at" db:"expires_at"`
+	AcceptedAt  *time.Time `json:"accepted_at,omitempty" db:"accepted_at"`
+	CreatedAt   time.Time  `json:"created_at" db:"created_at"`
+
+	// relasi
+	Board *Board `json:"board,omitempty" gorm:"foreignKey:BoardID;references:InternalID"`
+}
@@ -14,10 +14,21 @@ type Card struct {
 	Description string     `json:"description" db:"description"`
 	DueDate     *time.Time `json:"due_date,omitempty" db:"due_date"`
 	Position    int        `json:"position" db:"position"`
+	ArchivedAt  *time.Time `json:"archived_at,omitempty" db:"archived_at"`
 	CreatedAt   time.Time  `json:"created_at" db:"created_at"`
+	Version     int64      `json:"version" db:"version" gorm:"default:1"`
+
+	// dihitung ulang setiap kali item checklist berubah
+	ChecklistProgress ChecklistProgress `json:"checklist_progress" gorm:"embedded;embeddedPrefix:checklist_"`
 
 	// relasi
 	Assigness   []CardAssignee   `json:"assigness,omitempty" gorm:"foreignKey:CardID;reference:InternalID"`
 	Attachments []CardAttachment `json:"attachments,omitempty" gorm:"foreignKey:CardID;references:InternalID"`
 	Labels      []CardLabel      `json:"labels,omitempty" gorm:"foreignKey:CardID;references:InternalID"`
+	Checklists  []Checklist      `json:"checklists,omitempty" gorm:"foreignKey:CardID;references:InternalID"`
+
+	CustomFields []CardCustomFieldValue `json:"custom_fields,omitempty" gorm:"foreignKey:CardID;references:InternalID"`
 }
+
+// Relations of a card that can be requested with ?include=.
+var CardIncludes = []string{"assignees", "labels", "attachments", "checklists", "custom_fields"}
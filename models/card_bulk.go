@@ -0,0 +1,49 @@
+package models
+
+import "time"
+
+// Operations a bulk card request can apply.
+const (
+	CardBulkMove        = "move"
+	CardBulkAddLabel    = "add_label"
+	CardBulkRemoveLabel = "remove_label"
+	CardBulkAssign      = "assign"
+	CardBulkUnassign    = "unassign"
+	CardBulkSetDueDate  = "set_due_date"
+	CardBulkArchive     = "archive"
+	CardBulkDelete      = "delete"
+)
+
+// CardBulkOperation is a validated bulk operation with its target list,
+// label or user resolved to internal IDs.
+type CardBulkOperation struct {
+	Operation string
+	ListID    int64
+	LabelID   int64
+	UserID    int64
+	DueDate   *time.Time
+}
+
+// Outcomes of a bulk operation for a single card.
+const (
+	CardBulkStatusOK         = "ok"
+	CardBulkStatusFailed     = "failed"
+	CardBulkStatusRolledBack = "rolled_back"
+)
+
+// CardBulkResult is the outcome of a bulk operation for one card.
+type CardBulkResult struct {
+	CardID string `json:"card_id"`
+	Status string `json:"status"`
+	Error  string `json:"error,omitempty"`
+}
+
+// CardBulkResponse summarizes a bulk operation. When an atomic operation
+// fails nothing is applied and every card is either failed or rolled back.
+type CardBulkResponse struct {
+	Operation string           `json:"operation"`
+	Atomic    bool             `json:"atomic"`
+	Succeeded int              `json:"succeeded"`
+	Failed    int              `json:"failed"`
+	Results   []CardBulkResult `json:"results"`
+}
@@ -0,0 +1,33 @@
+package models
+
+import "time"
+
+// Fields of the card query language.
+const (
+	CardQueryText     = ""         // free text, matched against title and description
+	CardQueryLabel    = "label"    // label:bug
+	CardQueryAssignee = "assignee" // assignee:@me, assignee:none, assignee:<email or public ID>
+	CardQueryList     = "list"     // list:"In Progress"
+	CardQueryDue      = "due"      // due:<7d, due:>=2025-01-31, due:none
+	CardQueryIs       = "is"       // is:archived, is:overdue
+
+	// cf.<key>:<value> filters by a custom field of the board, see CustomField
+	CardQueryCustomField = "cf"
+)
+
+// CardQueryTerm is one condition of a parsed card query. All terms of a
+// query must match.
+type CardQueryTerm struct {
+	Field   string
+	Value   string
+	Op      string     // comparison of a due term: <, <=, >, >= or = (the whole day)
+	Time    *time.Time // resolved bound of a due term or the current time for is:overdue
+	Negated bool
+
+	// Custom field terms keep the field key and the 1-based position of their
+	// value until the service resolves them against the board's fields.
+	Key         string
+	Pos         int
+	CustomField *CustomField
+	Number      *float64 // bound of a number field
+}
@@ -0,0 +1,29 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// CardReminder records a due-date reminder that has already been sent, so
+// every reminder goes out exactly once per card, assignee and due date.
+type CardReminder struct {
+	CardID  int64     `json:"card_internal_id" db:"card_internal_id" gorm:"column:card_internal_id;primaryKey"`
+	UserID  int64     `json:"user_internal_id" db:"user_internal_id" gorm:"column:user_internal_id;primaryKey"`
+	Kind    string    `json:"kind" db:"kind" gorm:"primaryKey"`
+	DueDate time.Time `json:"due_date" db:"due_date" gorm:"primaryKey"`
+	SentAt  time.Time `json:"sent_at" db:"sent_at"`
+}
+
+// PendingReminder is a card assignee that has not yet received a reminder.
+type PendingReminder struct {
+	CardID       int64     `db:"card_internal_id" gorm:"column:card_internal_id"`
+	CardPublicID uuid.UUID `db:"card_public_id" gorm:"column:card_public_id"`
+	Title        string    `db:"title"`
+	DueDate      time.Time `db:"due_date"`
+	UserID       int64     `db:"user_internal_id" gorm:"column:user_internal_id"`
+	Name         string    `db:"name"`
+	Email        string    `db:"email"`
+	Locale       string    `db:"locale"`
+}
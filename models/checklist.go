@@ -0,0 +1,42 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Checklist is a named, ordered list of sub-steps on a card.
+type Checklist struct {
+	InternalID int64     `json:"internal_id" db:"internal_id" gorm:"primaryKey;autoIncrement"`
+	PublicID   uuid.UUID `json:"public_id" db:"public_id"`
+	CardID     int64     `json:"card_internal_id" db:"card_internal_id" gorm:"column:card_internal_id"`
+	Title      string    `json:"title" db:"title"`
+	Position   int       `json:"position" db:"position"`
+	CreatedAt  time.Time `json:"created_at" db:"created_at"`
+
+	// relasi
+	Items []ChecklistItem `json:"items" gorm:"foreignKey:ChecklistID;references:InternalID"`
+}
+
+// ChecklistItem is a single step of a checklist. An item is checked when
+// CheckedAt is set.
+type ChecklistItem struct {
+	InternalID       int64      `json:"internal_id" db:"internal_id" gorm:"primaryKey;autoIncrement"`
+	PublicID         uuid.UUID  `json:"public_id" db:"public_id"`
+	ChecklistID      int64      `json:"checklist_internal_id" db:"checklist_internal_id" gorm:"column:checklist_internal_id"`
+	Title            string     `json:"title" db:"title"`
+	Position         int        `json:"position" db:"position"`
+	AssigneeID       *int64     `json:"-" db:"assignee_internal_id" gorm:"column:assignee_internal_id"`
+	AssigneePublicID *uuid.UUID `json:"assignee_public_id,omitempty" db:"assignee_public_id"`
+	DueDate          *time.Time `json:"due_date,omitempty" db:"due_date"`
+	CheckedAt        *time.Time `json:"checked_at,omitempty" db:"checked_at"`
+	CheckedByID      *uuid.UUID `json:"checked_by_public_id,omitempty" db:"checked_by_public_id" gorm:"column:checked_by_public_id"`
+	CreatedAt        time.Time  `json:"created_at" db:"created_at"`
+}
+
+// ChecklistProgress counts the checked items of all checklists of a card.
+type ChecklistProgress struct {
+	Checked int `json:"checked" db:"checklist_checked"`
+	Total   int `json:"total" db:"checklist_total"`
+}
@@ -0,0 +1,28 @@
+package models
+
+// Directions a cursor can page in.
+const (
+	CursorNext = "next"
+	CursorPrev = "prev"
+)
+
+// Cursor is a position in a keyset-paginated listing: the values of the
+// listing's order columns for one row. A next cursor starts the page after
+// that row, a prev cursor ends the page before it.
+type Cursor struct {
+	Direction string   `json:"d"`
+	Key       []string `json:"k"`
+}
+
+// CursorPage requests one page of a keyset-paginated listing.
+type CursorPage struct {
+	Cursor *Cursor // nil for the first page
+	Limit  int
+}
+
+// CursorResult holds the cursors of the pages around a keyset page, nil when
+// there is no such page.
+type CursorResult struct {
+	Next *Cursor
+	Prev *Cursor
+}
@@ -0,0 +1,65 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/mohod24/go-project-management/models/types"
+	"gorm.io/gorm"
+)
+
+// Types of custom fields.
+const (
+	CustomFieldText     = "text"
+	CustomFieldNumber   = "number"
+	CustomFieldDate     = "date"
+	CustomFieldDropdown = "dropdown"
+	CustomFieldCheckbox = "checkbox"
+)
+
+// CustomFieldTypes lists every supported custom field type.
+var CustomFieldTypes = []string{CustomFieldText, CustomFieldNumber, CustomFieldDate, CustomFieldDropdown, CustomFieldCheckbox}
+
+// CustomField is a field the admins of a board define for its cards. Key is
+// derived from the name and is how queries and sorts refer to the field, as
+// in cf.story_points:>3. Options are the choices of a dropdown field.
+type CustomField struct {
+	InternalID int64             `json:"internal_id" db:"internal_id" gorm:"primaryKey;autoIncrement"`
+	PublicID   uuid.UUID         `json:"public_id" db:"public_id"`
+	BoardID    int64             `json:"-" db:"board_internal_id" gorm:"column:board_internal_id"`
+	Name       string            `json:"name" db:"name"`
+	Key        string            `json:"key" db:"key"`
+	Type       string            `json:"type" db:"type"`
+	Options    types.JSONStrings `json:"options,omitempty" db:"options" gorm:"type:jsonb"`
+	Position   int               `json:"position" db:"position"`
+	CreatedAt  time.Time         `json:"created_at" db:"created_at"`
+}
+
+// CardCustomFieldValue is the value of a custom field on a card. Only the
+// column of the field's type is set; Value exposes it in payloads.
+type CardCustomFieldValue struct {
+	CardID        int64      `json:"-" db:"card_internal_id" gorm:"column:card_internal_id;primaryKey"`
+	FieldID       int64      `json:"-" db:"custom_field_internal_id" gorm:"column:custom_field_internal_id;primaryKey"`
+	FieldPublicID uuid.UUID  `json:"field_id" db:"custom_field_public_id" gorm:"column:custom_field_public_id"`
+	TextValue     *string    `json:"-" db:"text_value"`
+	NumberValue   *float64   `json:"-" db:"number_value"`
+	DateValue     *time.Time `json:"-" db:"date_value"`
+	BoolValue     *bool      `json:"-" db:"bool_value"`
+
+	Value interface{} `json:"value" gorm:"-"`
+}
+
+// AfterFind exposes the stored column as Value.
+func (v *CardCustomFieldValue) AfterFind(tx *gorm.DB) error {
+	switch {
+	case v.TextValue != nil:
+		v.Value = *v.TextValue
+	case v.NumberValue != nil:
+		v.Value = *v.NumberValue
+	case v.DateValue != nil:
+		v.Value = *v.DateValue
+	case v.BoolValue != nil:
+		v.Value = *v.BoolValue
+	}
+	return nil
+}
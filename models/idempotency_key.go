@@ -0,0 +1,18 @@
+package models
+
+import "time"
+
+// IdempotencyKey is the first response to a request sent with an
+// Idempotency-Key header, kept so retries of the request can be replayed.
+// StatusCode is nil while the first request is still being handled.
+type IdempotencyKey struct {
+	InternalID   int64     `json:"internal_id" db:"internal_id" gorm:"primaryKey;autoIncrement"`
+	UserID       int64     `json:"user_internal_id" db:"user_internal_id" gorm:"column:user_internal_id"`
+	Key          string    `json:"key" db:"key"`
+	RequestHash  string    `json:"-" db:"request_hash"`
+	StatusCode   *int      `json:"status_code,omitempty" db:"status_code"`
+	ContentType  string    `json:"content_type" db:"content_type"`
+	ResponseBody []byte    `json:"-" db:"response_body"`
+	ExpiresAt    time.Time `json:"expires_at" db:"expires_at"`
+	CreatedAt    time.Time `json:"created_at" db:"created_at"`
+}
@@ -5,6 +5,7 @@ import "github.com/google/uuid"
 type Label struct {
 	InternalID int64     `json:"internal_id" db:"internal_id" gorm:"primaryKey;autoIncrement"`
 	PublicID   uuid.UUID `json:"public_id" db:"public_id"`
+	BoardID    int64     `json:"-" db:"board_internal_id" gorm:"column:board_internal_id"`
 	Name       string    `json:"name" db:"name"`
 	Color      string    `json:"color" db:"color"`
 }
@@ -12,5 +12,12 @@ type List struct {
 	BoardPublicID uuid.UUID `json:"board_public_id" db:"board_public_id" gorm:"board_public_id"`
 	Title         string    `json:"title" db:"title"`
 	CreatedAt     time.Time `json:"created_at" db:"created_at"`
+	Version       int64     `json:"version" db:"version" gorm:"default:1"`
 	BoardInternalID int64     `json:"-" db:"board_internal_id"`
-}
\ No newline at end of file
+
+	// relasi, hanya dimuat lewat ?include=
+	Cards []Card `json:"cards,omitempty" gorm:"foreignKey:ListID;references:InternalID"`
+}
+
+// Relations of a list that can be requested with ?include=.
+var ListIncludes = []string{"cards"}
\ No newline at end of file
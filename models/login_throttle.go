@@ -0,0 +1,26 @@
+package models
+
+import "time"
+
+const (
+	LoginThrottleAccount = "account"
+	LoginThrottleIP      = "ip"
+)
+
+// LoginThrottle counts recent failed logins for an account (by email) or a
+// client IP address.
+type LoginThrottle struct {
+	Scope        string     `json:"scope" db:"scope" gorm:"primaryKey"`
+	Subject      string     `json:"subject" db:"subject" gorm:"primaryKey"`
+	Failures     int        `json:"failures" db:"failures"`
+	LastFailedAt time.Time  `json:"last_failed_at" db:"last_failed_at"`
+	LockedUntil  *time.Time `json:"locked_until,omitempty" db:"locked_until"`
+}
+
+// LoginLockoutStatus is the lockout state of an account as shown to admins.
+type LoginLockoutStatus struct {
+	Failures     int        `json:"failures"`
+	LastFailedAt *time.Time `json:"last_failed_at,omitempty"`
+	Locked       bool       `json:"locked"`
+	LockedUntil  *time.Time `json:"locked_until,omitempty"`
+}
@@ -0,0 +1,20 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type Notification struct {
+	InternalID     int64      `json:"internal_id" db:"internal_id" gorm:"primaryKey;autoIncrement"`
+	PublicID       uuid.UUID  `json:"public_id" db:"public_id"`
+	UserID         int64      `json:"-" db:"user_internal_id" gorm:"column:user_internal_id"`
+	ActorID        *int64     `json:"-" db:"actor_internal_id" gorm:"column:actor_internal_id"`
+	EntityType     string     `json:"entity_type" db:"entity_type"`
+	EntityPublicID uuid.UUID  `json:"entity_public_id" db:"entity_public_id"`
+	Action         string     `json:"action" db:"action"`
+	Message        string     `json:"message" db:"message"`
+	ReadAt         *time.Time `json:"read_at,omitempty" db:"read_at"`
+	CreatedAt      time.Time  `json:"created_at" db:"created_at"`
+}
@@ -0,0 +1,12 @@
+package models
+
+import "time"
+
+// PasswordHistory is a previous password hash of a user, kept to prevent
+// reusing recent passwords.
+type PasswordHistory struct {
+	InternalID   int64     `json:"-" db:"internal_id" gorm:"primaryKey;autoIncrement"`
+	UserID       int64     `json:"-" db:"user_internal_id" gorm:"column:user_internal_id"`
+	PasswordHash string    `json:"-" db:"password_hash"`
+	CreatedAt    time.Time `json:"created_at" db:"created_at"`
+}
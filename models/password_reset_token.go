@@ -0,0 +1,14 @@
+package models
+
+import "time"
+
+// PasswordResetToken is a single-use password reset token. Only the SHA-256
+// hash of the token is stored.
+type PasswordResetToken struct {
+	InternalID int64      `json:"internal_id" db:"internal_id" gorm:"primaryKey;autoIncrement"`
+	UserID     int64      `json:"user_internal_id" db:"user_internal_id" gorm:"column:user_internal_id"`
+	TokenHash  string     `json:"-" db:"token_hash"`
+	ExpiresAt  time.Time  `json:"expires_at" db:"expires_at"`
+	UsedAt     *time.Time `json:"used_at,omitempty" db:"used_at"`
+	CreatedAt  time.Time  `json:"created_at" db:"created_at"`
+}
@@ -0,0 +1,39 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/mohod24/go-project-management/models/types"
+)
+
+// Scopes a personal access token can be granted. A write scope includes the
+// matching read scope.
+const (
+	ScopeReadBoards  = "read:boards"
+	ScopeWriteBoards = "write:boards"
+	ScopeReadCards   = "read:cards"
+	ScopeWriteCards  = "write:cards"
+	ScopeReadUsers   = "read:users"
+	ScopeAdminUsers  = "admin:users"
+)
+
+var PersonalAccessTokenScopes = []string{
+	ScopeReadBoards, ScopeWriteBoards, ScopeReadCards, ScopeWriteCards, ScopeReadUsers, ScopeAdminUsers,
+}
+
+// PersonalAccessToken is a named, scoped API token for scripts and CI jobs.
+// Only the SHA-256 hash of the token is stored.
+type PersonalAccessToken struct {
+	InternalID  int64             `json:"-" db:"internal_id" gorm:"primaryKey;autoIncrement"`
+	PublicID    uuid.UUID         `json:"public_id" db:"public_id"`
+	UserID      int64             `json:"-" db:"user_internal_id" gorm:"column:user_internal_id"`
+	Name        string            `json:"name" db:"name"`
+	TokenHash   string            `json:"-" db:"token_hash"`
+	TokenPrefix string            `json:"token_prefix" db:"token_prefix"`
+	Scopes      types.StringArray `json:"scopes" db:"scopes" gorm:"type:text[]"`
+	ExpiresAt   *time.Time        `json:"expires_at" db:"expires_at"`
+	LastUsedAt  *time.Time        `json:"last_used_at" db:"last_used_at"`
+	RevokedAt   *time.Time        `json:"revoked_at,omitempty" db:"revoked_at"`
+	CreatedAt   time.Time         `json:"created_at" db:"created_at"`
+}
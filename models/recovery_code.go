@@ -0,0 +1,13 @@
+package models
+
+import "time"
+
+// RecoveryCode is a one-time two-factor recovery code. Only the SHA-256 hash
+// of the code is stored.
+type RecoveryCode struct {
+	InternalID int64      `json:"internal_id" db:"internal_id" gorm:"primaryKey;autoIncrement"`
+	UserID     int64      `json:"user_internal_id" db:"user_internal_id" gorm:"column:user_internal_id"`
+	CodeHash   string     `json:"-" db:"code_hash"`
+	UsedAt     *time.Time `json:"used_at,omitempty" db:"used_at"`
+	CreatedAt  time.Time  `json:"created_at" db:"created_at"`
+}
@@ -0,0 +1,29 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Kinds of search results.
+const (
+	SearchTypeBoard   = "board"
+	SearchTypeCard    = "card"
+	SearchTypeComment = "comment"
+)
+
+// SearchTypes are all kinds of search results.
+var SearchTypes = []string{SearchTypeBoard, SearchTypeCard, SearchTypeComment}
+
+// SearchResult is a board, card or comment matching a full-text search.
+type SearchResult struct {
+	Type          string     `json:"type"`
+	PublicID      uuid.UUID  `json:"public_id"`
+	BoardPublicID uuid.UUID  `json:"board_public_id"`
+	CardPublicID  *uuid.UUID `json:"card_public_id,omitempty"` // card of a comment
+	Title         string     `json:"title"`                    // board or card title; for comments the title of their card
+	Headline      string     `json:"headline"`                 // matching text, matches wrapped in <mark>
+	Rank          float64    `json:"rank"`
+	CreatedAt     time.Time  `json:"created_at"`
+}
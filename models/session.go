@@ -0,0 +1,23 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Session is one login of a user on a device. Its refresh token is rotated on
+// every refresh and only the SHA-256 hash of the current token ID is stored.
+type Session struct {
+	InternalID       int64      `json:"-" db:"internal_id" gorm:"primaryKey;autoIncrement"`
+	PublicID         uuid.UUID  `json:"public_id" db:"public_id"`
+	UserID           int64      `json:"-" db:"user_internal_id" gorm:"column:user_internal_id"`
+	RefreshTokenHash string     `json:"-" db:"refresh_token_hash"`
+	UserAgent        string     `json:"user_agent" db:"user_agent"`
+	IPAddress        string     `json:"ip_address" db:"ip_address"`
+	CreatedAt        time.Time  `json:"created_at" db:"created_at"`
+	LastUsedAt       time.Time  `json:"last_used_at" db:"last_used_at"`
+	ExpiresAt        time.Time  `json:"expires_at" db:"expires_at"`
+	RevokedAt        *time.Time `json:"revoked_at,omitempty" db:"revoked_at"`
+	Current          bool       `json:"current" gorm:"-"`
+}
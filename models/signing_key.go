@@ -0,0 +1,16 @@
+package models
+
+import "time"
+
+// SigningKey is a JWT signing key pair. The private key is stored as a PKCS #8
+// PEM block encrypted with APP_SECRET. The newest key whose ActivatesAt has
+// passed signs new tokens; every key verifies tokens until its ExpiresAt.
+type SigningKey struct {
+	InternalID  int64      `json:"-" db:"internal_id" gorm:"primaryKey;autoIncrement"`
+	KID         string     `json:"kid" db:"kid" gorm:"column:kid"`
+	Algorithm   string     `json:"algorithm" db:"algorithm"`
+	PrivateKey  string     `json:"-" db:"private_key"`
+	CreatedAt   time.Time  `json:"created_at" db:"created_at"`
+	ActivatesAt time.Time  `json:"activates_at" db:"activates_at"`
+	ExpiresAt   *time.Time `json:"expires_at,omitempty" db:"expires_at"`
+}
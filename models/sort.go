@@ -0,0 +1,34 @@
+package models
+
+// SortField is one column of a validated sort order.
+type SortField struct {
+	Column string
+	Desc   bool
+
+	// set instead of a column when sorting cards by a custom field
+	CustomField *CustomField
+}
+
+// Sortable fields of each listing: the public field name mapped to its column.
+var (
+	UserSortFields = map[string]string{
+		"id":         "internal_id",
+		"name":       "name",
+		"email":      "email",
+		"role":       "role",
+		"created_at": "created_at",
+		"updated_at": "updated_at",
+	}
+	BoardSortFields = map[string]string{
+		"title":      "title",
+		"due_date":   "due_date",
+		"created_at": "created_at",
+	}
+	// custom fields are added per board as cf.<key>
+	CardSortFields = map[string]string{
+		"title":      "title",
+		"due_date":   "due_date",
+		"position":   "position",
+		"created_at": "created_at",
+	}
+)
@@ -0,0 +1,15 @@
+package models
+
+import "time"
+
+// TwoFactorChallenge is the pending second step of a login. Only the SHA-256
+// hash of the challenge token is stored; Failures counts wrong codes.
+type TwoFactorChallenge struct {
+	InternalID   int64     `json:"internal_id" db:"internal_id" gorm:"primaryKey;autoIncrement"`
+	UserID       int64     `json:"user_internal_id" db:"user_internal_id" gorm:"column:user_internal_id"`
+	TokenHash    string    `json:"-" db:"token_hash"`
+	TokenVersion int       `json:"-" db:"token_version"`
+	Failures     int       `json:"failures" db:"failures"`
+	ExpiresAt    time.Time `json:"expires_at" db:"expires_at"`
+	CreatedAt    time.Time `json:"created_at" db:"created_at"`
+}
@@ -0,0 +1,36 @@
+package types
+
+import (
+	"database/sql/driver"
+	"encoding/json"
+	"errors"
+)
+
+// JSONStrings maps a Postgres jsonb column holding an array of strings. Unlike
+// StringArray its elements may contain any character.
+type JSONStrings []string
+
+func (a *JSONStrings) Scan(value interface{}) error {
+	switch v := value.(type) {
+	case []byte:
+		return json.Unmarshal(v, a)
+	case string:
+		return json.Unmarshal([]byte(v), a)
+	case nil:
+		*a = nil
+		return nil
+	}
+	return errors.New("failed to parse JSONStrings: unsupport data type")
+}
+
+func (a JSONStrings) Value() (driver.Value, error) {
+	if a == nil {
+		return "[]", nil
+	}
+	b, err := json.Marshal([]string(a))
+	return string(b), err
+}
+
+func (JSONStrings) GormDataType() string {
+	return "jsonb"
+}
@@ -0,0 +1,53 @@
+package types
+
+import (
+	"database/sql/driver"
+	"errors"
+	"strings"
+)
+
+// StringArray maps a Postgres text[] column. Elements must not contain
+// commas, quotes or braces, which holds for identifiers such as scopes.
+type StringArray []string
+
+func (a *StringArray) Scan(value interface{}) error {
+	var str string
+
+	switch v := value.(type) {
+	case []byte:
+		str = string(v)
+	case string:
+		str = v
+	default:
+		return errors.New("failed to parse StringArray: unsupport data type")
+	}
+
+	str = strings.TrimPrefix(str, "{")
+	str = strings.TrimSuffix(str, "}")
+	parts := strings.Split(str, ",")
+
+	*a = make(StringArray, 0, len(parts))
+	for _, s := range parts {
+		s = strings.TrimSpace(strings.Trim(s, `"`))
+		if s == "" {
+			continue
+		}
+		*a = append(*a, s)
+	}
+	return nil
+}
+
+func (a StringArray) Value() (driver.Value, error) {
+	if len(a) == 0 {
+		return "{}", nil
+	}
+	postgreFormat := make([]string, 0, len(a))
+	for _, value := range a {
+		postgreFormat = append(postgreFormat, `"`+value+`"`)
+	}
+	return "{" + strings.Join(postgreFormat, ",") + "}", nil
+}
+
+func (StringArray) GormDataType() string {
+	return "text[]"
+}
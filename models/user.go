@@ -1,30 +1,78 @@
 package models
 
 import (
+	"fmt"
 	"time"
 
 	"github.com/google/uuid"
 	"gorm.io/gorm"
 )
 
+// Due-date reminder preferences of a user.
+const (
+	ReminderAll     = "all"     // reminders before the due date and when overdue
+	ReminderOverdue = "overdue" // only when a card becomes overdue
+	ReminderNone    = "none"    // no reminders
+)
+
+// Locales supported by email templates.
+var SupportedLocales = []string{"en", "id"}
+
+// AvatarSizes are the square sizes, in pixels, every avatar is stored in.
+var AvatarSizes = []int{32, 64, 128, 256}
+
 type User struct {
-	InternalID int64          `json:"internal_id" db:"internal_id" gorm:"primaryKey"`
-	PublicID   uuid.UUID      `json:"public_id" db:"public_id" gorm:"column:public_id"`
-	Name       string         `json:"name" db:"name"`
-	Email      string         `json:"email" db:"email" gorm:"unique"`
-	Password   string         `json:"password" db:"password" gorm:"column:password"`
-	Role       string         `json:"role" db:"role"`
-	CreatedAt  time.Time      `json:"created_at" db:"created_at"`
-	UpdatedAt  time.Time      `json:"updated_at" db:"updated_at"`
-	DeletedAt  gorm.DeletedAt `json:"-" gorm:"index"`
+	InternalID         int64          `json:"internal_id" db:"internal_id" gorm:"primaryKey"`
+	PublicID           uuid.UUID      `json:"public_id" db:"public_id" gorm:"column:public_id"`
+	Name               string         `json:"name" db:"name"`
+	Email              string         `json:"email" db:"email" gorm:"unique"`
+	Password           string         `json:"password" db:"password" gorm:"column:password"`
+	Role               string         `json:"role" db:"role"`
+	ReminderPreference string         `json:"reminder_preference" db:"reminder_preference" gorm:"default:all"`
+	Locale             string         `json:"locale" db:"locale" gorm:"default:en"`
+	TokenVersion       int            `json:"-" db:"token_version"`
+	EmailVerified      bool           `json:"email_verified" db:"email_verified"`
+	EmailVerifiedAt    *time.Time     `json:"email_verified_at,omitempty" db:"email_verified_at"`
+	VerificationSentAt *time.Time     `json:"-" db:"verification_sent_at"`
+	TOTPSecret         *string        `json:"-" db:"totp_secret" gorm:"column:totp_secret"`
+	TOTPEnabled        bool           `json:"totp_enabled" db:"totp_enabled" gorm:"column:totp_enabled"`
+	TOTPLastStep       int64          `json:"-" db:"totp_last_step" gorm:"column:totp_last_step"`
+	TwoFactorRequired  bool           `json:"two_factor_required" db:"two_factor_required"`
+	OIDCIssuer         *string        `json:"-" db:"oidc_issuer" gorm:"column:oidc_issuer"`
+	OIDCSubject        *string        `json:"-" db:"oidc_subject" gorm:"column:oidc_subject"`
+	PendingEmail       *string        `json:"pending_email,omitempty" db:"pending_email"`
+	AvatarKey          *string        `json:"-" db:"avatar_key"`
+	CreatedAt          time.Time      `json:"created_at" db:"created_at"`
+	UpdatedAt          time.Time      `json:"updated_at" db:"updated_at"`
+	DeletedAt          gorm.DeletedAt `json:"-" gorm:"index"`
 }
 
 type UserResponse struct {
-	PublicID  uuid.UUID      `json:"public_id" `
-	Name      string         `json:"name" `
-	Email     string         `json:"email" `
-	Role      string         `json:"role" `
-	CreatedAt time.Time      `json:"created_at" `
-	UpdatedAt time.Time      `json:"updated_at" `
-	DeletedAt gorm.DeletedAt `json:"-"`
+	PublicID           uuid.UUID         `json:"public_id" `
+	Name               string            `json:"name" `
+	Email              string            `json:"email" `
+	Role               string            `json:"role" `
+	ReminderPreference string            `json:"reminder_preference"`
+	Locale             string            `json:"locale"`
+	EmailVerified      bool              `json:"email_verified"`
+	TOTPEnabled        bool              `json:"totp_enabled"`
+	TwoFactorRequired  bool              `json:"two_factor_required"`
+	PendingEmail       *string           `json:"pending_email,omitempty"`
+	AvatarURLs         map[string]string `json:"avatar_urls,omitempty"`
+	CreatedAt          time.Time         `json:"created_at" `
+	UpdatedAt          time.Time         `json:"updated_at" `
+	DeletedAt          gorm.DeletedAt    `json:"-"`
+}
+
+// AvatarURLs returns the path of the user's avatar in every size, keyed by
+// size, or nil when no avatar has been uploaded.
+func (u User) AvatarURLs() map[string]string {
+	if u.AvatarKey == nil {
+		return nil
+	}
+	urls := make(map[string]string, len(AvatarSizes))
+	for _, size := range AvatarSizes {
+		urls[fmt.Sprint(size)] = fmt.Sprintf("/avatars/%s_%d.png", *u.AvatarKey, size)
+	}
+	return urls
 }
@@ -0,0 +1,7 @@
+package models
+
+import "errors"
+
+// ErrVersionConflict is returned when a board, list or card is updated against
+// a version that is no longer current.
+var ErrVersionConflict = errors.New("the resource has been modified since it was read")
@@ -0,0 +1,17 @@
+package models
+
+import "time"
+
+// Entity types that can be watched.
+const (
+	WatchEntityBoard = "board"
+	WatchEntityList  = "list"
+	WatchEntityCard  = "card"
+)
+
+type Watcher struct {
+	UserID     int64     `json:"user_internal_id" db:"user_internal_id" gorm:"column:user_internal_id;primaryKey"`
+	EntityType string    `json:"entity_type" db:"entity_type" gorm:"primaryKey"`
+	EntityID   int64     `json:"entity_internal_id" db:"entity_internal_id" gorm:"column:entity_internal_id;primaryKey"`
+	CreatedAt  time.Time `json:"created_at" db:"created_at"`
+}
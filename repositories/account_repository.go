@@ -0,0 +1,152 @@
+package repositories
+
+import (
+	"time"
+
+	"github.com/mohod24/go-project-management/config"
+	"github.com/mohod24/go-project-management/models"
+	"gorm.io/gorm"
+)
+
+// AccountRepository defines the interface for the data export and account
+// deletion of a user, which span most tables.
+type AccountRepository interface {
+	FindOwnedBoards(userID uint) ([]models.Board, error)
+	Export(user *models.User) (*models.AccountExport, error)
+	ScheduleDeletion(userID uint, transfers map[uint]uint) error
+	Restore(userID uint) (bool, error)
+	FindDeletedBefore(before time.Time) ([]models.User, error)
+	Purge(userID uint) error
+}
+
+// accountRepository implements the AccountRepository interface.
+type accountRepository struct {
+}
+
+// NewAccountRepository creates a new instance of AccountRepository.
+func NewAccountRepository() AccountRepository {
+	return &accountRepository{}
+}
+
+// FindOwnedBoards returns the boards owned by a user.
+func (r *accountRepository) FindOwnedBoards(userID uint) ([]models.Board, error) {
+	var boards []models.Board
+	err := config.DB.Where("owner_internal_id = ?", userID).Order("created_at ASC").Find(&boards).Error
+	return boards, err
+}
+
+// Export collects the user's owned boards with their lists and cards, the
+// cards assigned to them, and their comments and attachments.
+func (r *accountRepository) Export(user *models.User) (*models.AccountExport, error) {
+	export := &models.AccountExport{}
+
+	boards, err := r.FindOwnedBoards(uint(user.InternalID))
+	if err != nil {
+		return nil, err
+	}
+	for _, board := range boards {
+		var lists []models.List
+		if err := config.DB.Where("board_internal_id = ?", board.InternalID).Order("created_at ASC").Find(&lists).Error; err != nil {
+			return nil, err
+		}
+		exported := models.ExportedBoard{Board: board, Lists: []models.ExportedList{}}
+		for _, list := range lists {
+			cards := []models.Card{}
+			if err := config.DB.Where("list_internal_id = ?", list.InternalID).Order("position ASC").Find(&cards).Error; err != nil {
+				return nil, err
+			}
+			exported.Lists = append(exported.Lists, models.ExportedList{List: list, Cards: cards})
+		}
+		export.Boards = append(export.Boards, exported)
+	}
+
+	export.Cards = []models.Card{}
+	if err := config.DB.Joins("JOIN card_assignees ON card_assignees.card_internal_id = cards.internal_id").
+		Where("card_assignees.user_internal_id = ?", user.InternalID).
+		Order("cards.created_at ASC").Find(&export.Cards).Error; err != nil {
+		return nil, err
+	}
+	export.Comments = []models.Comment{}
+	if err := config.DB.Where("user_internal_id = ?", user.InternalID).Order("created_at ASC").Find(&export.Comments).Error; err != nil {
+		return nil, err
+	}
+	export.Attachments = []models.CardAttachment{}
+	if err := config.DB.Where("user_internal_id = ?", user.InternalID).Order("created_at ASC").Find(&export.Attachments).Error; err != nil {
+		return nil, err
+	}
+	return export, nil
+}
+
+// ScheduleDeletion hands the boards in transfers (board ID to new owner ID)
+// to their new owners and deactivates the account by soft-deleting it, which
+// also invalidates every token and session. Boards that are not transferred
+// stay with the user and are deleted along with the account when it is purged.
+func (r *accountRepository) ScheduleDeletion(userID uint, transfers map[uint]uint) error {
+	return config.DB.Transaction(func(tx *gorm.DB) error {
+		for boardID, ownerID := range transfers {
+			if err := tx.Model(&models.Board{}).Where("internal_id = ? AND owner_internal_id = ?", boardID, userID).Updates(map[string]interface{}{
+				"owner_internal_id": ownerID,
+				"owner_public_id":   tx.Model(&models.User{}).Select("public_id").Where("internal_id = ?", ownerID),
+			}).Error; err != nil {
+				return err
+			}
+			// pemilik baru tidak perlu tercatat lagi sebagai member
+			if err := tx.Where("board_internal_id = ? AND user_internal_id = ?", boardID, ownerID).
+				Delete(&models.BoardMember{}).Error; err != nil {
+				return err
+			}
+		}
+
+		now := time.Now()
+		if err := tx.Model(&models.Session{}).Where("user_internal_id = ? AND revoked_at IS NULL", userID).
+			Update("revoked_at", now).Error; err != nil {
+			return err
+		}
+		if err := tx.Model(&models.PersonalAccessToken{}).Where("user_internal_id = ? AND revoked_at IS NULL", userID).
+			Update("revoked_at", now).Error; err != nil {
+			return err
+		}
+		return tx.Model(&models.User{}).Where("internal_id = ?", userID).Updates(map[string]interface{}{
+			"token_version": gorm.Expr("token_version + 1"),
+			"deleted_at":    now,
+		}).Error
+	})
+}
+
+// Restore reactivates an account that is scheduled for deletion. It returns
+// false when the account was already purged or is not scheduled for deletion.
+func (r *accountRepository) Restore(userID uint) (bool, error) {
+	result := config.DB.Unscoped().Model(&models.User{}).
+		Where("internal_id = ? AND deleted_at IS NOT NULL", userID).
+		Update("deleted_at", nil)
+	return result.RowsAffected > 0, result.Error
+}
+
+// FindDeletedBefore returns the accounts that were deleted before the given time.
+func (r *accountRepository) FindDeletedBefore(before time.Time) ([]models.User, error) {
+	var users []models.User
+	err := config.DB.Unscoped().Where("deleted_at IS NOT NULL AND deleted_at <= ?", before).Find(&users).Error
+	return users, err
+}
+
+// Purge permanently removes a deleted account. Comments the user wrote stay
+// on their cards without an author and checklist items assigned to the user
+// become unassigned; everything else the user owns is removed by the foreign
+// keys of the users table.
+func (r *accountRepository) Purge(userID uint) error {
+	return config.DB.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Model(&models.Comment{}).Where("user_internal_id = ?", userID).Updates(map[string]interface{}{
+			"user_internal_id": nil,
+			"user_public_id":   nil,
+		}).Error; err != nil {
+			return err
+		}
+		if err := tx.Model(&models.ChecklistItem{}).Where("assignee_internal_id = ?", userID).Updates(map[string]interface{}{
+			"assignee_internal_id": nil,
+			"assignee_public_id":   nil,
+		}).Error; err != nil {
+			return err
+		}
+		return tx.Unscoped().Where("internal_id = ? AND deleted_at IS NOT NULL", userID).Delete(&models.User{}).Error
+	})
+}
@@ -0,0 +1,80 @@
+package repositories
+
+import (
+	"time"
+
+	"github.com/mohod24/go-project-management/config"
+	"github.com/mohod24/go-project-management/models"
+)
+
+// BoardInviteRepository defines the interface for board invite database operations.
+type BoardInviteRepository interface {
+	Create(invite *models.BoardInvite) error
+	Update(invite *models.BoardInvite) error
+	FindByPublicID(publicID string) (*models.BoardInvite, error)
+	FindPendingByBoardAndEmail(boardID uint, email string) (*models.BoardInvite, error)
+	FindPendingByBoard(boardID uint) ([]models.BoardInvite, error)
+	FindPendingByEmail(email string) ([]models.BoardInvite, error)
+}
+
+// boardInviteRepository implements the BoardInviteRepository interface.
+type boardInviteRepository struct {
+}
+
+// NewBoardInviteRepository creates a new instance of BoardInviteRepository.
+func NewBoardInviteRepository() BoardInviteRepository {
+	return &boardInviteRepository{}
+}
+
+// Create saves a new invite to the database.
+func (r *boardInviteRepository) Create(invite *models.BoardInvite) error {
+	return config.DB.Create(invite).Error
+}
+
+// Update modifies the state of an existing invite.
+func (r *boardInviteRepository) Update(invite *models.BoardInvite) error {
+	return config.DB.Model(&models.BoardInvite{}).Where("internal_id = ?", invite.InternalID).Updates(map[string]interface{}{
+		"status":           invite.Status,
+		"user_internal_id": invite.UserID,
+		"expires_at":       invite.ExpiresAt,
+		"accepted_at":      invite.AcceptedAt,
+	}).Error
+}
+
+// FindByPublicID retrieves an invite and its board by the invite's public ID.
+func (r *boardInviteRepository) FindByPublicID(publicID string) (*models.BoardInvite, error) {
+	var invite models.BoardInvite
+	err := config.DB.Preload("Board").Where("public_id = ?", publicID).First(&invite).Error
+	if err != nil {
+		return nil, err
+	}
+	return &invite, nil
+}
+
+// FindPendingByBoardAndEmail retrieves the pending invite of an email address to a board.
+func (r *boardInviteRepository) FindPendingByBoardAndEmail(boardID uint, email string) (*models.BoardInvite, error) {
+	var invite models.BoardInvite
+	err := config.DB.Where("board_internal_id = ? AND email = ? AND status = ?", boardID, email, models.InviteStatusPending).
+		First(&invite).Error
+	if err != nil {
+		return nil, err
+	}
+	return &invite, nil
+}
+
+// FindPendingByBoard retrieves the unexpired pending invites of a board.
+func (r *boardInviteRepository) FindPendingByBoard(boardID uint) ([]models.BoardInvite, error) {
+	var invites []models.BoardInvite
+	err := config.DB.Where("board_internal_id = ? AND status = ? AND expires_at > ?", boardID, models.InviteStatusPending, time.Now()).
+		Order("created_at DESC").Find(&invites).Error
+	return invites, err
+}
+
+// FindPendingByEmail retrieves the unexpired pending invites sent to an email address.
+func (r *boardInviteRepository) FindPendingByEmail(email string) ([]models.BoardInvite, error) {
+	var invites []models.BoardInvite
+	err := config.DB.Preload("Board").
+		Where("LOWER(email) = LOWER(?) AND status = ? AND expires_at > ?", email, models.InviteStatusPending, time.Now()).
+		Order("created_at DESC").Find(&invites).Error
+	return invites, err
+}
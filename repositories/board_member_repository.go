@@ -7,6 +7,7 @@ import (
 
 type BoardMemberRepository interface {
 	GetMembers(boardPublicID string) ([]models.User, error)
+	IsMember(boardID, userID uint) (bool, error)
 }
 
 type boardMemberRepository struct {
@@ -23,4 +24,11 @@ func (r *boardMemberRepository) GetMembers(boardPublicID string) ([]models.User,
 		Where("boards.public_id = ?", boardPublicID).
 		Find(&users).Error
 	return users, err
-}
\ No newline at end of file
+}
+func (r *boardMemberRepository) IsMember(boardID, userID uint) (bool, error) {
+	var count int64
+	err := config.DB.Model(&models.BoardMember{}).
+		Where("board_internal_id = ? AND user_internal_id = ?", boardID, userID).
+		Count(&count).Error
+	return count > 0, err
+}
@@ -5,6 +5,7 @@ import (
 
 	"github.com/mohod24/go-project-management/config"
 	"github.com/mohod24/go-project-management/models"
+	"gorm.io/gorm"
 )
 
 // BoardRepository defines the interface for board-related database operations.
@@ -12,6 +13,10 @@ type BoardRepository interface {
 	Create(board *models.Board) error
 	Update(board *models.Board) error
 	FindByPublicID(publicID string) (*models.Board, error)
+	FindByID(id uint) (*models.Board, error)
+	FindByPublicIDWith(publicID string, includes []string) (*models.Board, error)
+	FindAccessible(userID uint, sort []models.SortField, includes []string, limit, offset int) ([]models.Board, int64, error)
+	FindAccessibleCursor(userID uint, includes []string, page models.CursorPage) ([]models.Board, models.CursorResult, error)
 	AddMember(boardID uint, userIDs []uint) error
 	RemoveMembers(boardID uint, userIDs []uint) error
 }
@@ -31,13 +36,19 @@ func (r *boardRepository) Create(board *models.Board) error {
 	return config.DB.Create(board).Error
 }
 
-// Update modifies an existing board in the database.
+// Update modifies an existing board in the database. board.Version is the
+// version the change was made against and is set to the new version.
 func (r *boardRepository) Update(board *models.Board) error {
-	return config.DB.Model(&models.Board{}).Where("public_id = ?", board.PublicID).Updates(map[string]interface{}{
+	var updated models.Board
+	if err := updateVersioned(&updated, board.PublicID, board.Version, map[string]interface{}{
 		"title":        board.Title,
 		"description":  board.Description,
 		"due_date":     board.DueDate,
-	}).Error
+	}); err != nil {
+		return err
+	}
+	board.Version = updated.Version
+	return nil
 }
 
 // FindByPublicID retrieves a board by its public ID.
@@ -50,6 +61,16 @@ func (r *boardRepository) FindByPublicID(publicID string) (*models.Board, error)
 	return &board, nil
 }
 
+// FindByID retrieves a board by its internal ID.
+func (r *boardRepository) FindByID(id uint) (*models.Board, error) {
+	var board models.Board
+	err := config.DB.First(&board, id).Error
+	if err != nil {
+		return nil, err
+	}
+	return &board, nil
+}
+
 // AddMember adds members to a board.
 func (r *boardRepository) AddMember(boardID uint, userIDs []uint) error {
 	// Implementation for adding members to a board
@@ -80,3 +101,46 @@ func (r* boardRepository) RemoveMembers(boardID uint, userIDs []uint) error {
 	}
 	return config.DB.Where("board_internal_id = ? AND user_internal_id IN ?", boardID, userIDs).Delete(&models.BoardMember{}).Error
 }
+
+// accessibleBoards selects the boards a user owns or is a member of.
+func accessibleBoards(userID uint) *gorm.DB {
+	return config.DB.Model(&models.Board{}).
+		Where("owner_internal_id = ? OR internal_id IN (?)", userID,
+			config.DB.Model(&models.BoardMember{}).Select("board_internal_id").Where("user_internal_id = ?", userID))
+}
+
+// FindAccessible retrieves the boards a user can access, newest first unless
+// sorted otherwise, with page number pagination.
+func (r *boardRepository) FindAccessible(userID uint, sort []models.SortField, includes []string, limit, offset int) ([]models.Board, int64, error) {
+	var boards []models.Board
+	var total int64
+	db := accessibleBoards(userID)
+	if err := db.Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+	if len(sort) == 0 {
+		sort = []models.SortField{{Column: "created_at", Desc: true}}
+	}
+	err := preloadIncludes(applySort(db, sort, "boards"), "board", includes).Limit(limit).Offset(offset).Find(&boards).Error
+	return boards, total, err
+}
+
+// FindAccessibleCursor retrieves the boards a user can access, newest first,
+// one keyset page at a time.
+func (r *boardRepository) FindAccessibleCursor(userID uint, includes []string, page models.CursorPage) ([]models.Board, models.CursorResult, error) {
+	ks := keyset{columns: []string{"created_at", "internal_id"}, types: []string{"timestamp", "bigint"}, desc: true}
+	return findPage(preloadIncludes(accessibleBoards(userID), "board", includes), ks, page, func(b models.Board) []string {
+		return []string{cursorTime(b.CreatedAt), cursorID(b.InternalID)}
+	})
+}
+
+// FindByPublicIDWith retrieves a board by its public ID together with the
+// requested relations.
+func (r *boardRepository) FindByPublicIDWith(publicID string, includes []string) (*models.Board, error) {
+	var board models.Board
+	err := preloadIncludes(config.DB, "board", includes).Where("public_id = ?", publicID).First(&board).Error
+	if err != nil {
+		return nil, err
+	}
+	return &board, nil
+}
@@ -0,0 +1,344 @@
+package repositories
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/mohod24/go-project-management/config"
+	"github.com/mohod24/go-project-management/models"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// likeEscaper escapes LIKE wildcards in user input.
+var likeEscaper = strings.NewReplacer(`\`, `\\`, "%", `\%`, "_", `\_`)
+
+// CardRepository defines the interface for card-related database operations.
+type CardRepository interface {
+	Create(card *models.Card) error
+	Update(card *models.Card) error
+	FindByPublicID(publicID string) (*models.Card, error)
+	FindByID(id uint) (*models.Card, error)
+	FindByPublicIDWith(publicID string, includes []string) (*models.Card, error)
+	AddAssignees(cardID uint, userIDs []uint) error
+	FindFiltered(boardID, listID uint, terms []models.CardQueryTerm, actorID uint, includes []string, sort []models.SortField, page *models.CursorPage) ([]models.Card, models.CursorResult, error)
+	FindInBoard(boardID uint, publicIDs []uuid.UUID) ([]models.Card, error)
+	FindLabel(boardID uint, publicID string) (*models.Label, error)
+	BulkApply(op models.CardBulkOperation, cards []models.Card, atomic bool) ([]error, error)
+}
+
+// cardRepository implements the CardRepository interface.
+type cardRepository struct {
+}
+
+// NewCardRepository creates a new instance of CardRepository.
+func NewCardRepository() CardRepository {
+	return &cardRepository{}
+}
+
+// Create saves a new card to the database.
+func (r *cardRepository) Create(card *models.Card) error {
+	return config.DB.Create(card).Error
+}
+
+// Update modifies an existing card in the database. card.Version is the
+// version the change was made against and is set to the new version.
+func (r *cardRepository) Update(card *models.Card) error {
+	var updated models.Card
+	if err := updateVersioned(&updated, card.PublicID, card.Version, map[string]interface{}{
+		"title":       card.Title,
+		"description": card.Description,
+		"due_date":    card.DueDate,
+	}); err != nil {
+		return err
+	}
+	card.Version = updated.Version
+	return nil
+}
+
+// FindByPublicID retrieves a card and its assignees by the card's public ID.
+func (r *cardRepository) FindByPublicID(publicID string) (*models.Card, error) {
+	var card models.Card
+	err := config.DB.Preload("Assigness").Where("public_id = ?", publicID).First(&card).Error
+	if err != nil {
+		return nil, err
+	}
+	return &card, nil
+}
+
+// FindByID retrieves a card by its internal ID.
+func (r *cardRepository) FindByID(id uint) (*models.Card, error) {
+	var card models.Card
+	err := config.DB.Where("internal_id = ?", id).First(&card).Error
+	if err != nil {
+		return nil, err
+	}
+	return &card, nil
+}
+
+// FindByPublicIDWith retrieves a card by its public ID together with the
+// requested relations.
+func (r *cardRepository) FindByPublicIDWith(publicID string, includes []string) (*models.Card, error) {
+	var card models.Card
+	err := preloadIncludes(config.DB, "card", includes).Where("public_id = ?", publicID).First(&card).Error
+	if err != nil {
+		return nil, err
+	}
+	return &card, nil
+}
+
+// AddAssignees assigns users to a card, ignoring users that are already assigned.
+func (r *cardRepository) AddAssignees(cardID uint, userIDs []uint) error {
+	if len(userIDs) == 0 {
+		return nil
+	}
+	var assignees []models.CardAssignee
+	for _, userID := range userIDs {
+		assignees = append(assignees, models.CardAssignee{
+			CardID: int64(cardID),
+			UserID: int64(userID),
+		})
+	}
+	return config.DB.Clauses(clause.OnConflict{DoNothing: true}).Create(&assignees).Error
+}
+
+// FindInBoard retrieves the cards with the given public IDs that belong to a
+// board. Cards of other boards are left out.
+func (r *cardRepository) FindInBoard(boardID uint, publicIDs []uuid.UUID) ([]models.Card, error) {
+	var cards []models.Card
+	err := config.DB.
+		Joins("JOIN lists ON lists.internal_id = cards.list_internal_id").
+		Where("lists.board_internal_id = ? AND cards.public_id IN ?", boardID, publicIDs).
+		Find(&cards).Error
+	return cards, err
+}
+
+// FindLabel retrieves a label of a board by its public ID.
+func (r *cardRepository) FindLabel(boardID uint, publicID string) (*models.Label, error) {
+	var label models.Label
+	err := config.DB.Where("board_internal_id = ? AND public_id = ?", boardID, publicID).First(&label).Error
+	if err != nil {
+		return nil, err
+	}
+	return &label, nil
+}
+
+// errBulkRolledBack aborts the transaction of an atomic bulk operation.
+var errBulkRolledBack = errors.New("bulk operation rolled back")
+
+// BulkApply applies a bulk operation to cards in one transaction and returns
+// the error of every card that failed, indexed like cards. When atomic is set
+// the first failure rolls the whole transaction back. Otherwise every card
+// runs in its own savepoint, so a failure only undoes that card.
+func (r *cardRepository) BulkApply(op models.CardBulkOperation, cards []models.Card, atomic bool) ([]error, error) {
+	failures := make([]error, len(cards))
+	err := config.DB.Transaction(func(tx *gorm.DB) error {
+		for i := range cards {
+			if atomic {
+				if err := applyBulkOperation(tx, op, &cards[i]); err != nil {
+					failures[i] = err
+					return errBulkRolledBack
+				}
+				continue
+			}
+			// transaksi bersarang memakai savepoint
+			failures[i] = tx.Transaction(func(sp *gorm.DB) error {
+				return applyBulkOperation(sp, op, &cards[i])
+			})
+		}
+		return nil
+	})
+	if errors.Is(err, errBulkRolledBack) {
+		return failures, nil
+	}
+	return failures, err
+}
+
+// applyBulkOperation applies a bulk operation to a single card. Changes to the
+// card's own columns bump its version.
+func applyBulkOperation(tx *gorm.DB, op models.CardBulkOperation, card *models.Card) error {
+	switch op.Operation {
+	case models.CardBulkMove:
+		if card.ListID == op.ListID {
+			return nil
+		}
+		// card pindahan diletakkan di akhir list tujuan
+		position, err := nextPosition(tx, "cards", "list_internal_id", op.ListID)
+		if err != nil {
+			return err
+		}
+		return updateBulkCard(tx, card, map[string]interface{}{
+			"list_internal_id": op.ListID,
+			"position":         position,
+		})
+	case models.CardBulkAddLabel:
+		return tx.Clauses(clause.OnConflict{DoNothing: true}).
+			Create(&models.CardLabel{CardID: card.InternalID, LabelID: op.LabelID}).Error
+	case models.CardBulkRemoveLabel:
+		return tx.Where("card_internal_id = ? AND label_internal_id = ?", card.InternalID, op.LabelID).
+			Delete(&models.CardLabel{}).Error
+	case models.CardBulkAssign:
+		return tx.Clauses(clause.OnConflict{DoNothing: true}).
+			Create(&models.CardAssignee{CardID: card.InternalID, UserID: op.UserID}).Error
+	case models.CardBulkUnassign:
+		return tx.Where("card_internal_id = ? AND user_internal_id = ?", card.InternalID, op.UserID).
+			Delete(&models.CardAssignee{}).Error
+	case models.CardBulkSetDueDate:
+		return updateBulkCard(tx, card, map[string]interface{}{"due_date": op.DueDate})
+	case models.CardBulkArchive:
+		if card.ArchivedAt != nil {
+			return nil
+		}
+		return updateBulkCard(tx, card, map[string]interface{}{"archived_at": time.Now()})
+	case models.CardBulkDelete:
+		// watcher tidak punya foreign key ke cards
+		if err := tx.Where("entity_type = ? AND entity_internal_id = ?", models.WatchEntityCard, card.InternalID).
+			Delete(&models.Watcher{}).Error; err != nil {
+			return err
+		}
+		return tx.Where("internal_id = ?", card.InternalID).Delete(&models.Card{}).Error
+	}
+	return fmt.Errorf("unsupported bulk operation %q", op.Operation)
+}
+
+// updateBulkCard updates columns of a card and bumps its version.
+func updateBulkCard(tx *gorm.DB, card *models.Card, updates map[string]interface{}) error {
+	updates["version"] = gorm.Expr("version + 1")
+	return tx.Model(&models.Card{}).Where("internal_id = ?", card.InternalID).Updates(updates).Error
+}
+
+// FindFiltered retrieves the cards of a board, or of one of its lists when
+// listID is not 0, that match every term of a parsed card query. actorID is
+// the user that assignee:@me refers to. Without a page every matching card
+// is returned, in list and position order unless sort is given.
+func (r *cardRepository) FindFiltered(boardID, listID uint, terms []models.CardQueryTerm, actorID uint, includes []string, sort []models.SortField, page *models.CursorPage) ([]models.Card, models.CursorResult, error) {
+	db := config.DB.Model(&models.Card{}).
+		Joins("JOIN lists ON lists.internal_id = cards.list_internal_id").
+		Where("lists.board_internal_id = ?", boardID)
+	if listID != 0 {
+		db = db.Where("cards.list_internal_id = ?", listID)
+	}
+	for _, term := range terms {
+		condition, args := cardQueryCondition(term, actorID)
+		if term.Negated {
+			// COALESCE supaya card tanpa due date ikut cocok dengan -due:<7d
+			condition = "NOT COALESCE((" + condition + "), false)"
+		}
+		db = db.Where(condition, args...)
+	}
+
+	db = preloadIncludes(db, "card", includes)
+
+	ks := keyset{
+		columns: []string{"cards.list_internal_id", "cards.position", "cards.internal_id"},
+		types:   []string{"bigint", "int", "bigint"},
+	}
+	if page != nil {
+		return findPage(db, ks, *page, func(c models.Card) []string {
+			return []string{cursorID(c.ListID), strconv.Itoa(c.Position), cursorID(c.InternalID)}
+		})
+	}
+	var cards []models.Card
+	if len(sort) > 0 {
+		db = applyCardSort(db, sort)
+	} else {
+		db = db.Order(strings.Join(ks.columns, ", "))
+	}
+	err := db.Find(&cards).Error
+	return cards, models.CursorResult{}, err
+}
+
+// applyCardSort orders cards by validated sort fields. Every custom field is
+// joined under its own alias, and cards without a value for it come last.
+func applyCardSort(db *gorm.DB, sort []models.SortField) *gorm.DB {
+	for i, field := range sort {
+		if field.CustomField == nil {
+			db = db.Order(clause.OrderByColumn{Column: clause.Column{Table: "cards", Name: field.Column}, Desc: field.Desc})
+			continue
+		}
+		alias := "cf_sort_" + strconv.Itoa(i)
+		db = db.Joins("LEFT JOIN card_custom_field_values "+alias+" ON "+alias+".card_internal_id = cards.internal_id"+
+			" AND "+alias+".custom_field_internal_id = ?", field.CustomField.InternalID)
+		direction := " ASC"
+		if field.Desc {
+			direction = " DESC"
+		}
+		db = db.Order(alias + "." + customFieldColumns[field.CustomField.Type] + direction + " NULLS LAST")
+	}
+	return db.Order(clause.OrderByColumn{Column: clause.Column{Table: "cards", Name: "internal_id"}})
+}
+
+// cardQueryCondition turns a card query term into a parameterized SQL condition.
+func cardQueryCondition(term models.CardQueryTerm, actorID uint) (string, []interface{}) {
+	switch term.Field {
+	case models.CardQueryLabel:
+		return "EXISTS (SELECT 1 FROM card_labels JOIN labels ON labels.internal_id = card_labels.label_internal_id" +
+			" WHERE card_labels.card_internal_id = cards.internal_id AND lower(labels.name) = lower(?))", []interface{}{term.Value}
+	case models.CardQueryAssignee:
+		switch term.Value {
+		case "@me":
+			return "EXISTS (SELECT 1 FROM card_assignees WHERE card_assignees.card_internal_id = cards.internal_id" +
+				" AND card_assignees.user_internal_id = ?)", []interface{}{actorID}
+		case "none":
+			return "NOT EXISTS (SELECT 1 FROM card_assignees WHERE card_assignees.card_internal_id = cards.internal_id)", nil
+		}
+		// selain @me dan none, assignee dicari lewat email atau public ID
+		return "EXISTS (SELECT 1 FROM card_assignees JOIN users ON users.internal_id = card_assignees.user_internal_id" +
+			" WHERE card_assignees.card_internal_id = cards.internal_id" +
+			" AND (lower(users.email) = lower(?) OR users.public_id::text = ?))", []interface{}{term.Value, term.Value}
+	case models.CardQueryList:
+		return "lower(lists.title) = lower(?)", []interface{}{term.Value}
+	case models.CardQueryDue:
+		switch term.Op {
+		case "":
+			return "cards.due_date IS NULL", nil
+		case "=":
+			return "cards.due_date >= ? AND cards.due_date < ?", []interface{}{*term.Time, term.Time.AddDate(0, 0, 1)}
+		}
+		return "cards.due_date " + term.Op + " ?", []interface{}{*term.Time}
+	case models.CardQueryIs:
+		if term.Value == "overdue" {
+			return "cards.due_date < ? AND cards.archived_at IS NULL", []interface{}{*term.Time}
+		}
+		return "cards.archived_at IS NOT NULL", nil
+	case models.CardQueryCustomField:
+		return customFieldCondition(term)
+	}
+	pattern := "%" + likeEscaper.Replace(term.Value) + "%"
+	return "(cards.title ILIKE ? OR cards.description ILIKE ?)", []interface{}{pattern, pattern}
+}
+
+// customFieldCondition turns a resolved cf.<key> term into a condition on the
+// card's value for that field.
+func customFieldCondition(term models.CardQueryTerm) (string, []interface{}) {
+	field := term.CustomField
+	exists := "EXISTS (SELECT 1 FROM card_custom_field_values v WHERE v.card_internal_id = cards.internal_id" +
+		" AND v.custom_field_internal_id = ?"
+	args := []interface{}{field.InternalID}
+	if term.Value == "none" && term.Op == "" {
+		return "NOT " + exists + ")", args
+	}
+
+	switch field.Type {
+	case models.CustomFieldText:
+		return exists + " AND v.text_value ILIKE ?)", append(args, "%"+likeEscaper.Replace(term.Value)+"%")
+	case models.CustomFieldDropdown:
+		return exists + " AND lower(v.text_value) = lower(?))", append(args, term.Value)
+	case models.CustomFieldNumber:
+		return exists + " AND v.number_value " + term.Op + " ?)", append(args, *term.Number)
+	case models.CustomFieldDate:
+		if term.Op == "=" {
+			return exists + " AND v.date_value >= ? AND v.date_value < ?)", append(args, *term.Time, term.Time.AddDate(0, 0, 1))
+		}
+		return exists + " AND v.date_value " + term.Op + " ?)", append(args, *term.Time)
+	}
+	// checkbox: card tanpa nilai dianggap tidak dicentang
+	if term.Value == "true" {
+		return exists + " AND v.bool_value)", args
+	}
+	return "NOT " + exists + " AND v.bool_value)", args
+}
@@ -0,0 +1,239 @@
+package repositories
+
+import (
+	"slices"
+
+	"github.com/mohod24/go-project-management/config"
+	"github.com/mohod24/go-project-management/models"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// ChecklistRepository defines the interface for checklist-related database operations.
+// Every change to items recounts the checklist progress stored on the card.
+type ChecklistRepository interface {
+	Create(checklist *models.Checklist) error
+	Update(checklist *models.Checklist, position int) error
+	Delete(checklist *models.Checklist) error
+	FindByPublicID(publicID string) (*models.Checklist, error)
+	FindByID(id uint) (*models.Checklist, error)
+	FindByCard(cardID uint) ([]models.Checklist, error)
+	CreateItem(checklist *models.Checklist, item *models.ChecklistItem) error
+	UpdateItem(item *models.ChecklistItem, position int) error
+	SetItemChecked(checklist *models.Checklist, item *models.ChecklistItem) error
+	DeleteItem(checklist *models.Checklist, item *models.ChecklistItem) error
+	FindItemByPublicID(publicID string) (*models.ChecklistItem, error)
+	ConvertItem(checklist *models.Checklist, item *models.ChecklistItem, card *models.Card) error
+}
+
+// checklistRepository implements the ChecklistRepository interface.
+type checklistRepository struct {
+}
+
+// NewChecklistRepository creates a new instance of ChecklistRepository.
+func NewChecklistRepository() ChecklistRepository {
+	return &checklistRepository{}
+}
+
+// Create adds a checklist after the existing checklists of its card.
+func (r *checklistRepository) Create(checklist *models.Checklist) error {
+	return config.DB.Transaction(func(tx *gorm.DB) error {
+		position, err := nextPosition(tx, "checklists", "card_internal_id", checklist.CardID)
+		if err != nil {
+			return err
+		}
+		checklist.Position = position
+		return tx.Create(checklist).Error
+	})
+}
+
+// Update renames a checklist and, when position is not 0, moves it to that
+// 1-based position among the checklists of its card.
+func (r *checklistRepository) Update(checklist *models.Checklist, position int) error {
+	return config.DB.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Model(&models.Checklist{}).Where("internal_id = ?", checklist.InternalID).
+			Update("title", checklist.Title).Error; err != nil {
+			return err
+		}
+		if position == 0 {
+			return nil
+		}
+		return reorder(tx, "checklists", "card_internal_id", checklist.CardID, checklist.InternalID, position)
+	})
+}
+
+// Delete removes a checklist together with its items.
+func (r *checklistRepository) Delete(checklist *models.Checklist) error {
+	return config.DB.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("internal_id = ?", checklist.InternalID).Delete(&models.Checklist{}).Error; err != nil {
+			return err
+		}
+		return recountChecklists(tx, checklist.CardID)
+	})
+}
+
+// FindByPublicID retrieves a checklist and its items by the checklist's public ID.
+func (r *checklistRepository) FindByPublicID(publicID string) (*models.Checklist, error) {
+	var checklist models.Checklist
+	err := config.DB.Preload("Items", orderItems).Where("public_id = ?", publicID).First(&checklist).Error
+	if err != nil {
+		return nil, err
+	}
+	return &checklist, nil
+}
+
+// FindByID retrieves a checklist by its internal ID.
+func (r *checklistRepository) FindByID(id uint) (*models.Checklist, error) {
+	var checklist models.Checklist
+	err := config.DB.Where("internal_id = ?", id).First(&checklist).Error
+	if err != nil {
+		return nil, err
+	}
+	return &checklist, nil
+}
+
+// FindByCard retrieves the checklists of a card with their items, in order.
+func (r *checklistRepository) FindByCard(cardID uint) ([]models.Checklist, error) {
+	var checklists []models.Checklist
+	err := config.DB.
+		Preload("Items", orderItems).
+		Where("card_internal_id = ?", cardID).
+		Order("position, internal_id").
+		Find(&checklists).Error
+	return checklists, err
+}
+
+// CreateItem adds an item at the end of a checklist.
+func (r *checklistRepository) CreateItem(checklist *models.Checklist, item *models.ChecklistItem) error {
+	return config.DB.Transaction(func(tx *gorm.DB) error {
+		position, err := nextPosition(tx, "checklist_items", "checklist_internal_id", checklist.InternalID)
+		if err != nil {
+			return err
+		}
+		item.ChecklistID = checklist.InternalID
+		item.Position = position
+		if err := tx.Create(item).Error; err != nil {
+			return err
+		}
+		return recountChecklists(tx, checklist.CardID)
+	})
+}
+
+// UpdateItem changes the title, assignee and due date of an item and, when
+// position is not 0, moves it to that 1-based position in its checklist.
+func (r *checklistRepository) UpdateItem(item *models.ChecklistItem, position int) error {
+	return config.DB.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Model(&models.ChecklistItem{}).Where("internal_id = ?", item.InternalID).Updates(map[string]interface{}{
+			"title":                item.Title,
+			"assignee_internal_id": item.AssigneeID,
+			"assignee_public_id":   item.AssigneePublicID,
+			"due_date":             item.DueDate,
+		}).Error; err != nil {
+			return err
+		}
+		if position == 0 {
+			return nil
+		}
+		return reorder(tx, "checklist_items", "checklist_internal_id", item.ChecklistID, item.InternalID, position)
+	})
+}
+
+// SetItemChecked stores whether an item is checked, and by whom.
+func (r *checklistRepository) SetItemChecked(checklist *models.Checklist, item *models.ChecklistItem) error {
+	return config.DB.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Model(&models.ChecklistItem{}).Where("internal_id = ?", item.InternalID).Updates(map[string]interface{}{
+			"checked_at":           item.CheckedAt,
+			"checked_by_public_id": item.CheckedByID,
+		}).Error; err != nil {
+			return err
+		}
+		return recountChecklists(tx, checklist.CardID)
+	})
+}
+
+// DeleteItem removes an item from a checklist.
+func (r *checklistRepository) DeleteItem(checklist *models.Checklist, item *models.ChecklistItem) error {
+	return config.DB.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("internal_id = ?", item.InternalID).Delete(&models.ChecklistItem{}).Error; err != nil {
+			return err
+		}
+		return recountChecklists(tx, checklist.CardID)
+	})
+}
+
+// FindItemByPublicID retrieves a checklist item by its public ID.
+func (r *checklistRepository) FindItemByPublicID(publicID string) (*models.ChecklistItem, error) {
+	var item models.ChecklistItem
+	err := config.DB.Where("public_id = ?", publicID).First(&item).Error
+	if err != nil {
+		return nil, err
+	}
+	return &item, nil
+}
+
+// ConvertItem replaces a checklist item with card, which is created at the end
+// of its list and assigned to the item's assignee.
+func (r *checklistRepository) ConvertItem(checklist *models.Checklist, item *models.ChecklistItem, card *models.Card) error {
+	return config.DB.Transaction(func(tx *gorm.DB) error {
+		position, err := nextPosition(tx, "cards", "list_internal_id", card.ListID)
+		if err != nil {
+			return err
+		}
+		card.Position = position
+		if err := tx.Create(card).Error; err != nil {
+			return err
+		}
+		if item.AssigneeID != nil {
+			if err := tx.Clauses(clause.OnConflict{DoNothing: true}).
+				Create(&models.CardAssignee{CardID: card.InternalID, UserID: *item.AssigneeID}).Error; err != nil {
+				return err
+			}
+		}
+		if err := tx.Where("internal_id = ?", item.InternalID).Delete(&models.ChecklistItem{}).Error; err != nil {
+			return err
+		}
+		return recountChecklists(tx, checklist.CardID)
+	})
+}
+
+// orderItems orders preloaded checklist items by position.
+func orderItems(db *gorm.DB) *gorm.DB {
+	return db.Order("checklist_items.position, checklist_items.internal_id")
+}
+
+// recountChecklists stores the checklist progress of a card on the card.
+func recountChecklists(tx *gorm.DB, cardID int64) error {
+	return tx.Exec(`UPDATE cards SET
+		checklist_total = (SELECT COUNT(*) FROM checklist_items JOIN checklists ON checklists.internal_id = checklist_items.checklist_internal_id
+			WHERE checklists.card_internal_id = cards.internal_id),
+		checklist_checked = (SELECT COUNT(checklist_items.checked_at) FROM checklist_items JOIN checklists ON checklists.internal_id = checklist_items.checklist_internal_id
+			WHERE checklists.card_internal_id = cards.internal_id)
+		WHERE internal_id = ?`, cardID).Error
+}
+
+// nextPosition returns the position after the last row of table whose
+// parentColumn is parentID.
+func nextPosition(tx *gorm.DB, table, parentColumn string, parentID int64) (int, error) {
+	var position int
+	err := tx.Table(table).Where(parentColumn+" = ?", parentID).
+		Select("COALESCE(MAX(position), 0) + 1").Scan(&position).Error
+	return position, err
+}
+
+// reorder moves a row of table to a 1-based position among the rows sharing
+// its parent and renumbers them from 1.
+func reorder(tx *gorm.DB, table, parentColumn string, parentID, rowID int64, position int) error {
+	var ids []int64
+	if err := tx.Table(table).Where(parentColumn+" = ? AND internal_id <> ?", parentID, rowID).
+		Order("position, internal_id").Pluck("internal_id", &ids).Error; err != nil {
+		return err
+	}
+	index := min(max(position, 1), len(ids)+1) - 1
+	ids = slices.Insert(ids, index, rowID)
+	for i, id := range ids {
+		if err := tx.Table(table).Where("internal_id = ?", id).Update("position", i+1).Error; err != nil {
+			return err
+		}
+	}
+	return nil
+}
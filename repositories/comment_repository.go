@@ -0,0 +1,44 @@
+package repositories
+
+import (
+	"github.com/mohod24/go-project-management/config"
+	"github.com/mohod24/go-project-management/models"
+)
+
+// CommentRepository defines the interface for comment-related database operations.
+type CommentRepository interface {
+	Create(comment *models.Comment) error
+	FindByCardID(cardID uint) ([]models.Comment, error)
+	FindByCardIDCursor(cardID uint, page models.CursorPage) ([]models.Comment, models.CursorResult, error)
+}
+
+// commentRepository implements the CommentRepository interface.
+type commentRepository struct {
+}
+
+// NewCommentRepository creates a new instance of CommentRepository.
+func NewCommentRepository() CommentRepository {
+	return &commentRepository{}
+}
+
+// Create saves a new comment to the database.
+func (r *commentRepository) Create(comment *models.Comment) error {
+	return config.DB.Create(comment).Error
+}
+
+// FindByCardID retrieves all comments of a card, oldest first.
+func (r *commentRepository) FindByCardID(cardID uint) ([]models.Comment, error) {
+	var comments []models.Comment
+	err := config.DB.Where("card_internal_id = ?", cardID).Order("created_at ASC").Find(&comments).Error
+	return comments, err
+}
+
+// FindByCardIDCursor retrieves the comments of a card, oldest first, one
+// keyset page at a time.
+func (r *commentRepository) FindByCardIDCursor(cardID uint, page models.CursorPage) ([]models.Comment, models.CursorResult, error) {
+	db := config.DB.Model(&models.Comment{}).Where("card_internal_id = ?", cardID)
+	ks := keyset{columns: []string{"created_at", "internal_id"}, types: []string{"timestamp", "bigint"}}
+	return findPage(db, ks, page, func(c models.Comment) []string {
+		return []string{cursorTime(c.CreatedAt), cursorID(c.InternalID)}
+	})
+}
@@ -0,0 +1,104 @@
+package repositories
+
+import (
+	"errors"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/mohod24/go-project-management/models"
+	"gorm.io/gorm"
+)
+
+// errCursorMismatch is returned for a cursor that belongs to a listing with
+// a different order.
+var errCursorMismatch = errors.New("invalid or expired cursor")
+
+// keyset is the order of a cursor-paginated listing. The columns are compared
+// as one row, so together they must be unique; types are the SQL types the
+// cursor values are cast to.
+type keyset struct {
+	columns []string
+	types   []string
+	desc    bool
+}
+
+// findPage loads one page of a keyset-paginated query. It fetches one row
+// more than the limit to find out whether there is a page beyond it, and
+// key returns the cursor values of a row.
+func findPage[T any](db *gorm.DB, ks keyset, page models.CursorPage, key func(T) []string) ([]T, models.CursorResult, error) {
+	var result models.CursorResult
+	forward := page.Cursor == nil || page.Cursor.Direction == models.CursorNext
+	desc := ks.desc
+	if !forward {
+		// halaman sebelumnya diambil dengan urutan terbalik lalu dibalik lagi
+		desc = !desc
+	}
+
+	if page.Cursor != nil {
+		if len(page.Cursor.Key) != len(ks.columns) {
+			return nil, result, errCursorMismatch
+		}
+		placeholders := make([]string, len(ks.types))
+		args := make([]interface{}, len(ks.types))
+		for i, t := range ks.types {
+			placeholders[i] = "CAST(? AS " + t + ")"
+			args[i] = page.Cursor.Key[i]
+		}
+		op := " > "
+		if desc {
+			op = " < "
+		}
+		db = db.Where("("+strings.Join(ks.columns, ", ")+")"+op+"("+strings.Join(placeholders, ", ")+")", args...)
+	}
+	for _, column := range ks.columns {
+		if desc {
+			db = db.Order(column + " DESC")
+		} else {
+			db = db.Order(column + " ASC")
+		}
+	}
+
+	var rows []T
+	if err := db.Limit(page.Limit + 1).Find(&rows).Error; err != nil {
+		return nil, result, err
+	}
+	more := len(rows) > page.Limit
+	if more {
+		rows = rows[:page.Limit]
+	}
+	if !forward {
+		slices.Reverse(rows)
+	}
+	if len(rows) == 0 {
+		return rows, result, nil
+	}
+
+	first := &models.Cursor{Direction: models.CursorPrev, Key: key(rows[0])}
+	last := &models.Cursor{Direction: models.CursorNext, Key: key(rows[len(rows)-1])}
+	if forward {
+		if more {
+			result.Next = last
+		}
+		if page.Cursor != nil {
+			result.Prev = first
+		}
+	} else {
+		if more {
+			result.Prev = first
+		}
+		result.Next = last
+	}
+	return rows, result, nil
+}
+
+// cursorID formats an ID column value for a cursor.
+func cursorID(id int64) string {
+	return strconv.FormatInt(id, 10)
+}
+
+// cursorTime formats a timestamp column value for a cursor.
+func cursorTime(t time.Time) string {
+	return t.Format(time.RFC3339Nano)
+}
@@ -0,0 +1,109 @@
+package repositories
+
+import (
+	"github.com/mohod24/go-project-management/config"
+	"github.com/mohod24/go-project-management/models"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// customFieldColumns maps each custom field type to the value column it uses.
+var customFieldColumns = map[string]string{
+	models.CustomFieldText:     "text_value",
+	models.CustomFieldDropdown: "text_value",
+	models.CustomFieldNumber:   "number_value",
+	models.CustomFieldDate:     "date_value",
+	models.CustomFieldCheckbox: "bool_value",
+}
+
+// CustomFieldRepository defines the interface for custom field definitions and
+// their values on cards.
+type CustomFieldRepository interface {
+	Create(field *models.CustomField) error
+	Update(field *models.CustomField) error
+	Delete(field *models.CustomField) error
+	FindByPublicID(publicID string) (*models.CustomField, error)
+	FindByBoard(boardID uint) ([]models.CustomField, error)
+	SetValue(value *models.CardCustomFieldValue) error
+	ClearValue(cardID, fieldID uint) error
+}
+
+// customFieldRepository implements the CustomFieldRepository interface.
+type customFieldRepository struct {
+}
+
+// NewCustomFieldRepository creates a new instance of CustomFieldRepository.
+func NewCustomFieldRepository() CustomFieldRepository {
+	return &customFieldRepository{}
+}
+
+// Create adds a custom field after the existing fields of its board.
+func (r *customFieldRepository) Create(field *models.CustomField) error {
+	return config.DB.Transaction(func(tx *gorm.DB) error {
+		position, err := nextPosition(tx, "custom_fields", "board_internal_id", field.BoardID)
+		if err != nil {
+			return err
+		}
+		field.Position = position
+		return tx.Create(field).Error
+	})
+}
+
+// Update renames a custom field and replaces its dropdown options. Values that
+// are no longer one of the options are removed.
+func (r *customFieldRepository) Update(field *models.CustomField) error {
+	return config.DB.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Model(&models.CustomField{}).Where("internal_id = ?", field.InternalID).Updates(map[string]interface{}{
+			"name":    field.Name,
+			"key":     field.Key,
+			"options": field.Options,
+		}).Error; err != nil {
+			return err
+		}
+		if field.Type != models.CustomFieldDropdown {
+			return nil
+		}
+		query := tx.Where("custom_field_internal_id = ?", field.InternalID)
+		if len(field.Options) > 0 {
+			query = query.Where("text_value NOT IN ?", []string(field.Options))
+		}
+		return query.Delete(&models.CardCustomFieldValue{}).Error
+	})
+}
+
+// Delete removes a custom field together with its values.
+func (r *customFieldRepository) Delete(field *models.CustomField) error {
+	return config.DB.Where("internal_id = ?", field.InternalID).Delete(&models.CustomField{}).Error
+}
+
+// FindByPublicID retrieves a custom field by its public ID.
+func (r *customFieldRepository) FindByPublicID(publicID string) (*models.CustomField, error) {
+	var field models.CustomField
+	err := config.DB.Where("public_id = ?", publicID).First(&field).Error
+	if err != nil {
+		return nil, err
+	}
+	return &field, nil
+}
+
+// FindByBoard retrieves the custom fields of a board in order.
+func (r *customFieldRepository) FindByBoard(boardID uint) ([]models.CustomField, error) {
+	var fields []models.CustomField
+	err := config.DB.Where("board_internal_id = ?", boardID).Order("position, internal_id").Find(&fields).Error
+	return fields, err
+}
+
+// SetValue stores the value of a custom field on a card, replacing any
+// previous value.
+func (r *customFieldRepository) SetValue(value *models.CardCustomFieldValue) error {
+	return config.DB.Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "card_internal_id"}, {Name: "custom_field_internal_id"}},
+		DoUpdates: clause.AssignmentColumns([]string{"text_value", "number_value", "date_value", "bool_value"}),
+	}).Create(value).Error
+}
+
+// ClearValue removes the value of a custom field from a card.
+func (r *customFieldRepository) ClearValue(cardID, fieldID uint) error {
+	return config.DB.Where("card_internal_id = ? AND custom_field_internal_id = ?", cardID, fieldID).
+		Delete(&models.CardCustomFieldValue{}).Error
+}
@@ -0,0 +1,71 @@
+package repositories
+
+import (
+	"time"
+
+	"github.com/mohod24/go-project-management/config"
+	"github.com/mohod24/go-project-management/models"
+	"gorm.io/gorm/clause"
+)
+
+// IdempotencyKeyRepository defines the interface for stored idempotent responses.
+type IdempotencyKeyRepository interface {
+	Reserve(record *models.IdempotencyKey) (bool, error)
+	Find(userID int64, key string) (*models.IdempotencyKey, error)
+	Complete(record *models.IdempotencyKey) error
+	Delete(id int64) error
+	DeleteExpired(now time.Time) error
+}
+
+// idempotencyKeyRepository implements the IdempotencyKeyRepository interface.
+type idempotencyKeyRepository struct {
+}
+
+// NewIdempotencyKeyRepository creates a new instance of IdempotencyKeyRepository.
+func NewIdempotencyKeyRepository() IdempotencyKeyRepository {
+	return &idempotencyKeyRepository{}
+}
+
+// Reserve stores a pending record for the user's key. It reports false when
+// the key is already taken by a record that has not expired yet.
+func (r *idempotencyKeyRepository) Reserve(record *models.IdempotencyKey) (bool, error) {
+	// kunci yang sudah kedaluwarsa boleh dipakai lagi
+	if err := config.DB.Where("user_internal_id = ? AND key = ? AND expires_at <= ?", record.UserID, record.Key, time.Now()).
+		Delete(&models.IdempotencyKey{}).Error; err != nil {
+		return false, err
+	}
+	result := config.DB.Clauses(clause.OnConflict{DoNothing: true}).Create(record)
+	if result.Error != nil {
+		return false, result.Error
+	}
+	return result.RowsAffected == 1, nil
+}
+
+// Find retrieves the record of a user's key.
+func (r *idempotencyKeyRepository) Find(userID int64, key string) (*models.IdempotencyKey, error) {
+	var record models.IdempotencyKey
+	err := config.DB.Where("user_internal_id = ? AND key = ?", userID, key).First(&record).Error
+	if err != nil {
+		return nil, err
+	}
+	return &record, nil
+}
+
+// Complete stores the response of a pending record.
+func (r *idempotencyKeyRepository) Complete(record *models.IdempotencyKey) error {
+	return config.DB.Model(&models.IdempotencyKey{}).Where("internal_id = ?", record.InternalID).Updates(map[string]interface{}{
+		"status_code":   record.StatusCode,
+		"content_type":  record.ContentType,
+		"response_body": record.ResponseBody,
+	}).Error
+}
+
+// Delete removes a record so its key can be used again.
+func (r *idempotencyKeyRepository) Delete(id int64) error {
+	return config.DB.Where("internal_id = ?", id).Delete(&models.IdempotencyKey{}).Error
+}
+
+// DeleteExpired removes every record whose replay window has ended.
+func (r *idempotencyKeyRepository) DeleteExpired(now time.Time) error {
+	return config.DB.Where("expires_at <= ?", now).Delete(&models.IdempotencyKey{}).Error
+}
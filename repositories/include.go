@@ -0,0 +1,45 @@
+package repositories
+
+import (
+	"gorm.io/gorm"
+)
+
+// includePreloads maps the ?include= names of each resource to GORM
+// preloads. A preload loads a relation for all rows in one extra query, so
+// includes never turn into one query per row.
+var includePreloads = map[string]func(db *gorm.DB) *gorm.DB{
+	// board
+	"board:lists": func(db *gorm.DB) *gorm.DB {
+		return db.Preload("Lists", func(db *gorm.DB) *gorm.DB { return db.Order("lists.created_at, lists.internal_id") })
+	},
+	"board:lists.cards": func(db *gorm.DB) *gorm.DB {
+		return db.Preload("Lists.Cards", func(db *gorm.DB) *gorm.DB { return db.Order("cards.position, cards.internal_id") })
+	},
+	"board:members": func(db *gorm.DB) *gorm.DB { return db.Preload("Members") },
+	// list
+	"list:cards": func(db *gorm.DB) *gorm.DB {
+		return db.Preload("Cards", func(db *gorm.DB) *gorm.DB { return db.Order("cards.position, cards.internal_id") })
+	},
+	// card
+	"card:assignees":     func(db *gorm.DB) *gorm.DB { return db.Preload("Assigness") },
+	"card:labels":        func(db *gorm.DB) *gorm.DB { return db.Preload("Labels") },
+	"card:attachments":   func(db *gorm.DB) *gorm.DB { return db.Preload("Attachments") },
+	"card:custom_fields": func(db *gorm.DB) *gorm.DB { return db.Preload("CustomFields") },
+	"card:checklists": func(db *gorm.DB) *gorm.DB {
+		return db.
+			Preload("Checklists", func(db *gorm.DB) *gorm.DB { return db.Order("checklists.position, checklists.internal_id") }).
+			Preload("Checklists.Items", func(db *gorm.DB) *gorm.DB {
+				return db.Order("checklist_items.position, checklist_items.internal_id")
+			})
+	},
+}
+
+// preloadIncludes adds the preloads of validated includes of a resource.
+func preloadIncludes(db *gorm.DB, resource string, includes []string) *gorm.DB {
+	for _, include := range includes {
+		if preload, ok := includePreloads[resource+":"+include]; ok {
+			db = preload(db)
+		}
+	}
+	return db
+}
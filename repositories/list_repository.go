@@ -0,0 +1,73 @@
+package repositories
+
+import (
+	"github.com/mohod24/go-project-management/config"
+	"github.com/mohod24/go-project-management/models"
+)
+
+// ListRepository defines the interface for list-related database operations.
+type ListRepository interface {
+	Create(list *models.List) error
+	Update(list *models.List) error
+	FindByPublicID(publicID string) (*models.List, error)
+	FindByID(id uint) (*models.List, error)
+	FindByPublicIDWith(publicID string, includes []string) (*models.List, error)
+}
+
+// listRepository implements the ListRepository interface.
+type listRepository struct {
+}
+
+// NewListRepository creates a new instance of ListRepository.
+func NewListRepository() ListRepository {
+	return &listRepository{}
+}
+
+// Create saves a new list to the database.
+func (r *listRepository) Create(list *models.List) error {
+	return config.DB.Create(list).Error
+}
+
+// Update modifies an existing list in the database. list.Version is the
+// version the change was made against and is set to the new version.
+func (r *listRepository) Update(list *models.List) error {
+	var updated models.List
+	if err := updateVersioned(&updated, list.PublicID, list.Version, map[string]interface{}{
+		"title": list.Title,
+	}); err != nil {
+		return err
+	}
+	list.Version = updated.Version
+	return nil
+}
+
+// FindByPublicID retrieves a list by its public ID.
+func (r *listRepository) FindByPublicID(publicID string) (*models.List, error) {
+	var list models.List
+	err := config.DB.Where("public_id = ?", publicID).First(&list).Error
+	if err != nil {
+		return nil, err
+	}
+	return &list, nil
+}
+
+// FindByID retrieves a list by its internal ID.
+func (r *listRepository) FindByID(id uint) (*models.List, error) {
+	var list models.List
+	err := config.DB.First(&list, id).Error
+	if err != nil {
+		return nil, err
+	}
+	return &list, nil
+}
+
+// FindByPublicIDWith retrieves a list by its public ID together with the
+// requested relations.
+func (r *listRepository) FindByPublicIDWith(publicID string, includes []string) (*models.List, error) {
+	var list models.List
+	err := preloadIncludes(config.DB, "list", includes).Where("public_id = ?", publicID).First(&list).Error
+	if err != nil {
+		return nil, err
+	}
+	return &list, nil
+}
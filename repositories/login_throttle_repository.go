@@ -0,0 +1,72 @@
+package repositories
+
+import (
+	"time"
+
+	"github.com/mohod24/go-project-management/config"
+	"github.com/mohod24/go-project-management/models"
+)
+
+// LoginThrottleRepository defines the interface for failed login bookkeeping.
+type LoginThrottleRepository interface {
+	Find(scope, subject string) (*models.LoginThrottle, error)
+	FindLocked(now time.Time) ([]models.LoginThrottle, error)
+	RecordFailure(scope, subject string, now, windowStart time.Time) (*models.LoginThrottle, error)
+	Lock(scope, subject string, until time.Time) error
+	Clear(scope, subject string) error
+}
+
+// loginThrottleRepository implements the LoginThrottleRepository interface.
+type loginThrottleRepository struct {
+}
+
+// NewLoginThrottleRepository creates a new instance of LoginThrottleRepository.
+func NewLoginThrottleRepository() LoginThrottleRepository {
+	return &loginThrottleRepository{}
+}
+
+// Find retrieves the failure counter of an account or IP address.
+func (r *loginThrottleRepository) Find(scope, subject string) (*models.LoginThrottle, error) {
+	var throttle models.LoginThrottle
+	err := config.DB.Where("scope = ? AND subject = ?", scope, subject).First(&throttle).Error
+	if err != nil {
+		return nil, err
+	}
+	return &throttle, nil
+}
+
+// FindLocked retrieves every account and IP address that is locked at now.
+func (r *loginThrottleRepository) FindLocked(now time.Time) ([]models.LoginThrottle, error) {
+	var throttles []models.LoginThrottle
+	err := config.DB.Where("locked_until > ?", now).Order("locked_until DESC").Find(&throttles).Error
+	return throttles, err
+}
+
+// RecordFailure atomically increments the failure counter and returns it. The
+// counter starts over when the last failure happened before windowStart.
+func (r *loginThrottleRepository) RecordFailure(scope, subject string, now, windowStart time.Time) (*models.LoginThrottle, error) {
+	var throttle models.LoginThrottle
+	err := config.DB.Raw(`INSERT INTO login_throttles (scope, subject, failures, last_failed_at)
+		VALUES (?, ?, 1, ?)
+		ON CONFLICT (scope, subject) DO UPDATE SET
+			failures = CASE WHEN login_throttles.last_failed_at <= ? THEN 1 ELSE login_throttles.failures + 1 END,
+			last_failed_at = EXCLUDED.last_failed_at
+		RETURNING scope, subject, failures, last_failed_at, locked_until`,
+		scope, subject, now, windowStart).Scan(&throttle).Error
+	if err != nil {
+		return nil, err
+	}
+	return &throttle, nil
+}
+
+// Lock blocks logins for an account or IP address until the given time.
+func (r *loginThrottleRepository) Lock(scope, subject string, until time.Time) error {
+	return config.DB.Model(&models.LoginThrottle{}).
+		Where("scope = ? AND subject = ?", scope, subject).
+		Update("locked_until", until).Error
+}
+
+// Clear resets the failure counter and lifts any lockout.
+func (r *loginThrottleRepository) Clear(scope, subject string) error {
+	return config.DB.Where("scope = ? AND subject = ?", scope, subject).Delete(&models.LoginThrottle{}).Error
+}
@@ -0,0 +1,79 @@
+package repositories
+
+import (
+	"time"
+
+	"github.com/mohod24/go-project-management/config"
+	"github.com/mohod24/go-project-management/models"
+)
+
+// NotificationRepository defines the interface for notification-related database operations.
+type NotificationRepository interface {
+	CreateBulk(notifications []models.Notification) error
+	FindByUser(userID uint, unreadOnly bool, limit, offset int) ([]models.Notification, int64, error)
+	FindByUserCursor(userID uint, unreadOnly bool, page models.CursorPage) ([]models.Notification, models.CursorResult, error)
+	MarkRead(userID uint, publicID string) error
+	MarkAllRead(userID uint) error
+}
+
+// notificationRepository implements the NotificationRepository interface.
+type notificationRepository struct {
+}
+
+// NewNotificationRepository creates a new instance of NotificationRepository.
+func NewNotificationRepository() NotificationRepository {
+	return &notificationRepository{}
+}
+
+// CreateBulk saves several notifications in a single insert.
+func (r *notificationRepository) CreateBulk(notifications []models.Notification) error {
+	if len(notifications) == 0 {
+		return nil
+	}
+	return config.DB.Create(&notifications).Error
+}
+
+// FindByUser retrieves a user's notifications, newest first.
+func (r *notificationRepository) FindByUser(userID uint, unreadOnly bool, limit, offset int) ([]models.Notification, int64, error) {
+	var notifications []models.Notification
+	var total int64
+
+	db := config.DB.Model(&models.Notification{}).Where("user_internal_id = ?", userID)
+	if unreadOnly {
+		db = db.Where("read_at IS NULL")
+	}
+	if err := db.Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	err := db.Order("created_at DESC").Limit(limit).Offset(offset).Find(&notifications).Error
+	return notifications, total, err
+}
+
+// FindByUserCursor retrieves a user's notifications, newest first, one keyset
+// page at a time.
+func (r *notificationRepository) FindByUserCursor(userID uint, unreadOnly bool, page models.CursorPage) ([]models.Notification, models.CursorResult, error) {
+	db := config.DB.Model(&models.Notification{}).Where("user_internal_id = ?", userID)
+	if unreadOnly {
+		db = db.Where("read_at IS NULL")
+	}
+	ks := keyset{columns: []string{"created_at", "internal_id"}, types: []string{"timestamp", "bigint"}, desc: true}
+	return findPage(db, ks, page, func(n models.Notification) []string {
+		return []string{cursorTime(n.CreatedAt), cursorID(n.InternalID)}
+	})
+}
+
+// MarkRead marks a single notification of a user as read.
+func (r *notificationRepository) MarkRead(userID uint, publicID string) error {
+	result := config.DB.Model(&models.Notification{}).
+		Where("user_internal_id = ? AND public_id = ? AND read_at IS NULL", userID, publicID).
+		Update("read_at", time.Now())
+	return result.Error
+}
+
+// MarkAllRead marks every unread notification of a user as read.
+func (r *notificationRepository) MarkAllRead(userID uint) error {
+	return config.DB.Model(&models.Notification{}).
+		Where("user_internal_id = ? AND read_at IS NULL", userID).
+		Update("read_at", time.Now()).Error
+}
@@ -0,0 +1,38 @@
+package repositories
+
+import (
+	"github.com/mohod24/go-project-management/config"
+	"github.com/mohod24/go-project-management/models"
+	"gorm.io/gorm"
+)
+
+// PasswordHistoryRepository defines the interface for reading previous password hashes.
+type PasswordHistoryRepository interface {
+	FindRecentHashes(userID uint, limit int) ([]string, error)
+}
+
+// passwordHistoryRepository implements the PasswordHistoryRepository interface.
+type passwordHistoryRepository struct {
+}
+
+// NewPasswordHistoryRepository creates a new instance of PasswordHistoryRepository.
+func NewPasswordHistoryRepository() PasswordHistoryRepository {
+	return &passwordHistoryRepository{}
+}
+
+// FindRecentHashes retrieves the user's most recent previous password hashes.
+func (r *passwordHistoryRepository) FindRecentHashes(userID uint, limit int) ([]string, error) {
+	var hashes []string
+	err := config.DB.Model(&models.PasswordHistory{}).
+		Where("user_internal_id = ?", userID).
+		Order("created_at DESC").Limit(limit).
+		Pluck("password_hash", &hashes).Error
+	return hashes, err
+}
+
+// archivePassword copies the user's current password hash into the history
+// before it is replaced. It must run inside the transaction that changes it.
+func archivePassword(tx *gorm.DB, userID int64) error {
+	return tx.Exec(`INSERT INTO password_histories (user_internal_id, password_hash, created_at)
+		SELECT internal_id, password, NOW() FROM users WHERE internal_id = ?`, userID).Error
+}
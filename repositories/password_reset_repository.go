@@ -0,0 +1,79 @@
+package repositories
+
+import (
+	"time"
+
+	"github.com/mohod24/go-project-management/config"
+	"github.com/mohod24/go-project-management/models"
+	"gorm.io/gorm"
+)
+
+// PasswordResetRepository defines the interface for password reset token operations.
+type PasswordResetRepository interface {
+	Create(token *models.PasswordResetToken) error
+	FindValidByHash(tokenHash string) (*models.PasswordResetToken, error)
+	ResetPassword(token *models.PasswordResetToken, passwordHash string) error
+}
+
+// passwordResetRepository implements the PasswordResetRepository interface.
+type passwordResetRepository struct {
+}
+
+// NewPasswordResetRepository creates a new instance of PasswordResetRepository.
+func NewPasswordResetRepository() PasswordResetRepository {
+	return &passwordResetRepository{}
+}
+
+// Create stores a new reset token and invalidates the user's older unused tokens.
+func (r *passwordResetRepository) Create(token *models.PasswordResetToken) error {
+	return config.DB.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Model(&models.PasswordResetToken{}).
+			Where("user_internal_id = ? AND used_at IS NULL", token.UserID).
+			Update("used_at", time.Now()).Error; err != nil {
+			return err
+		}
+		return tx.Create(token).Error
+	})
+}
+
+// FindValidByHash retrieves an unused, unexpired token by its hash.
+func (r *passwordResetRepository) FindValidByHash(tokenHash string) (*models.PasswordResetToken, error) {
+	var token models.PasswordResetToken
+	err := config.DB.Where("token_hash = ? AND used_at IS NULL AND expires_at > ?", tokenHash, time.Now()).
+		First(&token).Error
+	if err != nil {
+		return nil, err
+	}
+	return &token, nil
+}
+
+// ResetPassword consumes the token, stores the new password hash, ends every
+// session and bumps the user's token version so every previously issued token
+// stops working.
+func (r *passwordResetRepository) ResetPassword(token *models.PasswordResetToken, passwordHash string) error {
+	return config.DB.Transaction(func(tx *gorm.DB) error {
+		// used_at IS NULL memastikan token hanya bisa dipakai sekali
+		result := tx.Model(&models.PasswordResetToken{}).
+			Where("internal_id = ? AND used_at IS NULL", token.InternalID).
+			Update("used_at", time.Now())
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected == 0 {
+			return gorm.ErrRecordNotFound
+		}
+		if err := archivePassword(tx, token.UserID); err != nil {
+			return err
+		}
+		if err := tx.Model(&models.Session{}).
+			Where("user_internal_id = ? AND revoked_at IS NULL", token.UserID).
+			Update("revoked_at", time.Now()).Error; err != nil {
+			return err
+		}
+		return tx.Model(&models.User{}).Where("internal_id = ?", token.UserID).Updates(map[string]interface{}{
+			"password":      passwordHash,
+			"token_version": gorm.Expr("token_version + 1"),
+			"updated_at":    time.Now(),
+		}).Error
+	})
+}
@@ -0,0 +1,73 @@
+package repositories
+
+import (
+	"time"
+
+	"github.com/mohod24/go-project-management/config"
+	"github.com/mohod24/go-project-management/models"
+	"gorm.io/gorm"
+)
+
+// PersonalAccessTokenRepository defines the interface for personal access token operations.
+type PersonalAccessTokenRepository interface {
+	Create(token *models.PersonalAccessToken) error
+	FindActiveByHash(tokenHash string, now time.Time) (*models.PersonalAccessToken, error)
+	FindByUser(userID uint) ([]models.PersonalAccessToken, error)
+	Revoke(userID uint, publicID string) error
+	TouchLastUsed(id uint, now time.Time) error
+}
+
+// personalAccessTokenRepository implements the PersonalAccessTokenRepository interface.
+type personalAccessTokenRepository struct {
+}
+
+// NewPersonalAccessTokenRepository creates a new instance of PersonalAccessTokenRepository.
+func NewPersonalAccessTokenRepository() PersonalAccessTokenRepository {
+	return &personalAccessTokenRepository{}
+}
+
+// Create stores a new personal access token.
+func (r *personalAccessTokenRepository) Create(token *models.PersonalAccessToken) error {
+	return config.DB.Create(token).Error
+}
+
+// FindActiveByHash retrieves an unrevoked, unexpired token by its hash.
+func (r *personalAccessTokenRepository) FindActiveByHash(tokenHash string, now time.Time) (*models.PersonalAccessToken, error) {
+	var token models.PersonalAccessToken
+	err := config.DB.Where("token_hash = ? AND revoked_at IS NULL AND (expires_at IS NULL OR expires_at > ?)", tokenHash, now).
+		First(&token).Error
+	if err != nil {
+		return nil, err
+	}
+	return &token, nil
+}
+
+// FindByUser retrieves every unrevoked token of a user, newest first.
+func (r *personalAccessTokenRepository) FindByUser(userID uint) ([]models.PersonalAccessToken, error) {
+	var tokens []models.PersonalAccessToken
+	err := config.DB.Where("user_internal_id = ? AND revoked_at IS NULL", userID).
+		Order("created_at DESC").Find(&tokens).Error
+	return tokens, err
+}
+
+// Revoke marks one of the user's tokens as revoked.
+func (r *personalAccessTokenRepository) Revoke(userID uint, publicID string) error {
+	result := config.DB.Model(&models.PersonalAccessToken{}).
+		Where("public_id = ? AND user_internal_id = ? AND revoked_at IS NULL", publicID, userID).
+		Update("revoked_at", time.Now())
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return gorm.ErrRecordNotFound
+	}
+	return nil
+}
+
+// TouchLastUsed records that a token was used. To avoid a write on every
+// request, the timestamp is only moved forward once per minute.
+func (r *personalAccessTokenRepository) TouchLastUsed(id uint, now time.Time) error {
+	return config.DB.Model(&models.PersonalAccessToken{}).
+		Where("internal_id = ? AND (last_used_at IS NULL OR last_used_at < ?)", id, now.Add(-time.Minute)).
+		Update("last_used_at", now).Error
+}
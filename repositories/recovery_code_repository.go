@@ -0,0 +1,63 @@
+package repositories
+
+import (
+	"time"
+
+	"github.com/mohod24/go-project-management/config"
+	"github.com/mohod24/go-project-management/models"
+	"gorm.io/gorm"
+)
+
+// RecoveryCodeRepository defines the interface for two-factor recovery code operations.
+type RecoveryCodeRepository interface {
+	Replace(userID uint, codeHashes []string) error
+	Consume(userID uint, codeHash string) (bool, error)
+	CountUnused(userID uint) (int64, error)
+	DeleteByUser(userID uint) error
+}
+
+// recoveryCodeRepository implements the RecoveryCodeRepository interface.
+type recoveryCodeRepository struct {
+}
+
+// NewRecoveryCodeRepository creates a new instance of RecoveryCodeRepository.
+func NewRecoveryCodeRepository() RecoveryCodeRepository {
+	return &recoveryCodeRepository{}
+}
+
+// Replace deletes the user's recovery codes and stores a new set.
+func (r *recoveryCodeRepository) Replace(userID uint, codeHashes []string) error {
+	return config.DB.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("user_internal_id = ?", userID).Delete(&models.RecoveryCode{}).Error; err != nil {
+			return err
+		}
+		now := time.Now()
+		var codes []models.RecoveryCode
+		for _, hash := range codeHashes {
+			codes = append(codes, models.RecoveryCode{UserID: int64(userID), CodeHash: hash, CreatedAt: now})
+		}
+		return tx.Create(&codes).Error
+	})
+}
+
+// Consume marks an unused recovery code as used. It returns false when the
+// code does not exist or was already used.
+func (r *recoveryCodeRepository) Consume(userID uint, codeHash string) (bool, error) {
+	result := config.DB.Model(&models.RecoveryCode{}).
+		Where("user_internal_id = ? AND code_hash = ? AND used_at IS NULL", userID, codeHash).
+		Update("used_at", time.Now())
+	return result.RowsAffected > 0, result.Error
+}
+
+// CountUnused returns how many recovery codes the user has left.
+func (r *recoveryCodeRepository) CountUnused(userID uint) (int64, error) {
+	var count int64
+	err := config.DB.Model(&models.RecoveryCode{}).
+		Where("user_internal_id = ? AND used_at IS NULL", userID).Count(&count).Error
+	return count, err
+}
+
+// DeleteByUser removes all recovery codes of a user.
+func (r *recoveryCodeRepository) DeleteByUser(userID uint) error {
+	return config.DB.Where("user_internal_id = ?", userID).Delete(&models.RecoveryCode{}).Error
+}
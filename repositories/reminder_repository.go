@@ -0,0 +1,69 @@
+package repositories
+
+import (
+	"time"
+
+	"github.com/mohod24/go-project-management/config"
+	"github.com/mohod24/go-project-management/models"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// ReminderRepository defines the interface for due-date reminder bookkeeping.
+type ReminderRepository interface {
+	FindPending(kind string, dueFrom, dueTo time.Time, preferences []string) ([]models.PendingReminder, error)
+	Claim(reminder *models.CardReminder, notification *models.Notification) (bool, error)
+}
+
+// reminderRepository implements the ReminderRepository interface.
+type reminderRepository struct {
+}
+
+// NewReminderRepository creates a new instance of ReminderRepository.
+func NewReminderRepository() ReminderRepository {
+	return &reminderRepository{}
+}
+
+// FindPending returns card assignees whose unarchived card is due in
+// (dueFrom, dueTo], who still have access to the card's board, whose reminder
+// preference is one of preferences, and who have not yet received a reminder
+// of this kind for the card's current due date.
+func (r *reminderRepository) FindPending(kind string, dueFrom, dueTo time.Time, preferences []string) ([]models.PendingReminder, error) {
+	var pending []models.PendingReminder
+	err := config.DB.Table("cards").
+		Select("cards.internal_id AS card_internal_id, cards.public_id AS card_public_id, cards.title, cards.due_date, card_assignees.user_internal_id, users.name, users.email, users.locale").
+		Joins("JOIN card_assignees ON card_assignees.card_internal_id = cards.internal_id").
+		Joins("JOIN users ON users.internal_id = card_assignees.user_internal_id AND users.deleted_at IS NULL").
+		Joins("JOIN lists ON lists.internal_id = cards.list_internal_id").
+		Joins("JOIN boards ON boards.internal_id = lists.board_internal_id").
+		Where("(boards.owner_internal_id = card_assignees.user_internal_id OR EXISTS (SELECT 1 FROM board_members"+
+			" WHERE board_members.board_internal_id = boards.internal_id AND board_members.user_internal_id = card_assignees.user_internal_id))").
+		Joins("LEFT JOIN card_reminders ON card_reminders.card_internal_id = cards.internal_id"+
+			" AND card_reminders.user_internal_id = card_assignees.user_internal_id"+
+			" AND card_reminders.kind = ? AND card_reminders.due_date = cards.due_date", kind).
+		Where("cards.due_date > ? AND cards.due_date <= ?", dueFrom, dueTo).
+		Where("cards.archived_at IS NULL").
+		Where("users.reminder_preference IN ?", preferences).
+		Where("card_reminders.card_internal_id IS NULL").
+		Scan(&pending).Error
+	return pending, err
+}
+
+// Claim records the reminder and stores its notification in one transaction.
+// It returns false without creating the notification when another instance
+// has already claimed the same reminder.
+func (r *reminderRepository) Claim(reminder *models.CardReminder, notification *models.Notification) (bool, error) {
+	claimed := false
+	err := config.DB.Transaction(func(tx *gorm.DB) error {
+		result := tx.Clauses(clause.OnConflict{DoNothing: true}).Create(reminder)
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected == 0 {
+			return nil
+		}
+		claimed = true
+		return tx.Create(notification).Error
+	})
+	return claimed, err
+}
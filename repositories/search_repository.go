@@ -0,0 +1,83 @@
+package repositories
+
+import (
+	"strings"
+
+	"github.com/mohod24/go-project-management/config"
+	"github.com/mohod24/go-project-management/models"
+)
+
+// Markers placed around matches by ts_headline. They are control characters
+// so that the service can HTML-escape the text before turning them into tags.
+const (
+	SearchMatchStart = "\x02"
+	SearchMatchStop  = "\x03"
+)
+
+// searchHeadlineOptions configures the highlighted fragment of each result.
+const searchHeadlineOptions = "StartSel=" + SearchMatchStart + ", StopSel=" + SearchMatchStop + ", MaxWords=35, MinWords=15, MaxFragments=2"
+
+// searchAccessibleBoards selects the boards the user owns or is a member of.
+const searchAccessibleBoards = `SELECT internal_id FROM boards WHERE owner_internal_id = @user
+	UNION SELECT board_internal_id FROM board_members WHERE user_internal_id = @user`
+
+// searchQueries select the matches of each result type.
+var searchQueries = map[string]string{
+	models.SearchTypeBoard: `SELECT 'board' AS type, b.public_id, b.public_id AS board_public_id, NULL::uuid AS card_public_id,
+		b.title, ts_headline('simple', b.title || ' ' || coalesce(b.description, ''), q, @options) AS headline,
+		ts_rank(b.search_vector, q) AS rank, b.created_at
+		FROM boards b, websearch_to_tsquery('simple', @query) q
+		WHERE b.search_vector @@ q AND b.internal_id IN (` + searchAccessibleBoards + `)`,
+	models.SearchTypeCard: `SELECT 'card' AS type, c.public_id, l.board_public_id, NULL::uuid AS card_public_id,
+		c.title, ts_headline('simple', c.title || ' ' || coalesce(c.description, ''), q, @options) AS headline,
+		ts_rank(c.search_vector, q) AS rank, c.created_at
+		FROM cards c JOIN lists l ON l.internal_id = c.list_internal_id, websearch_to_tsquery('simple', @query) q
+		WHERE c.search_vector @@ q AND l.board_internal_id IN (` + searchAccessibleBoards + `)`,
+	models.SearchTypeComment: `SELECT 'comment' AS type, m.public_id, l.board_public_id, c.public_id AS card_public_id,
+		c.title, ts_headline('simple', m.message, q, @options) AS headline,
+		ts_rank(m.search_vector, q) AS rank, m.created_at
+		FROM comments m JOIN cards c ON c.internal_id = m.card_internal_id
+		JOIN lists l ON l.internal_id = c.list_internal_id, websearch_to_tsquery('simple', @query) q
+		WHERE m.search_vector @@ q AND l.board_internal_id IN (` + searchAccessibleBoards + `)`,
+}
+
+// SearchRepository defines the interface for full-text search.
+type SearchRepository interface {
+	Search(userID uint, query string, types []string, limit, offset int) ([]models.SearchResult, int64, error)
+}
+
+// searchRepository implements the SearchRepository interface.
+type searchRepository struct {
+}
+
+// NewSearchRepository creates a new instance of SearchRepository.
+func NewSearchRepository() SearchRepository {
+	return &searchRepository{}
+}
+
+// Search returns the boards, cards and comments of the given types that match
+// a web search style query, best match first, restricted to the boards the
+// user can access.
+func (r *searchRepository) Search(userID uint, query string, types []string, limit, offset int) ([]models.SearchResult, int64, error) {
+	var parts []string
+	for _, t := range types {
+		parts = append(parts, searchQueries[t])
+	}
+	matches := strings.Join(parts, " UNION ALL ")
+	args := map[string]interface{}{
+		"user":    userID,
+		"query":   query,
+		"options": searchHeadlineOptions,
+		"limit":   limit,
+		"offset":  offset,
+	}
+
+	var total int64
+	if err := config.DB.Raw("SELECT COUNT(*) FROM ("+matches+") matches", args).Scan(&total).Error; err != nil {
+		return nil, 0, err
+	}
+	results := []models.SearchResult{}
+	err := config.DB.Raw("SELECT * FROM ("+matches+") matches ORDER BY rank DESC, created_at DESC LIMIT @limit OFFSET @offset", args).
+		Scan(&results).Error
+	return results, total, err
+}
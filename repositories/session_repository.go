@@ -0,0 +1,95 @@
+package repositories
+
+import (
+	"time"
+
+	"github.com/mohod24/go-project-management/config"
+	"github.com/mohod24/go-project-management/models"
+	"gorm.io/gorm"
+)
+
+// SessionRepository defines the interface for login session operations.
+type SessionRepository interface {
+	Create(session *models.Session) error
+	FindActiveByPublicID(publicID string, now time.Time) (*models.Session, error)
+	FindActiveByUser(userID uint, now time.Time) ([]models.Session, error)
+	Rotate(id uint, oldHash, newHash string, expiresAt, now time.Time) (bool, error)
+	Touch(id uint, now time.Time) error
+	Revoke(userID uint, publicID string) error
+	RevokeByID(id uint) error
+}
+
+// sessionRepository implements the SessionRepository interface.
+type sessionRepository struct {
+}
+
+// NewSessionRepository creates a new instance of SessionRepository.
+func NewSessionRepository() SessionRepository {
+	return &sessionRepository{}
+}
+
+// Create stores a new session.
+func (r *sessionRepository) Create(session *models.Session) error {
+	return config.DB.Create(session).Error
+}
+
+// FindActiveByPublicID retrieves an unrevoked, unexpired session.
+func (r *sessionRepository) FindActiveByPublicID(publicID string, now time.Time) (*models.Session, error) {
+	var session models.Session
+	err := config.DB.Where("public_id = ? AND revoked_at IS NULL AND expires_at > ?", publicID, now).
+		First(&session).Error
+	if err != nil {
+		return nil, err
+	}
+	return &session, nil
+}
+
+// FindActiveByUser retrieves a user's active sessions, most recently used first.
+func (r *sessionRepository) FindActiveByUser(userID uint, now time.Time) ([]models.Session, error) {
+	var sessions []models.Session
+	err := config.DB.Where("user_internal_id = ? AND revoked_at IS NULL AND expires_at > ?", userID, now).
+		Order("last_used_at DESC").Find(&sessions).Error
+	return sessions, err
+}
+
+// Rotate replaces the session's refresh token hash if it still equals
+// oldHash. It returns false when another refresh already rotated it.
+func (r *sessionRepository) Rotate(id uint, oldHash, newHash string, expiresAt, now time.Time) (bool, error) {
+	result := config.DB.Model(&models.Session{}).
+		Where("internal_id = ? AND refresh_token_hash = ? AND revoked_at IS NULL", id, oldHash).
+		Updates(map[string]interface{}{
+			"refresh_token_hash": newHash,
+			"expires_at":         expiresAt,
+			"last_used_at":       now,
+		})
+	return result.RowsAffected == 1, result.Error
+}
+
+// Touch records that the session was used. To avoid a write on every
+// request, the timestamp is only moved forward once per minute.
+func (r *sessionRepository) Touch(id uint, now time.Time) error {
+	return config.DB.Model(&models.Session{}).
+		Where("internal_id = ? AND last_used_at < ?", id, now.Add(-time.Minute)).
+		Update("last_used_at", now).Error
+}
+
+// Revoke ends one of the user's sessions.
+func (r *sessionRepository) Revoke(userID uint, publicID string) error {
+	result := config.DB.Model(&models.Session{}).
+		Where("public_id = ? AND user_internal_id = ? AND revoked_at IS NULL", publicID, userID).
+		Update("revoked_at", time.Now())
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return gorm.ErrRecordNotFound
+	}
+	return nil
+}
+
+// RevokeByID ends a session by its internal ID.
+func (r *sessionRepository) RevokeByID(id uint) error {
+	return config.DB.Model(&models.Session{}).
+		Where("internal_id = ? AND revoked_at IS NULL", id).
+		Update("revoked_at", time.Now()).Error
+}
@@ -0,0 +1,52 @@
+package repositories
+
+import (
+	"time"
+
+	"github.com/mohod24/go-project-management/config"
+	"github.com/mohod24/go-project-management/models"
+	"gorm.io/gorm"
+)
+
+// SigningKeyRepository defines the interface for JWT signing key operations.
+type SigningKeyRepository interface {
+	FindUsable(now time.Time) ([]models.SigningKey, error)
+	Rotate(key *models.SigningKey, retireAt time.Time) error
+	DeleteExpired(now time.Time) (int64, error)
+}
+
+// signingKeyRepository implements the SigningKeyRepository interface.
+type signingKeyRepository struct {
+}
+
+// NewSigningKeyRepository creates a new instance of SigningKeyRepository.
+func NewSigningKeyRepository() SigningKeyRepository {
+	return &signingKeyRepository{}
+}
+
+// FindUsable retrieves every key that has not expired, oldest first.
+func (r *signingKeyRepository) FindUsable(now time.Time) ([]models.SigningKey, error) {
+	var keys []models.SigningKey
+	err := config.DB.Where("expires_at IS NULL OR expires_at > ?", now).
+		Order("activates_at ASC").Find(&keys).Error
+	return keys, err
+}
+
+// Rotate stores a new key and schedules every older key without an expiry to
+// stop verifying tokens at retireAt.
+func (r *signingKeyRepository) Rotate(key *models.SigningKey, retireAt time.Time) error {
+	return config.DB.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Model(&models.SigningKey{}).
+			Where("expires_at IS NULL").
+			Update("expires_at", retireAt).Error; err != nil {
+			return err
+		}
+		return tx.Create(key).Error
+	})
+}
+
+// DeleteExpired removes keys that can no longer verify any token.
+func (r *signingKeyRepository) DeleteExpired(now time.Time) (int64, error) {
+	result := config.DB.Where("expires_at <= ?", now).Delete(&models.SigningKey{})
+	return result.RowsAffected, result.Error
+}
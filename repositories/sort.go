@@ -0,0 +1,16 @@
+package repositories
+
+import (
+	"github.com/mohod24/go-project-management/models"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// applySort orders a query by validated sort fields, quoting every column,
+// and by internal_id last so that pages are stable when values repeat.
+func applySort(db *gorm.DB, sort []models.SortField, table string) *gorm.DB {
+	for _, field := range sort {
+		db = db.Order(clause.OrderByColumn{Column: clause.Column{Table: table, Name: field.Column}, Desc: field.Desc})
+	}
+	return db.Order(clause.OrderByColumn{Column: clause.Column{Table: table, Name: "internal_id"}})
+}
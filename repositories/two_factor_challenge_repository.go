@@ -0,0 +1,62 @@
+package repositories
+
+import (
+	"time"
+
+	"github.com/mohod24/go-project-management/config"
+	"github.com/mohod24/go-project-management/models"
+	"gorm.io/gorm"
+)
+
+// TwoFactorChallengeRepository defines the interface for pending two-step logins.
+type TwoFactorChallengeRepository interface {
+	Create(challenge *models.TwoFactorChallenge) error
+	FindValidByHash(tokenHash string) (*models.TwoFactorChallenge, error)
+	RecordFailure(challenge *models.TwoFactorChallenge) (int, error)
+	Delete(challenge *models.TwoFactorChallenge) (bool, error)
+}
+
+// twoFactorChallengeRepository implements the TwoFactorChallengeRepository interface.
+type twoFactorChallengeRepository struct {
+}
+
+// NewTwoFactorChallengeRepository creates a new instance of TwoFactorChallengeRepository.
+func NewTwoFactorChallengeRepository() TwoFactorChallengeRepository {
+	return &twoFactorChallengeRepository{}
+}
+
+// Create stores a new challenge and removes expired ones.
+func (r *twoFactorChallengeRepository) Create(challenge *models.TwoFactorChallenge) error {
+	return config.DB.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("expires_at <= ?", time.Now()).Delete(&models.TwoFactorChallenge{}).Error; err != nil {
+			return err
+		}
+		return tx.Create(challenge).Error
+	})
+}
+
+// FindValidByHash retrieves an unexpired challenge by its hash.
+func (r *twoFactorChallengeRepository) FindValidByHash(tokenHash string) (*models.TwoFactorChallenge, error) {
+	var challenge models.TwoFactorChallenge
+	err := config.DB.Where("token_hash = ? AND expires_at > ?", tokenHash, time.Now()).First(&challenge).Error
+	if err != nil {
+		return nil, err
+	}
+	return &challenge, nil
+}
+
+// RecordFailure counts a wrong code against the challenge and returns the new
+// number of failures.
+func (r *twoFactorChallengeRepository) RecordFailure(challenge *models.TwoFactorChallenge) (int, error) {
+	var failures int
+	err := config.DB.Raw("UPDATE two_factor_challenges SET failures = failures + 1 WHERE internal_id = ? RETURNING failures",
+		challenge.InternalID).Scan(&failures).Error
+	return failures, err
+}
+
+// Delete removes a challenge. It returns false when the challenge was already
+// gone, so a challenge can complete a login only once.
+func (r *twoFactorChallengeRepository) Delete(challenge *models.TwoFactorChallenge) (bool, error) {
+	result := config.DB.Where("internal_id = ?", challenge.InternalID).Delete(&models.TwoFactorChallenge{})
+	return result.RowsAffected > 0, result.Error
+}
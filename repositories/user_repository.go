@@ -1,10 +1,11 @@
 package repositories
 
 import (
-	"strings"
+	"time"
 
 	"github.com/mohod24/go-project-management/config"
 	"github.com/mohod24/go-project-management/models"
+	"gorm.io/gorm"
 )
 
 // UserRepository defines the interface for user data operations.
@@ -13,8 +14,21 @@ type UserRepository interface {
 	FindByEmail(email string) (*models.User, error)
 	FindByID(id uint) (*models.User, error)
 	FindByPublicID(publicID string) (*models.User, error)
-	FindAllPagination(filter, sort string, limit, ofset int) ([]models.User, int64, error)
+	FindByPublicIDUnscoped(publicID string) (*models.User, error)
+	FindByOIDCSubject(issuer, subject string) (*models.User, error)
+	LinkOIDC(id uint, issuer, subject string) error
+	ChangePassword(id uint, passwordHash string) error
+	SetPendingEmail(id uint, email *string) error
+	ChangeEmail(id uint, email string, verifiedAt time.Time) error
+	UpdateAvatar(id uint, avatarKey *string) error
+	FindAllPagination(filter string, sort []models.SortField, limit, ofset int) ([]models.User, int64, error)
+	FindAllCursor(filter string, page models.CursorPage) ([]models.User, models.CursorResult, error)
 	Update(user *models.User) error
+	UpdatePreferences(publicID string, preferences map[string]interface{}) error
+	MarkEmailVerified(id uint, verifiedAt time.Time) error
+	UpdateVerificationSentAt(id uint, sentAt time.Time) error
+	UpdateTwoFactor(id uint, fields map[string]interface{}) error
+	ClaimTOTPStep(id uint, step int64) (bool, error)
 	Delete(id uint) error
 }
 
@@ -51,8 +65,32 @@ func (r *userRepository) FindByPublicID(publicID string) (*models.User, error) {
 	return &user, err
 }
 
+// FindByPublicIDUnscoped retrieves a user by their public ID, including an
+// account that is scheduled for deletion.
+func (r *userRepository) FindByPublicIDUnscoped(publicID string) (*models.User, error) {
+	var user models.User
+	err := config.DB.Unscoped().Where("public_id = ?", publicID).First(&user).Error
+	return &user, err
+}
+
+// FindByOIDCSubject retrieves the user linked to an identity provider account.
+func (r *userRepository) FindByOIDCSubject(issuer, subject string) (*models.User, error) {
+	var user models.User
+	err := config.DB.Where("oidc_issuer = ? AND oidc_subject = ?", issuer, subject).First(&user).Error
+	return &user, err
+}
+
+// LinkOIDC links a user to an identity provider account.
+func (r *userRepository) LinkOIDC(id uint, issuer, subject string) error {
+	return config.DB.Model(&models.User{}).Where("internal_id = ?", id).Updates(map[string]interface{}{
+		"oidc_issuer":  issuer,
+		"oidc_subject": subject,
+		"updated_at":   time.Now(),
+	}).Error
+}
+
 // FindAllPagination retrieves users with pagination, filtering, and sorting.
-func (r *userRepository) FindAllPagination(filter, sort string, limit, ofset int) ([]models.User, int64, error) {
+func (r *userRepository) FindAllPagination(filter string, sort []models.SortField, limit, ofset int) ([]models.User, int64, error) {
 	var users []models.User
 	var total int64
 
@@ -68,29 +106,28 @@ func (r *userRepository) FindAllPagination(filter, sort string, limit, ofset int
 		return nil, 0, err
 	}
 
-	//sorting
-	if sort != "" {
-		//Misalnya sort=name (ASC ascending ) sort =-name (DESC descending)
-		if sort == "-id" {
-			sort = "-internal_id"
-		} else if sort == "id" {
-			sort = "internal_id"
-		}
-
-		if strings.HasPrefix(sort, "-") {
-			sort = strings.TrimPrefix(sort, "-") + " DESC"
-		} else {
-			sort += " ASC"
-		}
-
-		db = db.Order(sort)
-	}
+	//sorting, kolom sudah divalidasi lewat models.UserSortFields
+	db = applySort(db, sort, "users")
 
 	err := db.Limit(limit).Offset(ofset).Find(&users).Error
 	return users, total, err
 
 }
 
+// FindAllCursor retrieves users in the order they signed up, one keyset page
+// at a time.
+func (r *userRepository) FindAllCursor(filter string, page models.CursorPage) ([]models.User, models.CursorResult, error) {
+	db := config.DB.Model(&models.User{})
+	if filter != "" {
+		filterPattern := "%" + filter + "%"
+		db = db.Where("name Ilike ? OR email Ilike ?", filterPattern, filterPattern)
+	}
+	ks := keyset{columns: []string{"users.internal_id"}, types: []string{"bigint"}}
+	return findPage(db, ks, page, func(u models.User) []string {
+		return []string{cursorID(u.InternalID)}
+	})
+}
+
 // Update modifies an existing user's information.
 func (r *userRepository) Update(user *models.User) error {
 	return config.DB.Model(&models.User{}).
@@ -99,6 +136,91 @@ func (r *userRepository) Update(user *models.User) error {
 	}).Error
 }
 
+// UpdatePreferences changes a user's reminder and language preferences.
+func (r *userRepository) UpdatePreferences(publicID string, preferences map[string]interface{}) error {
+	return config.DB.Model(&models.User{}).
+		Where("public_id = ?", publicID).Updates(preferences).Error
+}
+
+// MarkEmailVerified records that the user confirmed their email address.
+func (r *userRepository) MarkEmailVerified(id uint, verifiedAt time.Time) error {
+	return config.DB.Model(&models.User{}).Where("internal_id = ?", id).Updates(map[string]interface{}{
+		"email_verified":    true,
+		"email_verified_at": verifiedAt,
+	}).Error
+}
+
+// UpdateVerificationSentAt records when the last verification email was sent.
+func (r *userRepository) UpdateVerificationSentAt(id uint, sentAt time.Time) error {
+	return config.DB.Model(&models.User{}).Where("internal_id = ?", id).
+		Update("verification_sent_at", sentAt).Error
+}
+
+// UpdateTwoFactor changes a user's two-factor authentication settings.
+func (r *userRepository) UpdateTwoFactor(id uint, fields map[string]interface{}) error {
+	return config.DB.Model(&models.User{}).Where("internal_id = ?", id).Updates(fields).Error
+}
+
+// ClaimTOTPStep records the time step of an accepted TOTP code. It returns
+// false if a code of the same or a later step was already used, which
+// prevents replaying a code within its validity window.
+func (r *userRepository) ClaimTOTPStep(id uint, step int64) (bool, error) {
+	result := config.DB.Model(&models.User{}).
+		Where("internal_id = ? AND totp_last_step < ?", id, step).
+		Update("totp_last_step", step)
+	return result.RowsAffected > 0, result.Error
+}
+
+// ChangePassword stores a new password hash, keeping the old one in the
+// password history. It ends every session, revokes every personal access
+// token and bumps the user's token version so previously issued tokens stop
+// working.
+func (r *userRepository) ChangePassword(id uint, passwordHash string) error {
+	return config.DB.Transaction(func(tx *gorm.DB) error {
+		if err := archivePassword(tx, int64(id)); err != nil {
+			return err
+		}
+		now := time.Now()
+		if err := tx.Model(&models.Session{}).Where("user_internal_id = ? AND revoked_at IS NULL", id).
+			Update("revoked_at", now).Error; err != nil {
+			return err
+		}
+		if err := tx.Model(&models.PersonalAccessToken{}).Where("user_internal_id = ? AND revoked_at IS NULL", id).
+			Update("revoked_at", now).Error; err != nil {
+			return err
+		}
+		return tx.Model(&models.User{}).Where("internal_id = ?", id).Updates(map[string]interface{}{
+			"password":      passwordHash,
+			"token_version": gorm.Expr("token_version + 1"),
+			"updated_at":    now,
+		}).Error
+	})
+}
+
+// SetPendingEmail stores the address a user asked to change their email to,
+// or clears it when email is nil.
+func (r *userRepository) SetPendingEmail(id uint, email *string) error {
+	return config.DB.Model(&models.User{}).Where("internal_id = ?", id).
+		Update("pending_email", email).Error
+}
+
+// ChangeEmail replaces the user's email with a confirmed address.
+func (r *userRepository) ChangeEmail(id uint, email string, verifiedAt time.Time) error {
+	return config.DB.Model(&models.User{}).Where("internal_id = ?", id).Updates(map[string]interface{}{
+		"email":             email,
+		"pending_email":     nil,
+		"email_verified":    true,
+		"email_verified_at": verifiedAt,
+		"updated_at":        time.Now(),
+	}).Error
+}
+
+// UpdateAvatar sets or, when avatarKey is nil, removes the user's avatar.
+func (r *userRepository) UpdateAvatar(id uint, avatarKey *string) error {
+	return config.DB.Model(&models.User{}).Where("internal_id = ?", id).
+		Update("avatar_key", avatarKey).Error
+}
+
 // Delete removes a user from the database by their internal ID.
 func (r *userRepository) Delete(id uint) error {
 	return config.DB.Delete(&models.User{}, id).Error
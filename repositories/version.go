@@ -0,0 +1,31 @@
+package repositories
+
+import (
+	"github.com/google/uuid"
+	"github.com/mohod24/go-project-management/config"
+	"github.com/mohod24/go-project-management/models"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// updateVersioned applies updates to the row of model with the given public ID
+// and bumps its version, which is scanned back into model. A non-zero expected
+// version must still be the stored one, otherwise models.ErrVersionConflict is
+// returned and nothing is written.
+func updateVersioned(model interface{}, publicID uuid.UUID, expected int64, updates map[string]interface{}) error {
+	updates["version"] = gorm.Expr("version + 1")
+	query := config.DB.Model(model).
+		Clauses(clause.Returning{Columns: []clause.Column{{Name: "version"}}}).
+		Where("public_id = ?", publicID)
+	if expected != 0 {
+		query = query.Where("version = ?", expected)
+	}
+	result := query.Updates(updates)
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return models.ErrVersionConflict
+	}
+	return nil
+}
@@ -0,0 +1,78 @@
+package repositories
+
+import (
+	"time"
+
+	"github.com/mohod24/go-project-management/config"
+	"github.com/mohod24/go-project-management/models"
+	"gorm.io/gorm/clause"
+)
+
+// WatcherRepository defines the interface for watch subscriptions.
+type WatcherRepository interface {
+	Watch(userID uint, entityType string, entityID uint) error
+	Unwatch(userID uint, entityType string, entityID uint) error
+	IsWatching(userID uint, entityType string, entityID uint) (bool, error)
+	FindWatcherIDs(entityType string, entityID uint) ([]uint, error)
+	DeleteForBoard(boardID uint, userIDs []uint) error
+}
+
+// watcherRepository implements the WatcherRepository interface.
+type watcherRepository struct {
+}
+
+// NewWatcherRepository creates a new instance of WatcherRepository.
+func NewWatcherRepository() WatcherRepository {
+	return &watcherRepository{}
+}
+
+// Watch subscribes a user to an entity. Watching twice is a no-op.
+func (r *watcherRepository) Watch(userID uint, entityType string, entityID uint) error {
+	watcher := models.Watcher{
+		UserID:     int64(userID),
+		EntityType: entityType,
+		EntityID:   int64(entityID),
+		CreatedAt:  time.Now(),
+	}
+	return config.DB.Clauses(clause.OnConflict{DoNothing: true}).Create(&watcher).Error
+}
+
+// Unwatch removes a user's subscription to an entity.
+func (r *watcherRepository) Unwatch(userID uint, entityType string, entityID uint) error {
+	return config.DB.Where("user_internal_id = ? AND entity_type = ? AND entity_internal_id = ?", userID, entityType, entityID).
+		Delete(&models.Watcher{}).Error
+}
+
+// IsWatching reports whether a user is subscribed to an entity.
+func (r *watcherRepository) IsWatching(userID uint, entityType string, entityID uint) (bool, error) {
+	var count int64
+	err := config.DB.Model(&models.Watcher{}).
+		Where("user_internal_id = ? AND entity_type = ? AND entity_internal_id = ?", userID, entityType, entityID).
+		Count(&count).Error
+	return count > 0, err
+}
+
+// FindWatcherIDs returns the internal IDs of every user watching an entity.
+func (r *watcherRepository) FindWatcherIDs(entityType string, entityID uint) ([]uint, error) {
+	var userIDs []uint
+	err := config.DB.Model(&models.Watcher{}).
+		Where("entity_type = ? AND entity_internal_id = ?", entityType, entityID).
+		Pluck("user_internal_id", &userIDs).Error
+	return userIDs, err
+}
+
+// DeleteForBoard removes the users' subscriptions to a board and to every list
+// and card on it.
+func (r *watcherRepository) DeleteForBoard(boardID uint, userIDs []uint) error {
+	if len(userIDs) == 0 {
+		return nil
+	}
+	lists := config.DB.Model(&models.List{}).Select("internal_id").Where("board_internal_id = ?", boardID)
+	cards := config.DB.Model(&models.Card{}).Select("cards.internal_id").
+		Joins("JOIN lists ON lists.internal_id = cards.list_internal_id").
+		Where("lists.board_internal_id = ?", boardID)
+	return config.DB.Where("user_internal_id IN ?", userIDs).
+		Where("(entity_type = ? AND entity_internal_id = ?) OR (entity_type = ? AND entity_internal_id IN (?)) OR (entity_type = ? AND entity_internal_id IN (?))",
+			models.WatchEntityBoard, boardID, models.WatchEntityList, lists, models.WatchEntityCard, cards).
+		Delete(&models.Watcher{}).Error
+}
@@ -8,42 +8,184 @@ import (
 	"github.com/joho/godotenv"
 	"github.com/mohod24/go-project-management/config"
 	"github.com/mohod24/go-project-management/controllers"
+	"github.com/mohod24/go-project-management/middleware"
+	"github.com/mohod24/go-project-management/models"
 	"github.com/mohod24/go-project-management/utils"
 )
 
-func Setup(app *fiber.App, 
+func Setup(app *fiber.App,
+	tokenAuth fiber.Handler,
+	authGuards []fiber.Handler,
+	idempotent fiber.Handler,
 	uc *controllers.UserController,
-	bc *controllers.BoardController) {
+	bc *controllers.BoardController,
+	lc *controllers.ListController,
+	cc *controllers.CardController,
+	wc *controllers.WatchController,
+	nc *controllers.NotificationController,
+	ic *controllers.BoardInviteController,
+	ac *controllers.AuthController,
+	pc *controllers.PersonalAccessTokenController,
+	sc *controllers.SessionController,
+	avc *controllers.AvatarController,
+	acc *controllers.AccountController,
+	src *controllers.SearchController,
+	clc *controllers.ChecklistController,
+	cfc *controllers.CustomFieldController) {
 	err := godotenv.Load()
 		if err != nil{
 		log.Fatal("Error loading .env file:", err)
 	}
 	
 	// Public Routes
+	app.Get("/.well-known/jwks.json", ac.JWKS)
+	app.Static("/avatars", config.AppConfig.AvatarDir, fiber.Static{MaxAge: 86400})
 	auth := app.Group("/v1/auth")
 	auth.Post("/register", uc.Register)
 	auth.Post("/login", uc.Login)
+	auth.Post("/login/2fa", uc.LoginTwoFactor)
+	auth.Post("/refresh", sc.RefreshToken)
+	auth.Get("/oidc/login", uc.OIDCLogin)
+	auth.Get("/oidc/callback", uc.OIDCCallback)
+	auth.Post("/password/forgot", ac.RequestPasswordReset)
+	auth.Post("/password/reset", ac.ConfirmPasswordReset)
+	auth.Get("/verify-email", ac.VerifyEmail)
+	auth.Post("/verify-email/resend", ac.ResendVerification)
+	auth.Get("/email/confirm", ac.ConfirmEmailChange)
+	auth.Get("/account/restore", acc.RestoreAccount)
 
-	// JWT Protected Routes
-	api := app.Group("/api/v1", jwtware.New(jwtware.Config{
-		SigningKey: []byte(config.AppConfig.JWTSecret),
+	// JWT Protected Routes, also reachable with a personal access token
+	jwtGuard := jwtware.New(jwtware.Config{
+		// request sudah diautentikasi oleh personal access token
+		Filter: func(c *fiber.Ctx) bool {
+			return c.Locals("user") != nil
+		},
+		KeyFunc:    utils.JWTKeyfuncV4,
 		ContextKey: "user",
 		ErrorHandler: func(c *fiber.Ctx, err error) error {
 			return utils.Unauthorized(c, "Error unauthorized", err.Error())
 		},
-	}))
+	})
+	api := app.Group("/api/v1", append([]fiber.Handler{tokenAuth, jwtGuard}, authGuards...)...)
+
+	// Scopes required from personal access tokens
+	boardScopes := middleware.RequireScopes(models.ScopeReadBoards, models.ScopeWriteBoards)
+	cardScopes := middleware.RequireScopes(models.ScopeReadCards, models.ScopeWriteCards)
 
 	// User Routes
-	userGroup := api.Group("/users")
+	userGroup := api.Group("/users", middleware.RequireScopes(models.ScopeReadUsers, models.ScopeAdminUsers))
 	userGroup.Get("/page", uc.GetUserPagination)
 	userGroup.Get("/:id", uc.GetUser)
 	userGroup.Put("/:id", uc.UpdateUser)
 	userGroup.Delete("/:id", uc.DeleteUser)
 
+	// Current User Routes
+	meGroup := api.Group("/me", middleware.InteractiveOnly())
+	meGroup.Get("/export", acc.ExportData)
+	meGroup.Delete("/", acc.DeleteAccount)
+	meGroup.Put("/preferences", uc.UpdatePreferences)
+	meGroup.Put("/password", uc.ChangePassword)
+	meGroup.Post("/email", ac.RequestEmailChange)
+	meGroup.Delete("/email", ac.CancelEmailChange)
+	meGroup.Put("/avatar", avc.UploadAvatar)
+	meGroup.Delete("/avatar", avc.DeleteAvatar)
+	meGroup.Post("/2fa/setup", ac.SetupTwoFactor)
+	meGroup.Post("/2fa/confirm", ac.ConfirmTwoFactor)
+	meGroup.Post("/2fa/disable", ac.DisableTwoFactor)
+	meGroup.Post("/2fa/recovery-codes", ac.RegenerateRecoveryCodes)
+	meGroup.Get("/tokens", pc.GetTokens)
+	meGroup.Post("/tokens", idempotent, pc.CreateToken)
+	meGroup.Delete("/tokens/:id", pc.RevokeToken)
+	meGroup.Get("/sessions", sc.GetMySessions)
+	meGroup.Delete("/sessions/:id", sc.RevokeMySession)
+
+	// Admin Routes
+	adminGroup := api.Group("/admin", middleware.RequireRole("admin"), middleware.RequireScope(models.ScopeAdminUsers))
+	adminGroup.Put("/users/:id/2fa-required", ac.SetTwoFactorRequired)
+	adminGroup.Get("/lockouts", ac.GetLockedLogins)
+	adminGroup.Delete("/lockouts/ip/:ip", ac.UnlockIP)
+	adminGroup.Get("/users/:id/lockout", ac.GetUserLockout)
+	adminGroup.Delete("/users/:id/lockout", ac.UnlockUser)
+	adminGroup.Get("/users/:id/sessions", sc.GetUserSessions)
+	adminGroup.Delete("/users/:id/sessions/:sessionId", sc.RevokeUserSession)
+
+	// Creating routes take idempotent so retries with an Idempotency-Key header
+	// replay the first response instead of creating duplicates
+
 	// Board Routes
-	boardGroup := api.Group("/boards")
-	boardGroup.Post("/", bc.CreateBoard)
+	boardGroup := api.Group("/boards", boardScopes)
+	boardGroup.Get("/", bc.GetBoards)
+	boardGroup.Post("/", idempotent, bc.CreateBoard)
+	boardGroup.Get("/:id", bc.GetBoard)
 	boardGroup.Put("/:id", bc.UpdateBoard)
-	boardGroup.Post("/:id/members", bc.AddBoardMember)
+	boardGroup.Post("/:id/members", idempotent, bc.AddBoardMember)
 	boardGroup.Delete("/:id/members", bc.RemoveBoardMembers)
+	boardGroup.Post("/:id/invites", idempotent, ic.InviteBoardMembers)
+	boardGroup.Get("/:id/invites", ic.GetBoardInvites)
+	boardGroup.Delete("/:id/invites/:inviteId", ic.RevokeBoardInvite)
+	boardGroup.Post("/:id/lists", idempotent, lc.CreateList)
+	boardGroup.Get("/:id/cards", cardScopes, cc.GetBoardCards)
+	boardGroup.Post("/:id/cards/bulk", cardScopes, cc.BulkCards)
+	boardGroup.Get("/:id/custom-fields", cfc.GetCustomFields)
+	boardGroup.Post("/:id/custom-fields", idempotent, cfc.CreateCustomField)
+	boardGroup.Get("/:id/watch", wc.WatchStatus(models.WatchEntityBoard))
+	boardGroup.Post("/:id/watch", wc.Watch(models.WatchEntityBoard))
+	boardGroup.Delete("/:id/watch", wc.Unwatch(models.WatchEntityBoard))
+
+	// Invite Routes
+	inviteGroup := api.Group("/invites", middleware.InteractiveOnly())
+	inviteGroup.Get("/", ic.GetMyInvites)
+	inviteGroup.Post("/accept", ic.AcceptInviteToken)
+	inviteGroup.Post("/:id/accept", ic.AcceptInvite)
+	inviteGroup.Post("/:id/decline", ic.DeclineInvite)
+
+	// List Routes
+	listGroup := api.Group("/lists")
+	listGroup.Get("/:id", boardScopes, lc.GetList)
+	listGroup.Put("/:id", boardScopes, lc.UpdateList)
+	listGroup.Get("/:id/cards", cardScopes, cc.GetListCards)
+	listGroup.Post("/:id/cards", cardScopes, idempotent, cc.CreateCard)
+	listGroup.Get("/:id/watch", boardScopes, wc.WatchStatus(models.WatchEntityList))
+	listGroup.Post("/:id/watch", boardScopes, wc.Watch(models.WatchEntityList))
+	listGroup.Delete("/:id/watch", boardScopes, wc.Unwatch(models.WatchEntityList))
+
+	// Card Routes
+	cardGroup := api.Group("/cards", cardScopes)
+	cardGroup.Get("/:id", cc.GetCard)
+	cardGroup.Put("/:id", cc.UpdateCard)
+	cardGroup.Post("/:id/assignees", idempotent, cc.AddCardAssignees)
+	cardGroup.Get("/:id/comments", cc.GetComments)
+	cardGroup.Post("/:id/comments", idempotent, cc.AddComment)
+	cardGroup.Get("/:id/checklists", clc.GetChecklists)
+	cardGroup.Post("/:id/checklists", idempotent, clc.CreateChecklist)
+	cardGroup.Put("/:id/custom-fields/:fieldId", cfc.SetCardValue)
+	cardGroup.Get("/:id/watch", wc.WatchStatus(models.WatchEntityCard))
+	cardGroup.Post("/:id/watch", wc.Watch(models.WatchEntityCard))
+	cardGroup.Delete("/:id/watch", wc.Unwatch(models.WatchEntityCard))
+
+	// Custom Field Routes
+	customFieldGroup := api.Group("/custom-fields", boardScopes)
+	customFieldGroup.Put("/:id", cfc.UpdateCustomField)
+	customFieldGroup.Delete("/:id", cfc.DeleteCustomField)
+
+	// Checklist Routes
+	checklistGroup := api.Group("/checklists", cardScopes)
+	checklistGroup.Put("/:id", clc.UpdateChecklist)
+	checklistGroup.Delete("/:id", clc.DeleteChecklist)
+	checklistGroup.Post("/:id/items", idempotent, clc.AddItem)
+	checklistItemGroup := api.Group("/checklist-items", cardScopes)
+	checklistItemGroup.Put("/:id", clc.UpdateItem)
+	checklistItemGroup.Delete("/:id", clc.DeleteItem)
+	checklistItemGroup.Put("/:id/check", clc.CheckItem)
+	checklistItemGroup.Put("/:id/uncheck", clc.UncheckItem)
+	checklistItemGroup.Post("/:id/convert", idempotent, clc.ConvertItem)
+
+	// Search Routes
+	api.Get("/search", boardScopes, cardScopes, src.Search)
+
+	// Notification Routes
+	notificationGroup := api.Group("/notifications", middleware.InteractiveOnly())
+	notificationGroup.Get("/", nc.GetNotifications)
+	notificationGroup.Put("/read-all", nc.MarkAllNotificationsRead)
+	notificationGroup.Put("/:id/read", nc.MarkNotificationRead)
 }
\ No newline at end of file
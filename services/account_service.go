@@ -0,0 +1,244 @@
+package services
+
+import (
+	"archive/zip"
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/jinzhu/copier"
+	"github.com/mohod24/go-project-management/config"
+	"github.com/mohod24/go-project-management/mailer"
+	"github.com/mohod24/go-project-management/repositories"
+	"github.com/mohod24/go-project-management/utils"
+)
+
+// restoreAccountTokenPurpose scopes signed account restore tokens.
+const restoreAccountTokenPurpose = "restore-account"
+
+// AccountDeletionRequest says what happens to the boards a user owns when the
+// account is deleted. Every owned board must be either transferred to one of
+// its members or deleted.
+type AccountDeletionRequest struct {
+	Password       string            `json:"password"`
+	TransferBoards map[string]string `json:"transfer_boards"` // board public ID to new owner public ID
+	DeleteBoards   []string          `json:"delete_boards"`   // board public IDs
+}
+
+// AccountService defines the interface for personal data export and
+// self-service account deletion.
+type AccountService interface {
+	Export(userPublicID string) ([]byte, error)
+	RequestDeletion(userPublicID string, req AccountDeletionRequest) (time.Time, error)
+	Restore(token string) error
+	Start(interval time.Duration) (stop func())
+	Purge(now time.Time) error
+}
+
+// accountService implements the AccountService interface.
+type accountService struct {
+	accountRepo     repositories.AccountRepository
+	userRepo        repositories.UserRepository
+	boardMemberRepo repositories.BoardMemberRepository
+	sender          mailer.Sender
+}
+
+// NewAccountService creates a new instance of AccountService. Deleted
+// accounts can be restored during ACCOUNT_DELETION_GRACE_PERIOD and are
+// purged for good afterwards.
+func NewAccountService(
+	accountRepo repositories.AccountRepository,
+	userRepo repositories.UserRepository,
+	boardMemberRepo repositories.BoardMemberRepository,
+	sender mailer.Sender,
+) AccountService {
+	return &accountService{accountRepo, userRepo, boardMemberRepo, sender}
+}
+
+// Export returns a zip archive with the user's personal data as JSON files.
+func (s *accountService) Export(userPublicID string) ([]byte, error) {
+	user, err := s.userRepo.FindByPublicID(userPublicID)
+	if err != nil {
+		return nil, errors.New("user not found")
+	}
+	export, err := s.accountRepo.Export(user)
+	if err != nil {
+		return nil, err
+	}
+	export.ExportedAt = time.Now()
+	if err := copier.Copy(&export.Profile, user); err != nil {
+		return nil, err
+	}
+
+	var buf bytes.Buffer
+	archive := zip.NewWriter(&buf)
+	files := []struct {
+		name string
+		data interface{}
+	}{
+		{"profile.json", export.Profile},
+		{"boards.json", export.Boards},
+		{"cards.json", export.Cards},
+		{"comments.json", export.Comments},
+		{"attachments.json", export.Attachments},
+	}
+	for _, file := range files {
+		w, err := archive.CreateHeader(&zip.FileHeader{Name: file.name, Method: zip.Deflate, Modified: export.ExportedAt})
+		if err != nil {
+			return nil, err
+		}
+		encoder := json.NewEncoder(w)
+		encoder.SetIndent("", "  ")
+		if err := encoder.Encode(file.data); err != nil {
+			return nil, err
+		}
+	}
+	if err := archive.Close(); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// RequestDeletion deactivates the user's account after handing over or
+// marking for deletion every board they own, and emails a link to undo it.
+// It returns the time at which the account will be purged.
+func (s *accountService) RequestDeletion(userPublicID string, req AccountDeletionRequest) (time.Time, error) {
+	user, err := s.userRepo.FindByPublicID(userPublicID)
+	if err != nil {
+		return time.Time{}, errors.New("user not found")
+	}
+	if !utils.CheckPasswordHash(req.Password, user.Password) {
+		return time.Time{}, errors.New("password is incorrect")
+	}
+	grace, err := time.ParseDuration(config.AppConfig.AccountDeletionGracePeriod)
+	if err != nil {
+		return time.Time{}, err
+	}
+
+	boards, err := s.accountRepo.FindOwnedBoards(uint(user.InternalID))
+	if err != nil {
+		return time.Time{}, err
+	}
+	deleted := make(map[string]bool, len(req.DeleteBoards))
+	for _, boardID := range req.DeleteBoards {
+		deleted[boardID] = true
+	}
+	owned := make(map[string]bool, len(boards))
+	transfers := make(map[uint]uint)
+	for _, board := range boards {
+		boardID := board.PublicID.String()
+		owned[boardID] = true
+		newOwnerID, transfer := req.TransferBoards[boardID]
+		switch {
+		case transfer && deleted[boardID]:
+			return time.Time{}, fmt.Errorf("board %s cannot be both transferred and deleted", boardID)
+		case deleted[boardID]:
+			continue
+		case !transfer:
+			return time.Time{}, fmt.Errorf("board %s must be transferred or deleted", boardID)
+		}
+
+		newOwner, err := s.userRepo.FindByPublicID(newOwnerID)
+		if err != nil || newOwner.InternalID == user.InternalID {
+			return time.Time{}, errors.New("new owner not found: " + newOwnerID)
+		}
+		isMember, err := s.boardMemberRepo.IsMember(uint(board.InternalID), uint(newOwner.InternalID))
+		if err != nil {
+			return time.Time{}, err
+		}
+		if !isMember {
+			return time.Time{}, fmt.Errorf("new owner of board %s must be a member of the board", boardID)
+		}
+		transfers[uint(board.InternalID)] = uint(newOwner.InternalID)
+	}
+	for boardID := range req.TransferBoards {
+		if !owned[boardID] {
+			return time.Time{}, errors.New("you do not own board " + boardID)
+		}
+	}
+	for boardID := range deleted {
+		if !owned[boardID] {
+			return time.Time{}, errors.New("you do not own board " + boardID)
+		}
+	}
+
+	if err := s.accountRepo.ScheduleDeletion(uint(user.InternalID), transfers); err != nil {
+		return time.Time{}, err
+	}
+	purgeAt := time.Now().Add(grace)
+	token := utils.SignToken(restoreAccountTokenPurpose, user.PublicID.String(), purgeAt)
+	if err := s.sender.SendTemplate(user.Email, user.Locale, "account_deleted", map[string]interface{}{
+		"Name":    user.Name,
+		"URL":     config.AppConfig.APPURL + "/v1/auth/account/restore?token=" + token,
+		"PurgeAt": purgeAt.Format("2006-01-02 15:04 MST"),
+	}); err != nil {
+		log.Println("Failed to queue account deletion email", err)
+	}
+	return purgeAt, nil
+}
+
+// Restore reactivates an account from the link sent when it was deleted.
+// Boards that were transferred stay with their new owners.
+func (s *accountService) Restore(token string) error {
+	publicID, err := utils.VerifySignedToken(restoreAccountTokenPurpose, token)
+	if err != nil {
+		return err
+	}
+	user, err := s.userRepo.FindByPublicIDUnscoped(publicID)
+	if err != nil {
+		return utils.ErrInvalidSignedToken
+	}
+	restored, err := s.accountRepo.Restore(uint(user.InternalID))
+	if err != nil {
+		return err
+	}
+	if !restored {
+		return utils.ErrInvalidSignedToken
+	}
+	return nil
+}
+
+// Start purges expired accounts in the background until stop is called.
+func (s *accountService) Start(interval time.Duration) (stop func()) {
+	ticker := time.NewTicker(interval)
+	done := make(chan struct{})
+	go func() {
+		for {
+			select {
+			case now := <-ticker.C:
+				if err := s.Purge(now); err != nil {
+					log.Println("Failed to purge deleted accounts", err)
+				}
+			case <-done:
+				ticker.Stop()
+				return
+			}
+		}
+	}()
+	return func() { close(done) }
+}
+
+// Purge permanently removes every account whose grace period has ended,
+// together with its avatar files.
+func (s *accountService) Purge(now time.Time) error {
+	grace, err := time.ParseDuration(config.AppConfig.AccountDeletionGracePeriod)
+	if err != nil {
+		return err
+	}
+	users, err := s.accountRepo.FindDeletedBefore(now.Add(-grace))
+	if err != nil {
+		return err
+	}
+	for _, user := range users {
+		if err := s.accountRepo.Purge(uint(user.InternalID)); err != nil {
+			return err
+		}
+		if user.AvatarKey != nil {
+			removeAvatarFiles(*user.AvatarKey)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,128 @@
+package services
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"image"
+	_ "image/gif" // register decoders for accepted upload formats
+	_ "image/jpeg"
+	"image/png"
+	"log"
+	"net/http"
+	"os"
+	"path/filepath"
+	"slices"
+
+	"github.com/mohod24/go-project-management/config"
+	"github.com/mohod24/go-project-management/models"
+	"github.com/mohod24/go-project-management/repositories"
+	"github.com/mohod24/go-project-management/utils"
+)
+
+// maxAvatarDimension bounds the decoded image size, so a small file cannot
+// expand into a huge bitmap in memory.
+const maxAvatarDimension = 4096
+
+// avatarContentTypes are the accepted upload formats, detected from content.
+var avatarContentTypes = []string{"image/jpeg", "image/png", "image/gif"}
+
+// AvatarService defines the interface for uploading and removing user avatars.
+type AvatarService interface {
+	Upload(userPublicID string, data []byte) (*models.User, error)
+	Delete(userPublicID string) error
+}
+
+// avatarService implements the AvatarService interface.
+type avatarService struct {
+	userRepo repositories.UserRepository
+}
+
+// NewAvatarService creates a new instance of AvatarService. Avatars are
+// written to AVATAR_DIR and served from /avatars.
+func NewAvatarService(userRepo repositories.UserRepository) AvatarService {
+	return &avatarService{userRepo}
+}
+
+// Upload validates an image, stores it as a PNG in every size of
+// models.AvatarSizes and replaces the user's previous avatar.
+func (s *avatarService) Upload(userPublicID string, data []byte) (*models.User, error) {
+	user, err := s.userRepo.FindByPublicID(userPublicID)
+	if err != nil {
+		return nil, errors.New("user not found")
+	}
+	if len(data) > config.AppConfig.AvatarMaxBytes {
+		return nil, fmt.Errorf("avatar must be at most %d bytes", config.AppConfig.AvatarMaxBytes)
+	}
+	if !slices.Contains(avatarContentTypes, http.DetectContentType(data)) {
+		return nil, errors.New("avatar must be a JPEG, PNG or GIF image")
+	}
+	cfg, _, err := image.DecodeConfig(bytes.NewReader(data))
+	if err != nil {
+		return nil, errors.New("avatar is not a valid image")
+	}
+	if cfg.Width > maxAvatarDimension || cfg.Height > maxAvatarDimension {
+		return nil, fmt.Errorf("avatar must be at most %dx%d pixels", maxAvatarDimension, maxAvatarDimension)
+	}
+	img, _, err := image.Decode(bytes.NewReader(data))
+	if err != nil {
+		return nil, errors.New("avatar is not a valid image")
+	}
+
+	// key baru tiap upload supaya cache browser tidak menampilkan avatar lama
+	random, err := utils.GenerateRandomToken(8)
+	if err != nil {
+		return nil, err
+	}
+	key := user.PublicID.String() + "-" + random
+	if err := os.MkdirAll(config.AppConfig.AvatarDir, 0o755); err != nil {
+		return nil, err
+	}
+	for _, size := range models.AvatarSizes {
+		var buf bytes.Buffer
+		if err := png.Encode(&buf, utils.ResizeSquare(img, size)); err != nil {
+			return nil, err
+		}
+		if err := os.WriteFile(avatarPath(key, size), buf.Bytes(), 0o644); err != nil {
+			return nil, err
+		}
+	}
+
+	if err := s.userRepo.UpdateAvatar(uint(user.InternalID), &key); err != nil {
+		removeAvatarFiles(key)
+		return nil, err
+	}
+	if user.AvatarKey != nil {
+		removeAvatarFiles(*user.AvatarKey)
+	}
+	user.AvatarKey = &key
+	return user, nil
+}
+
+// Delete removes the user's avatar.
+func (s *avatarService) Delete(userPublicID string) error {
+	user, err := s.userRepo.FindByPublicID(userPublicID)
+	if err != nil {
+		return errors.New("user not found")
+	}
+	if user.AvatarKey == nil {
+		return nil
+	}
+	if err := s.userRepo.UpdateAvatar(uint(user.InternalID), nil); err != nil {
+		return err
+	}
+	removeAvatarFiles(*user.AvatarKey)
+	return nil
+}
+
+func avatarPath(key string, size int) string {
+	return filepath.Join(config.AppConfig.AvatarDir, fmt.Sprintf("%s_%d.png", key, size))
+}
+
+func removeAvatarFiles(key string) {
+	for _, size := range models.AvatarSizes {
+		if err := os.Remove(avatarPath(key, size)); err != nil && !os.IsNotExist(err) {
+			log.Println("Failed to remove avatar file", err)
+		}
+	}
+}
@@ -0,0 +1,303 @@
+package services
+
+import (
+	"errors"
+	"log"
+	"net/mail"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/mohod24/go-project-management/config"
+	"github.com/mohod24/go-project-management/mailer"
+	"github.com/mohod24/go-project-management/models"
+	"github.com/mohod24/go-project-management/repositories"
+	"github.com/mohod24/go-project-management/utils"
+)
+
+// inviteTokenPurpose scopes signed invite tokens so they cannot be reused for other links.
+const inviteTokenPurpose = "board-invite"
+
+// BoardInviteService defines the interface for inviting people to boards by email.
+type BoardInviteService interface {
+	Invite(boardPublicID string, emails []string, actorPublicID string) ([]models.BoardInvite, error)
+	ListPending(boardPublicID, actorPublicID string) ([]models.BoardInvite, error)
+	Revoke(boardPublicID, invitePublicID, actorPublicID string) error
+	ListMine(userPublicID string) ([]models.BoardInvite, error)
+	Accept(invitePublicID, userPublicID string) error
+	AcceptToken(token, userPublicID string) error
+	Decline(invitePublicID, userPublicID string) error
+	CheckToken(token, email string) (*models.BoardInvite, error)
+	AcceptForUser(invite *models.BoardInvite, user *models.User) error
+}
+
+// boardInviteService implements the BoardInviteService interface.
+type boardInviteService struct {
+	inviteRepo      repositories.BoardInviteRepository
+	boardRepo       repositories.BoardRepository
+	userRepo        repositories.UserRepository
+	boardMemberRepo repositories.BoardMemberRepository
+	sender          mailer.Sender
+}
+
+// NewBoardInviteService creates a new instance of BoardInviteService.
+func NewBoardInviteService(
+	inviteRepo repositories.BoardInviteRepository,
+	boardRepo repositories.BoardRepository,
+	userRepo repositories.UserRepository,
+	boardMemberRepo repositories.BoardMemberRepository,
+	sender mailer.Sender,
+) BoardInviteService {
+	return &boardInviteService{inviteRepo, boardRepo, userRepo, boardMemberRepo, sender}
+}
+
+// loadOwnedBoard retrieves a board and checks that the actor owns it.
+func (s *boardInviteService) loadOwnedBoard(boardPublicID, actorPublicID string) (*models.Board, *models.User, error) {
+	board, err := s.boardRepo.FindByPublicID(boardPublicID)
+	if err != nil {
+		return nil, nil, errors.New("board not found")
+	}
+	actor, err := s.userRepo.FindByPublicID(actorPublicID)
+	if err != nil {
+		return nil, nil, errors.New("user not found")
+	}
+	if board.OwnerID != actor.InternalID {
+		return nil, nil, errors.New("only the board owner can manage invites")
+	}
+	return board, actor, nil
+}
+
+// inviteAddress extracts the bare, lower-cased address from an email such as
+// "Jane <Jane@Example.com>", so invites are stored and matched by address only.
+func inviteAddress(email string) (string, error) {
+	addr, err := mail.ParseAddress(strings.TrimSpace(email))
+	if err != nil {
+		return "", err
+	}
+	return strings.ToLower(addr.Address), nil
+}
+
+// Invite invites email addresses to a board. Registered users are either
+// added right away or asked to accept, depending on BOARD_INVITE_REQUIRE_ACCEPT;
+// unknown addresses receive a signed registration link.
+func (s *boardInviteService) Invite(boardPublicID string, emails []string, actorPublicID string) ([]models.BoardInvite, error) {
+	board, actor, err := s.loadOwnedBoard(boardPublicID, actorPublicID)
+	if err != nil {
+		return nil, err
+	}
+	ttl, err := time.ParseDuration(config.AppConfig.BoardInviteTTL)
+	if err != nil {
+		return nil, err
+	}
+
+	var invites []models.BoardInvite
+	for _, raw := range emails {
+		email, err := inviteAddress(raw)
+		if err != nil {
+			return nil, errors.New("invalid email: " + raw)
+		}
+
+		var user *models.User
+		if existing, err := s.userRepo.FindByEmail(email); err == nil && existing.InternalID != 0 {
+			user = existing
+			allowed, err := canAccessBoard(s.boardMemberRepo, board, uint(user.InternalID))
+			if err != nil {
+				return nil, err
+			}
+			if allowed {
+				continue // sudah menjadi anggota
+			}
+		}
+
+		// undangan pending yang sama cukup diperpanjang dan dikirim ulang
+		invite, err := s.inviteRepo.FindPendingByBoardAndEmail(uint(board.InternalID), email)
+		if err == nil {
+			invite.ExpiresAt = time.Now().Add(ttl)
+			if err := s.inviteRepo.Update(invite); err != nil {
+				return nil, err
+			}
+		} else {
+			invite = &models.BoardInvite{
+				PublicID:    uuid.New(),
+				BoardID:     board.InternalID,
+				Email:       email,
+				InvitedByID: actor.InternalID,
+				Status:      models.InviteStatusPending,
+				ExpiresAt:   time.Now().Add(ttl),
+			}
+			if user != nil {
+				invite.UserID = &user.InternalID
+			}
+			if err := s.inviteRepo.Create(invite); err != nil {
+				return nil, err
+			}
+		}
+
+		// user yang belum verifikasi email tetap harus menerima undangan sendiri
+		verified := user != nil && (user.EmailVerified || !config.AppConfig.RequireVerifiedEmailForBoards)
+		if verified && !config.AppConfig.BoardInviteRequireAccept {
+			if err := s.AcceptForUser(invite, user); err != nil {
+				return nil, err
+			}
+		}
+		s.sendInviteEmail(invite, board, actor, user)
+		invites = append(invites, *invite)
+	}
+	return invites, nil
+}
+
+// sendInviteEmail queues the invite email in the recipient's language. The
+// links open pages of the web app, which then call the API with the user's
+// session: an accept page for registered users, the board once they were
+// added, and registration for everyone else. Accept and register links carry
+// the signed invite token.
+func (s *boardInviteService) sendInviteEmail(invite *models.BoardInvite, board *models.Board, inviter, user *models.User) {
+	locale := config.AppConfig.MailDefaultLocale
+	token := utils.SignToken(inviteTokenPurpose, invite.PublicID.String(), invite.ExpiresAt)
+	url := config.AppConfig.WebAppURL + "/register?invite=" + token
+	if user != nil {
+		locale = user.Locale
+		url = config.AppConfig.WebAppURL + "/invites/accept?token=" + token
+		if invite.Status == models.InviteStatusAccepted {
+			url = config.AppConfig.WebAppURL + "/boards/" + board.PublicID.String()
+		}
+	}
+
+	if err := s.sender.SendTemplate(invite.Email, locale, "board_invite", map[string]interface{}{
+		"InviterName": inviter.Name,
+		"BoardTitle":  board.Title,
+		"URL":         url,
+		"ExpiresAt":   invite.ExpiresAt.Format("2006-01-02 15:04 MST"),
+		"Registered":  user != nil,
+		"Added":       invite.Status == models.InviteStatusAccepted,
+	}); err != nil {
+		log.Println("Failed to queue invite email", err)
+	}
+}
+
+// ListPending retrieves the pending invites of a board owned by the actor.
+func (s *boardInviteService) ListPending(boardPublicID, actorPublicID string) ([]models.BoardInvite, error) {
+	board, _, err := s.loadOwnedBoard(boardPublicID, actorPublicID)
+	if err != nil {
+		return nil, err
+	}
+	return s.inviteRepo.FindPendingByBoard(uint(board.InternalID))
+}
+
+// Revoke cancels a pending invite of a board owned by the actor.
+func (s *boardInviteService) Revoke(boardPublicID, invitePublicID, actorPublicID string) error {
+	board, _, err := s.loadOwnedBoard(boardPublicID, actorPublicID)
+	if err != nil {
+		return err
+	}
+	invite, err := s.inviteRepo.FindByPublicID(invitePublicID)
+	if err != nil || invite.BoardID != board.InternalID {
+		return errors.New("invite not found")
+	}
+	if invite.Status != models.InviteStatusPending {
+		return errors.New("invite is no longer pending")
+	}
+	invite.Status = models.InviteStatusRevoked
+	return s.inviteRepo.Update(invite)
+}
+
+// ListMine retrieves the pending invites addressed to the user.
+func (s *boardInviteService) ListMine(userPublicID string) ([]models.BoardInvite, error) {
+	user, err := s.userRepo.FindByPublicID(userPublicID)
+	if err != nil {
+		return nil, errors.New("user not found")
+	}
+	return s.inviteRepo.FindPendingByEmail(user.Email)
+}
+
+// loadOwnInvite retrieves a pending invite addressed to the user.
+func (s *boardInviteService) loadOwnInvite(invitePublicID, userPublicID string) (*models.BoardInvite, *models.User, error) {
+	user, err := s.userRepo.FindByPublicID(userPublicID)
+	if err != nil {
+		return nil, nil, errors.New("user not found")
+	}
+	invite, err := s.inviteRepo.FindByPublicID(invitePublicID)
+	if err != nil || !strings.EqualFold(invite.Email, user.Email) {
+		return nil, nil, errors.New("invite not found")
+	}
+	if invite.Status != models.InviteStatusPending || time.Now().After(invite.ExpiresAt) {
+		return nil, nil, errors.New("invite is no longer valid")
+	}
+	return invite, user, nil
+}
+
+// Accept joins the user to the board of an invite addressed to them.
+func (s *boardInviteService) Accept(invitePublicID, userPublicID string) error {
+	invite, user, err := s.loadOwnInvite(invitePublicID, userPublicID)
+	if err != nil {
+		return err
+	}
+	return s.AcceptForUser(invite, user)
+}
+
+// AcceptToken joins the user to the board of a signed invite link. The invite
+// must have been sent to the user's email address.
+func (s *boardInviteService) AcceptToken(token, userPublicID string) error {
+	user, err := s.userRepo.FindByPublicID(userPublicID)
+	if err != nil {
+		return errors.New("user not found")
+	}
+	invite, err := s.CheckToken(token, user.Email)
+	if err != nil {
+		return err
+	}
+	return s.AcceptForUser(invite, user)
+}
+
+// Decline rejects an invite addressed to the user.
+func (s *boardInviteService) Decline(invitePublicID, userPublicID string) error {
+	invite, _, err := s.loadOwnInvite(invitePublicID, userPublicID)
+	if err != nil {
+		return err
+	}
+	invite.Status = models.InviteStatusDeclined
+	return s.inviteRepo.Update(invite)
+}
+
+// CheckToken verifies a signed invite link and returns the pending invite it
+// points to. The invite must have been sent to the given email address.
+func (s *boardInviteService) CheckToken(token, email string) (*models.BoardInvite, error) {
+	invitePublicID, err := utils.VerifySignedToken(inviteTokenPurpose, token)
+	if err != nil {
+		return nil, err
+	}
+	address, err := inviteAddress(email)
+	if err != nil {
+		return nil, errors.New("invite not found for this email")
+	}
+	invite, err := s.inviteRepo.FindByPublicID(invitePublicID)
+	if err != nil || invite.Email != address {
+		return nil, errors.New("invite not found for this email")
+	}
+	if invite.Status != models.InviteStatusPending || time.Now().After(invite.ExpiresAt) {
+		return nil, errors.New("invite is no longer valid")
+	}
+	return invite, nil
+}
+
+// AcceptForUser adds the user to the invite's board and marks the invite accepted.
+func (s *boardInviteService) AcceptForUser(invite *models.BoardInvite, user *models.User) error {
+	if config.AppConfig.RequireVerifiedEmailForBoards && !user.EmailVerified {
+		return errors.New("please verify your email address before joining a board")
+	}
+	isMember, err := s.boardMemberRepo.IsMember(uint(invite.BoardID), uint(user.InternalID))
+	if err != nil {
+		return err
+	}
+	if !isMember {
+		if err := s.boardRepo.AddMember(uint(invite.BoardID), []uint{uint(user.InternalID)}); err != nil {
+			return err
+		}
+	}
+
+	now := time.Now()
+	invite.Status = models.InviteStatusAccepted
+	invite.UserID = &user.InternalID
+	invite.AcceptedAt = &now
+	return s.inviteRepo.Update(invite)
+}
@@ -2,17 +2,23 @@ package services
 
 import (
 	"errors"
+	"log"
 
 	"github.com/google/uuid"
+	"github.com/mohod24/go-project-management/config"
 	"github.com/mohod24/go-project-management/models"
 	"github.com/mohod24/go-project-management/repositories"
+	"github.com/mohod24/go-project-management/utils"
 )
 
 // BoardService defines the interface for board-related business logic.
 type BoardService interface {
 	Create(board *models.Board) error
-	Update(board *models.Board) error
+	Update(board *models.Board, actorPublicID string) error
 	GetByPublicID(publicID string) (*models.Board, error)
+	GetDetail(publicID, actorPublicID string, includes []string) (*models.Board, error)
+	GetAllPagination(userPublicID, sort string, includes []string, limit, offset int) ([]models.Board, int64, error)
+	GetAllCursor(userPublicID string, includes []string, page models.CursorPage) ([]models.Board, models.CursorResult, error)
 	AddMember(boardPublicID string, userPublicIDs []string) error
 	RemoveMembers(boardPublicID string, userPublicIDs []string) error
 }
@@ -22,6 +28,7 @@ type boardService struct {
 	boardRepo repositories.BoardRepository
 	userRepo  repositories.UserRepository
 	boardMemberRepo repositories.BoardMemberRepository
+	watchService    WatchService
 }
 
 // NewBoardService creates a new instance of BoardService.
@@ -29,8 +36,21 @@ func NewBoardService(
 	boardRepo repositories.BoardRepository,
 	userRepo repositories.UserRepository,
 	boardMemberRepo repositories.BoardMemberRepository,
+	watchService WatchService,
 ) BoardService {
-	return &boardService{boardRepo, userRepo, boardMemberRepo}
+	return &boardService{boardRepo, userRepo, boardMemberRepo, watchService}
+}
+
+// ErrBoardAccessDenied is returned when the actor is neither the owner nor a
+// member of the board.
+var ErrBoardAccessDenied = errors.New("you are not a member of this board")
+
+// canAccessBoard reports whether the user owns the board or is one of its members.
+func canAccessBoard(boardMemberRepo repositories.BoardMemberRepository, board *models.Board, userID uint) (bool, error) {
+	if board.OwnerID == int64(userID) {
+		return true, nil
+	}
+	return boardMemberRepo.IsMember(uint(board.InternalID), userID)
 }
 
 // Create creates a new board.
@@ -41,12 +61,42 @@ func (s *boardService) Create(board *models.Board) error {
 	}
 	board.PublicID = uuid.New()
 	board.OwnerID = user.InternalID
-	return s.boardRepo.Create(board)
+	if err := s.boardRepo.Create(board); err != nil {
+		return err
+	}
+	// owner otomatis menjadi watcher board
+	return s.watchService.AutoWatch(uint(user.InternalID), models.WatchEntityBoard, uint(board.InternalID))
 }
 
-// Update updates an existing board.
-func (s *boardService) Update(board *models.Board) error {
-	return s.boardRepo.Update(board)
+// Update updates an existing board and notifies its watchers. Only the owner
+// and members of the board may update it.
+func (s *boardService) Update(board *models.Board, actorPublicID string) error {
+	actor, err := s.userRepo.FindByPublicID(actorPublicID)
+	if err != nil {
+		return errors.New("user not found")
+	}
+	allowed, err := canAccessBoard(s.boardMemberRepo, board, uint(actor.InternalID))
+	if err != nil {
+		return err
+	}
+	if !allowed {
+		return ErrBoardAccessDenied
+	}
+	if err := s.boardRepo.Update(board); err != nil {
+		return err
+	}
+	if err := s.watchService.Notify(WatchEvent{
+		ActorID:        uint(actor.InternalID),
+		EntityType:     models.WatchEntityBoard,
+		EntityID:       uint(board.InternalID),
+		EntityPublicID: board.PublicID,
+		BoardID:        uint(board.InternalID),
+		Action:         "board.updated",
+		Message:        actor.Name + " updated board \"" + board.Title + "\"",
+	}); err != nil {
+		log.Println("Failed to notify board watchers", err)
+	}
+	return nil
 }
 
 // GetByPublicID retrieves a board by its public ID.
@@ -54,6 +104,50 @@ func (s *boardService) GetByPublicID(publicID string) (*models.Board, error) {
 	return s.boardRepo.FindByPublicID(publicID)
 }
 
+// GetDetail retrieves a board the actor has access to, together with the
+// requested relations.
+func (s *boardService) GetDetail(publicID, actorPublicID string, includes []string) (*models.Board, error) {
+	actor, err := s.userRepo.FindByPublicID(actorPublicID)
+	if err != nil {
+		return nil, errors.New("user not found")
+	}
+	board, err := s.boardRepo.FindByPublicIDWith(publicID, includes)
+	if err != nil {
+		return nil, errors.New("board not found")
+	}
+	allowed, err := canAccessBoard(s.boardMemberRepo, board, uint(actor.InternalID))
+	if err != nil {
+		return nil, err
+	}
+	if !allowed {
+		return nil, errors.New("you are not a member of this board")
+	}
+	return board, nil
+}
+
+// GetAllPagination retrieves the boards the user owns or is a member of.
+func (s *boardService) GetAllPagination(userPublicID, sort string, includes []string, limit, offset int) ([]models.Board, int64, error) {
+	sortFields, err := utils.ParseSort(sort, models.BoardSortFields)
+	if err != nil {
+		return nil, 0, err
+	}
+	user, err := s.userRepo.FindByPublicID(userPublicID)
+	if err != nil {
+		return nil, 0, errors.New("user not found")
+	}
+	return s.boardRepo.FindAccessible(uint(user.InternalID), sortFields, includes, limit, offset)
+}
+
+// GetAllCursor retrieves the boards the user owns or is a member of with
+// keyset pagination.
+func (s *boardService) GetAllCursor(userPublicID string, includes []string, page models.CursorPage) ([]models.Board, models.CursorResult, error) {
+	user, err := s.userRepo.FindByPublicID(userPublicID)
+	if err != nil {
+		return nil, models.CursorResult{}, errors.New("user not found")
+	}
+	return s.boardRepo.FindAccessibleCursor(uint(user.InternalID), includes, page)
+}
+
 // AddMember adds members to a board.
 func (s *boardService) AddMember(boardPublicID string, userPublicIDs []string) error {
 	board, err := s.boardRepo.FindByPublicID(boardPublicID)
@@ -66,6 +160,9 @@ func (s *boardService) AddMember(boardPublicID string, userPublicIDs []string) e
 		if err != nil {
 			return errors.New("user not found: " + userPublicID)
 		}
+		if config.AppConfig.RequireVerifiedEmailForBoards && !user.EmailVerified {
+			return errors.New("user has not verified their email: " + userPublicID)
+		}
 		userInternalIDs = append(userInternalIDs, uint(user.InternalID))
 	}
 	// Cek keanggotaaan sebelum ditambahkan
@@ -138,5 +235,9 @@ func (s *boardService) RemoveMembers(boardPublicID string, userPublicIDs []strin
 		return nil // tidak ada member untuk dihapus
 	}
 	// Remove members from the board
-	return s.boardRepo.RemoveMembers(uint(board.InternalID), membersToRemove)
+	if err := s.boardRepo.RemoveMembers(uint(board.InternalID), membersToRemove); err != nil {
+		return err
+	}
+	// member yang dihapus tidak boleh lagi menerima notifikasi board ini
+	return s.watchService.UnwatchBoard(uint(board.InternalID), membersToRemove)
 }
\ No newline at end of file
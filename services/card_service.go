@@ -0,0 +1,557 @@
+package services
+
+import (
+	"errors"
+	"fmt"
+	"log"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/mohod24/go-project-management/models"
+	"github.com/mohod24/go-project-management/repositories"
+	"github.com/mohod24/go-project-management/utils"
+)
+
+// CardService defines the interface for card-related business logic.
+type CardService interface {
+	Create(listPublicID string, card *models.Card, actorPublicID string) error
+	Update(card *models.Card, actorPublicID string) error
+	GetByPublicID(publicID string) (*models.Card, error)
+	GetWithIncludes(publicID string, includes []string, actorPublicID string) (*models.Card, error)
+	AddAssignees(cardPublicID string, userPublicIDs []string, actorPublicID string) error
+	AddComment(cardPublicID, actorPublicID, message string) (*models.Comment, error)
+	GetComments(cardPublicID, actorPublicID string) ([]models.Comment, error)
+	GetCommentsCursor(cardPublicID, actorPublicID string, page models.CursorPage) ([]models.Comment, models.CursorResult, error)
+	FindByBoard(boardPublicID, query, sort, actorPublicID string, includes []string, page *models.CursorPage) ([]models.Card, models.CursorResult, error)
+	FindByList(listPublicID, query, sort, actorPublicID string, includes []string, page *models.CursorPage) ([]models.Card, models.CursorResult, error)
+	Bulk(boardPublicID string, req CardBulkRequest, actorPublicID string) (*models.CardBulkResponse, error)
+}
+
+// MaxBulkCards is the largest number of cards a single bulk request may target.
+const MaxBulkCards = 500
+
+// CardBulkRequest applies one operation to many cards of a board. ListID is
+// required to move cards, LabelID to add or remove a label and UserID to
+// assign or unassign a user. A null DueDate clears the due date. Atomic
+// defaults to true: one failing card then cancels the whole request.
+type CardBulkRequest struct {
+	Operation string     `json:"operation"`
+	CardIDs   []string   `json:"card_ids"`
+	ListID    string     `json:"list_id"`
+	LabelID   string     `json:"label_id"`
+	UserID    string     `json:"user_id"`
+	DueDate   *time.Time `json:"due_date"`
+	Atomic    *bool      `json:"atomic"`
+}
+
+// cardService implements the CardService interface.
+type cardService struct {
+	cardRepo        repositories.CardRepository
+	listRepo        repositories.ListRepository
+	boardRepo       repositories.BoardRepository
+	userRepo        repositories.UserRepository
+	boardMemberRepo repositories.BoardMemberRepository
+	commentRepo     repositories.CommentRepository
+	customFieldRepo repositories.CustomFieldRepository
+	watchService    WatchService
+}
+
+// NewCardService creates a new instance of CardService.
+func NewCardService(
+	cardRepo repositories.CardRepository,
+	listRepo repositories.ListRepository,
+	boardRepo repositories.BoardRepository,
+	userRepo repositories.UserRepository,
+	boardMemberRepo repositories.BoardMemberRepository,
+	commentRepo repositories.CommentRepository,
+	customFieldRepo repositories.CustomFieldRepository,
+	watchService WatchService,
+) CardService {
+	return &cardService{cardRepo, listRepo, boardRepo, userRepo, boardMemberRepo, commentRepo, customFieldRepo, watchService}
+}
+
+// cardContext holds the actor, list and board a card operation works on.
+type cardContext struct {
+	actor *models.User
+	list  *models.List
+	board *models.Board
+}
+
+// loadContext resolves the actor and the list's board, and checks that the
+// actor has access to that board.
+func (s *cardService) loadContext(list *models.List, actorPublicID string) (*cardContext, error) {
+	actor, err := s.userRepo.FindByPublicID(actorPublicID)
+	if err != nil {
+		return nil, errors.New("user not found")
+	}
+	board, err := s.boardRepo.FindByID(uint(list.BoardInternalID))
+	if err != nil {
+		return nil, errors.New("board not found")
+	}
+	allowed, err := canAccessBoard(s.boardMemberRepo, board, uint(actor.InternalID))
+	if err != nil {
+		return nil, err
+	}
+	if !allowed {
+		return nil, errors.New("you are not a member of this board")
+	}
+	return &cardContext{actor: actor, list: list, board: board}, nil
+}
+
+// loadCard retrieves a card together with its operation context.
+func (s *cardService) loadCard(cardPublicID, actorPublicID string) (*models.Card, *cardContext, error) {
+	card, err := s.cardRepo.FindByPublicID(cardPublicID)
+	if err != nil {
+		return nil, nil, errors.New("card not found")
+	}
+	list, err := s.listRepo.FindByID(uint(card.ListID))
+	if err != nil {
+		return nil, nil, errors.New("list not found")
+	}
+	cc, err := s.loadContext(list, actorPublicID)
+	if err != nil {
+		return nil, nil, err
+	}
+	return card, cc, nil
+}
+
+// notify tells the watchers of a card, its list and its board about a change.
+func (s *cardService) notify(cc *cardContext, card *models.Card, action, message string) {
+	if err := s.watchService.Notify(WatchEvent{
+		ActorID:        uint(cc.actor.InternalID),
+		EntityType:     models.WatchEntityCard,
+		EntityID:       uint(card.InternalID),
+		EntityPublicID: card.PublicID,
+		ListID:         uint(cc.list.InternalID),
+		BoardID:        uint(cc.board.InternalID),
+		Action:         action,
+		Message:        message,
+	}); err != nil {
+		log.Println("Failed to notify card watchers", err)
+	}
+}
+
+// Create adds a new card to a list. The creator automatically watches the card.
+func (s *cardService) Create(listPublicID string, card *models.Card, actorPublicID string) error {
+	list, err := s.listRepo.FindByPublicID(listPublicID)
+	if err != nil {
+		return errors.New("list not found")
+	}
+	cc, err := s.loadContext(list, actorPublicID)
+	if err != nil {
+		return err
+	}
+
+	card.PublicID = uuid.New()
+	card.ListID = list.InternalID
+	if err := s.cardRepo.Create(card); err != nil {
+		return err
+	}
+	if err := s.watchService.AutoWatch(uint(cc.actor.InternalID), models.WatchEntityCard, uint(card.InternalID)); err != nil {
+		return err
+	}
+
+	s.notify(cc, card, "card.created", cc.actor.Name+" added card \""+card.Title+"\" to list \""+list.Title+"\"")
+	return nil
+}
+
+// Update modifies an existing card and notifies its watchers.
+func (s *cardService) Update(card *models.Card, actorPublicID string) error {
+	existing, cc, err := s.loadCard(card.PublicID.String(), actorPublicID)
+	if err != nil {
+		return err
+	}
+	if err := s.cardRepo.Update(card); err != nil {
+		return err
+	}
+
+	card.InternalID = existing.InternalID
+	s.notify(cc, card, "card.updated", cc.actor.Name+" updated card \""+card.Title+"\"")
+	return nil
+}
+
+// GetByPublicID retrieves a card by its public ID.
+func (s *cardService) GetByPublicID(publicID string) (*models.Card, error) {
+	return s.cardRepo.FindByPublicID(publicID)
+}
+
+// GetWithIncludes retrieves a card on a board the actor has access to,
+// together with the requested relations.
+func (s *cardService) GetWithIncludes(publicID string, includes []string, actorPublicID string) (*models.Card, error) {
+	card, err := s.cardRepo.FindByPublicIDWith(publicID, includes)
+	if err != nil {
+		return nil, errors.New("card not found")
+	}
+	list, err := s.listRepo.FindByID(uint(card.ListID))
+	if err != nil {
+		return nil, errors.New("list not found")
+	}
+	if _, err := s.loadContext(list, actorPublicID); err != nil {
+		return nil, err
+	}
+	return card, nil
+}
+
+// AddAssignees assigns board members to a card. Assignees automatically watch the card.
+func (s *cardService) AddAssignees(cardPublicID string, userPublicIDs []string, actorPublicID string) error {
+	card, cc, err := s.loadCard(cardPublicID, actorPublicID)
+	if err != nil {
+		return err
+	}
+
+	var userIDs []uint
+	for _, userPublicID := range userPublicIDs {
+		user, err := s.userRepo.FindByPublicID(userPublicID)
+		if err != nil {
+			return errors.New("user not found: " + userPublicID)
+		}
+		allowed, err := canAccessBoard(s.boardMemberRepo, cc.board, uint(user.InternalID))
+		if err != nil {
+			return err
+		}
+		if !allowed {
+			return errors.New("user is not a member of this board: " + userPublicID)
+		}
+		userIDs = append(userIDs, uint(user.InternalID))
+	}
+	if err := s.cardRepo.AddAssignees(uint(card.InternalID), userIDs); err != nil {
+		return err
+	}
+
+	for _, userID := range userIDs {
+		if err := s.watchService.AutoWatch(userID, models.WatchEntityCard, uint(card.InternalID)); err != nil {
+			return err
+		}
+	}
+	s.notify(cc, card, "card.assigned", cc.actor.Name+" changed the assignees of card \""+card.Title+"\"")
+	return nil
+}
+
+// AddComment posts a comment on a card. The commenter automatically watches the card.
+func (s *cardService) AddComment(cardPublicID, actorPublicID, message string) (*models.Comment, error) {
+	if message == "" {
+		return nil, errors.New("message is required")
+	}
+	card, cc, err := s.loadCard(cardPublicID, actorPublicID)
+	if err != nil {
+		return nil, err
+	}
+
+	comment := &models.Comment{
+		PublicID:  uuid.New(),
+		CardID:    card.InternalID,
+		CardPubID: card.PublicID,
+		UserID:    &cc.actor.InternalID,
+		UserPubID: &cc.actor.PublicID,
+		Message:   message,
+	}
+	if err := s.commentRepo.Create(comment); err != nil {
+		return nil, err
+	}
+	if err := s.watchService.AutoWatch(uint(cc.actor.InternalID), models.WatchEntityCard, uint(card.InternalID)); err != nil {
+		return nil, err
+	}
+
+	s.notify(cc, card, "card.commented", cc.actor.Name+" commented on card \""+card.Title+"\"")
+	return comment, nil
+}
+
+// GetComments retrieves all comments of a card on a board the actor has
+// access to.
+func (s *cardService) GetComments(cardPublicID, actorPublicID string) ([]models.Comment, error) {
+	card, _, err := s.loadCard(cardPublicID, actorPublicID)
+	if err != nil {
+		return nil, err
+	}
+	return s.commentRepo.FindByCardID(uint(card.InternalID))
+}
+
+// GetCommentsCursor retrieves the comments of a card on a board the actor has
+// access to, with keyset pagination.
+func (s *cardService) GetCommentsCursor(cardPublicID, actorPublicID string, page models.CursorPage) ([]models.Comment, models.CursorResult, error) {
+	card, _, err := s.loadCard(cardPublicID, actorPublicID)
+	if err != nil {
+		return nil, models.CursorResult{}, err
+	}
+	return s.commentRepo.FindByCardIDCursor(uint(card.InternalID), page)
+}
+
+// resolveCustomFields checks the cf.<key> terms of a query and the sort
+// fields against the custom fields of a board. Sorting accepts the fields of
+// CardSortFields and cf.<key> for every custom field.
+func (s *cardService) resolveCustomFields(board *models.Board, terms []models.CardQueryTerm, sort string, now time.Time) ([]models.SortField, error) {
+	fields, err := s.customFieldRepo.FindByBoard(uint(board.InternalID))
+	if err != nil {
+		return nil, err
+	}
+	byKey := make(map[string]*models.CustomField, len(fields))
+	for i := range fields {
+		byKey[fields[i].Key] = &fields[i]
+	}
+
+	for i := range terms {
+		if terms[i].Field != models.CardQueryCustomField {
+			continue
+		}
+		field, ok := byKey[terms[i].Key]
+		if !ok {
+			return nil, &utils.CardQueryError{Pos: terms[i].Pos, Msg: fmt.Sprintf("unknown custom field %q", "cf."+terms[i].Key)}
+		}
+		if err := utils.ResolveCustomFieldTerm(&terms[i], field, now); err != nil {
+			return nil, err
+		}
+	}
+
+	sortFields := make(map[string]string, len(models.CardSortFields)+len(fields))
+	for name, column := range models.CardSortFields {
+		sortFields[name] = column
+	}
+	for key := range byKey {
+		sortFields["cf."+key] = "cf." + key
+	}
+	sortBy, err := utils.ParseSort(sort, sortFields)
+	if err != nil {
+		return nil, err
+	}
+	for i := range sortBy {
+		if key, ok := strings.CutPrefix(sortBy[i].Column, "cf."); ok {
+			sortBy[i].CustomField = byKey[key]
+		}
+	}
+	return sortBy, nil
+}
+
+// FindByBoard retrieves the cards of a board that match a card query, one
+// keyset page at a time when page is not nil. Sort is a sort parameter such
+// as "-cf.story_points,title" and cannot be combined with a page.
+func (s *cardService) FindByBoard(boardPublicID, query, sort, actorPublicID string, includes []string, page *models.CursorPage) ([]models.Card, models.CursorResult, error) {
+	now := time.Now()
+	terms, err := utils.ParseCardQuery(query, now)
+	if err != nil {
+		return nil, models.CursorResult{}, err
+	}
+	board, err := s.boardRepo.FindByPublicID(boardPublicID)
+	if err != nil {
+		return nil, models.CursorResult{}, errors.New("board not found")
+	}
+	actor, err := s.userRepo.FindByPublicID(actorPublicID)
+	if err != nil {
+		return nil, models.CursorResult{}, errors.New("user not found")
+	}
+	allowed, err := canAccessBoard(s.boardMemberRepo, board, uint(actor.InternalID))
+	if err != nil {
+		return nil, models.CursorResult{}, err
+	}
+	if !allowed {
+		return nil, models.CursorResult{}, errors.New("you are not a member of this board")
+	}
+	sortBy, err := s.resolveCustomFields(board, terms, sort, now)
+	if err != nil {
+		return nil, models.CursorResult{}, err
+	}
+	return s.cardRepo.FindFiltered(uint(board.InternalID), 0, terms, uint(actor.InternalID), includes, sortBy, page)
+}
+
+// FindByList retrieves the cards of a list that match a card query, one
+// keyset page at a time when page is not nil. Sort works as in FindByBoard.
+func (s *cardService) FindByList(listPublicID, query, sort, actorPublicID string, includes []string, page *models.CursorPage) ([]models.Card, models.CursorResult, error) {
+	now := time.Now()
+	terms, err := utils.ParseCardQuery(query, now)
+	if err != nil {
+		return nil, models.CursorResult{}, err
+	}
+	list, err := s.listRepo.FindByPublicID(listPublicID)
+	if err != nil {
+		return nil, models.CursorResult{}, errors.New("list not found")
+	}
+	cc, err := s.loadContext(list, actorPublicID)
+	if err != nil {
+		return nil, models.CursorResult{}, err
+	}
+	sortBy, err := s.resolveCustomFields(cc.board, terms, sort, now)
+	if err != nil {
+		return nil, models.CursorResult{}, err
+	}
+	return s.cardRepo.FindFiltered(uint(cc.board.InternalID), uint(list.InternalID), terms, uint(cc.actor.InternalID), includes, sortBy, page)
+}
+
+// Bulk applies one operation to many cards of a board in a single transaction
+// and reports the outcome for every card in request order. Cards that are not
+// on the board fail without touching the others unless the request is atomic.
+func (s *cardService) Bulk(boardPublicID string, req CardBulkRequest, actorPublicID string) (*models.CardBulkResponse, error) {
+	if len(req.CardIDs) == 0 {
+		return nil, errors.New("card_ids is required")
+	}
+	if len(req.CardIDs) > MaxBulkCards {
+		return nil, fmt.Errorf("at most %d cards can be changed at once", MaxBulkCards)
+	}
+	board, err := s.boardRepo.FindByPublicID(boardPublicID)
+	if err != nil {
+		return nil, errors.New("board not found")
+	}
+	actor, err := s.userRepo.FindByPublicID(actorPublicID)
+	if err != nil {
+		return nil, errors.New("user not found")
+	}
+	allowed, err := canAccessBoard(s.boardMemberRepo, board, uint(actor.InternalID))
+	if err != nil {
+		return nil, err
+	}
+	if !allowed {
+		return nil, errors.New("you are not a member of this board")
+	}
+	op, target, err := s.bulkOperation(board, req)
+	if err != nil {
+		return nil, err
+	}
+
+	resp := &models.CardBulkResponse{
+		Operation: req.Operation,
+		Atomic:    req.Atomic == nil || *req.Atomic,
+		Results:   make([]models.CardBulkResult, len(req.CardIDs)),
+	}
+	var publicIDs []uuid.UUID
+	for i, cardID := range req.CardIDs {
+		resp.Results[i].CardID = cardID
+		if publicID, err := uuid.Parse(cardID); err == nil {
+			publicIDs = append(publicIDs, publicID)
+		}
+	}
+	found, err := s.cardRepo.FindInBoard(uint(board.InternalID), publicIDs)
+	if err != nil {
+		return nil, err
+	}
+	byID := make(map[string]int, len(found))
+	for i, card := range found {
+		byID[card.PublicID.String()] = i
+	}
+
+	// kartu yang tidak ada atau muncul dua kali gagal sebelum transaksi
+	var cards []models.Card
+	var indexes []int
+	seen := make(map[string]bool)
+	for i, result := range resp.Results {
+		publicID, err := uuid.Parse(result.CardID)
+		j, ok := byID[publicID.String()]
+		switch {
+		case err != nil:
+			resp.Results[i].Error = "invalid card id"
+		case !ok:
+			resp.Results[i].Error = "card not found on this board"
+		case seen[publicID.String()]:
+			resp.Results[i].Error = "card is listed more than once"
+		default:
+			seen[publicID.String()] = true
+			cards = append(cards, found[j])
+			indexes = append(indexes, i)
+			continue
+		}
+		resp.Results[i].Status = models.CardBulkStatusFailed
+	}
+
+	failed := len(req.CardIDs) - len(cards)
+	if failed == 0 || !resp.Atomic {
+		failures, err := s.cardRepo.BulkApply(op, cards, resp.Atomic)
+		if err != nil {
+			return nil, err
+		}
+		for k, failure := range failures {
+			if failure != nil {
+				resp.Results[indexes[k]].Status = models.CardBulkStatusFailed
+				resp.Results[indexes[k]].Error = failure.Error()
+				failed++
+			}
+		}
+	}
+
+	for k, card := range cards {
+		result := &resp.Results[indexes[k]]
+		switch {
+		case result.Status == models.CardBulkStatusFailed:
+			continue
+		case resp.Atomic && failed > 0:
+			result.Status = models.CardBulkStatusRolledBack
+		default:
+			result.Status = models.CardBulkStatusOK
+			resp.Succeeded++
+			s.notifyBulk(board, actor, &card, op, target)
+		}
+	}
+	resp.Failed = failed
+	return resp, nil
+}
+
+// bulkOperation validates a bulk request and resolves its target on the
+// board. target is the name used in notifications.
+func (s *cardService) bulkOperation(board *models.Board, req CardBulkRequest) (models.CardBulkOperation, string, error) {
+	op := models.CardBulkOperation{Operation: req.Operation}
+	switch req.Operation {
+	case models.CardBulkMove:
+		list, err := s.listRepo.FindByPublicID(req.ListID)
+		if err != nil || list.BoardInternalID != board.InternalID {
+			return op, "", errors.New("list_id must be a list on this board")
+		}
+		op.ListID = list.InternalID
+		return op, list.Title, nil
+	case models.CardBulkAddLabel, models.CardBulkRemoveLabel:
+		label, err := s.cardRepo.FindLabel(uint(board.InternalID), req.LabelID)
+		if err != nil {
+			return op, "", errors.New("label_id must be a label on this board")
+		}
+		op.LabelID = label.InternalID
+		return op, label.Name, nil
+	case models.CardBulkAssign, models.CardBulkUnassign:
+		user, err := s.userRepo.FindByPublicID(req.UserID)
+		if err != nil {
+			return op, "", errors.New("user not found: " + req.UserID)
+		}
+		if req.Operation == models.CardBulkAssign {
+			allowed, err := canAccessBoard(s.boardMemberRepo, board, uint(user.InternalID))
+			if err != nil {
+				return op, "", err
+			}
+			if !allowed {
+				return op, "", errors.New("user is not a member of this board: " + req.UserID)
+			}
+		}
+		op.UserID = user.InternalID
+		return op, user.Name, nil
+	case models.CardBulkSetDueDate:
+		op.DueDate = req.DueDate
+		return op, "", nil
+	case models.CardBulkArchive, models.CardBulkDelete:
+		return op, "", nil
+	}
+	return op, "", errors.New("unsupported operation: " + req.Operation)
+}
+
+// notifyBulk tells the watchers of a card about a bulk change that was applied
+// to it. New assignees start watching the card.
+func (s *cardService) notifyBulk(board *models.Board, actor *models.User, card *models.Card, op models.CardBulkOperation, target string) {
+	cc := &cardContext{actor: actor, list: &models.List{InternalID: card.ListID}, board: board}
+	var action, message string
+	switch op.Operation {
+	case models.CardBulkMove:
+		cc.list.InternalID = op.ListID
+		action, message = "card.moved", actor.Name+" moved card \""+card.Title+"\" to list \""+target+"\""
+	case models.CardBulkAddLabel, models.CardBulkRemoveLabel:
+		action, message = "card.labeled", actor.Name+" changed the labels of card \""+card.Title+"\""
+	case models.CardBulkAssign:
+		if err := s.watchService.AutoWatch(uint(op.UserID), models.WatchEntityCard, uint(card.InternalID)); err != nil {
+			log.Println("Failed to watch assigned card", err)
+		}
+		action, message = "card.assigned", actor.Name+" changed the assignees of card \""+card.Title+"\""
+	case models.CardBulkUnassign:
+		action, message = "card.assigned", actor.Name+" changed the assignees of card \""+card.Title+"\""
+	case models.CardBulkSetDueDate:
+		action, message = "card.updated", actor.Name+" changed the due date of card \""+card.Title+"\""
+	case models.CardBulkArchive:
+		action, message = "card.archived", actor.Name+" archived card \""+card.Title+"\""
+	case models.CardBulkDelete:
+		// card sudah terhapus, hanya watcher list dan board yang diberi tahu
+		card.InternalID = 0
+		action, message = "card.deleted", actor.Name+" deleted card \""+card.Title+"\""
+	}
+	s.notify(cc, card, action, message)
+}
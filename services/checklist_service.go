@@ -0,0 +1,363 @@
+package services
+
+import (
+	"errors"
+	"log"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/mohod24/go-project-management/models"
+	"github.com/mohod24/go-project-management/repositories"
+)
+
+// ChecklistItemRequest holds the editable fields of a checklist item.
+// AssigneeID is the public ID of a board member; null or "" leaves the item
+// unassigned. Position is 1-based, 0 keeps the current position.
+type ChecklistItemRequest struct {
+	Title      string     `json:"title"`
+	AssigneeID *string    `json:"assignee_id"`
+	DueDate    *time.Time `json:"due_date"`
+	Position   int        `json:"position"`
+}
+
+// ChecklistService defines the interface for checklist-related business logic.
+type ChecklistService interface {
+	Create(cardPublicID, title, actorPublicID string) (*models.Checklist, error)
+	GetByCard(cardPublicID, actorPublicID string) ([]models.Checklist, error)
+	Update(checklistPublicID, title string, position int, actorPublicID string) (*models.Checklist, error)
+	Delete(checklistPublicID, actorPublicID string) error
+	AddItem(checklistPublicID string, req ChecklistItemRequest, actorPublicID string) (*models.ChecklistItem, error)
+	UpdateItem(itemPublicID string, req ChecklistItemRequest, actorPublicID string) (*models.ChecklistItem, error)
+	SetItemChecked(itemPublicID string, checked bool, actorPublicID string) (*models.ChecklistItem, error)
+	DeleteItem(itemPublicID, actorPublicID string) error
+	ConvertItem(itemPublicID, listPublicID, actorPublicID string) (*models.Card, error)
+}
+
+// checklistService implements the ChecklistService interface.
+type checklistService struct {
+	checklistRepo   repositories.ChecklistRepository
+	cardRepo        repositories.CardRepository
+	listRepo        repositories.ListRepository
+	boardRepo       repositories.BoardRepository
+	userRepo        repositories.UserRepository
+	boardMemberRepo repositories.BoardMemberRepository
+	watchService    WatchService
+}
+
+// NewChecklistService creates a new instance of ChecklistService.
+func NewChecklistService(
+	checklistRepo repositories.ChecklistRepository,
+	cardRepo repositories.CardRepository,
+	listRepo repositories.ListRepository,
+	boardRepo repositories.BoardRepository,
+	userRepo repositories.UserRepository,
+	boardMemberRepo repositories.BoardMemberRepository,
+	watchService WatchService,
+) ChecklistService {
+	return &checklistService{checklistRepo, cardRepo, listRepo, boardRepo, userRepo, boardMemberRepo, watchService}
+}
+
+// checklistContext holds the actor and the card, list and board a checklist
+// operation works on.
+type checklistContext struct {
+	actor *models.User
+	card  *models.Card
+	list  *models.List
+	board *models.Board
+}
+
+// loadContext resolves the list and board of a card, and checks that the
+// actor has access to that board.
+func (s *checklistService) loadContext(card *models.Card, actorPublicID string) (*checklistContext, error) {
+	actor, err := s.userRepo.FindByPublicID(actorPublicID)
+	if err != nil {
+		return nil, errors.New("user not found")
+	}
+	list, err := s.listRepo.FindByID(uint(card.ListID))
+	if err != nil {
+		return nil, errors.New("list not found")
+	}
+	board, err := s.boardRepo.FindByID(uint(list.BoardInternalID))
+	if err != nil {
+		return nil, errors.New("board not found")
+	}
+	allowed, err := canAccessBoard(s.boardMemberRepo, board, uint(actor.InternalID))
+	if err != nil {
+		return nil, err
+	}
+	if !allowed {
+		return nil, errors.New("you are not a member of this board")
+	}
+	return &checklistContext{actor: actor, card: card, list: list, board: board}, nil
+}
+
+// loadChecklist retrieves a checklist together with its operation context.
+func (s *checklistService) loadChecklist(checklistPublicID, actorPublicID string) (*models.Checklist, *checklistContext, error) {
+	checklist, err := s.checklistRepo.FindByPublicID(checklistPublicID)
+	if err != nil {
+		return nil, nil, errors.New("checklist not found")
+	}
+	card, err := s.cardRepo.FindByID(uint(checklist.CardID))
+	if err != nil {
+		return nil, nil, errors.New("card not found")
+	}
+	cc, err := s.loadContext(card, actorPublicID)
+	if err != nil {
+		return nil, nil, err
+	}
+	return checklist, cc, nil
+}
+
+// loadItem retrieves a checklist item with its checklist and operation context.
+func (s *checklistService) loadItem(itemPublicID, actorPublicID string) (*models.ChecklistItem, *models.Checklist, *checklistContext, error) {
+	item, err := s.checklistRepo.FindItemByPublicID(itemPublicID)
+	if err != nil {
+		return nil, nil, nil, errors.New("checklist item not found")
+	}
+	checklist, err := s.checklistRepo.FindByID(uint(item.ChecklistID))
+	if err != nil {
+		return nil, nil, nil, errors.New("checklist not found")
+	}
+	card, err := s.cardRepo.FindByID(uint(checklist.CardID))
+	if err != nil {
+		return nil, nil, nil, errors.New("card not found")
+	}
+	cc, err := s.loadContext(card, actorPublicID)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+	return item, checklist, cc, nil
+}
+
+// notify tells the watchers of the card, its list and its board about a change.
+func (s *checklistService) notify(cc *checklistContext, card *models.Card, action, message string) {
+	if err := s.watchService.Notify(WatchEvent{
+		ActorID:        uint(cc.actor.InternalID),
+		EntityType:     models.WatchEntityCard,
+		EntityID:       uint(card.InternalID),
+		EntityPublicID: card.PublicID,
+		ListID:         uint(card.ListID),
+		BoardID:        uint(cc.board.InternalID),
+		Action:         action,
+		Message:        message,
+	}); err != nil {
+		log.Println("Failed to notify card watchers", err)
+	}
+}
+
+// validTitle trims a checklist or item title and checks its length.
+func validTitle(title string) (string, error) {
+	title = strings.TrimSpace(title)
+	if title == "" || len(title) > 255 {
+		return "", errors.New("title is required and must be at most 255 characters")
+	}
+	return title, nil
+}
+
+// Create adds a checklist at the end of a card's checklists.
+func (s *checklistService) Create(cardPublicID, title, actorPublicID string) (*models.Checklist, error) {
+	title, err := validTitle(title)
+	if err != nil {
+		return nil, err
+	}
+	card, err := s.cardRepo.FindByPublicID(cardPublicID)
+	if err != nil {
+		return nil, errors.New("card not found")
+	}
+	if _, err := s.loadContext(card, actorPublicID); err != nil {
+		return nil, err
+	}
+
+	checklist := &models.Checklist{
+		PublicID: uuid.New(),
+		CardID:   card.InternalID,
+		Title:    title,
+		Items:    []models.ChecklistItem{},
+	}
+	if err := s.checklistRepo.Create(checklist); err != nil {
+		return nil, err
+	}
+	return checklist, nil
+}
+
+// GetByCard retrieves the checklists of a card with their items.
+func (s *checklistService) GetByCard(cardPublicID, actorPublicID string) ([]models.Checklist, error) {
+	card, err := s.cardRepo.FindByPublicID(cardPublicID)
+	if err != nil {
+		return nil, errors.New("card not found")
+	}
+	if _, err := s.loadContext(card, actorPublicID); err != nil {
+		return nil, err
+	}
+	return s.checklistRepo.FindByCard(uint(card.InternalID))
+}
+
+// Update renames a checklist and optionally moves it to another position.
+func (s *checklistService) Update(checklistPublicID, title string, position int, actorPublicID string) (*models.Checklist, error) {
+	title, err := validTitle(title)
+	if err != nil {
+		return nil, err
+	}
+	checklist, _, err := s.loadChecklist(checklistPublicID, actorPublicID)
+	if err != nil {
+		return nil, err
+	}
+	checklist.Title = title
+	if err := s.checklistRepo.Update(checklist, position); err != nil {
+		return nil, err
+	}
+	return s.checklistRepo.FindByPublicID(checklistPublicID)
+}
+
+// Delete removes a checklist and its items.
+func (s *checklistService) Delete(checklistPublicID, actorPublicID string) error {
+	checklist, _, err := s.loadChecklist(checklistPublicID, actorPublicID)
+	if err != nil {
+		return err
+	}
+	return s.checklistRepo.Delete(checklist)
+}
+
+// applyItemRequest validates an item request and copies it onto item.
+func (s *checklistService) applyItemRequest(cc *checklistContext, item *models.ChecklistItem, req ChecklistItemRequest) error {
+	title, err := validTitle(req.Title)
+	if err != nil {
+		return err
+	}
+	item.Title = title
+	item.DueDate = req.DueDate
+	item.AssigneeID = nil
+	item.AssigneePublicID = nil
+	if req.AssigneeID == nil || *req.AssigneeID == "" {
+		return nil
+	}
+	assignee, err := s.userRepo.FindByPublicID(*req.AssigneeID)
+	if err != nil {
+		return errors.New("user not found: " + *req.AssigneeID)
+	}
+	allowed, err := canAccessBoard(s.boardMemberRepo, cc.board, uint(assignee.InternalID))
+	if err != nil {
+		return err
+	}
+	if !allowed {
+		return errors.New("user is not a member of this board: " + *req.AssigneeID)
+	}
+	item.AssigneeID = &assignee.InternalID
+	item.AssigneePublicID = &assignee.PublicID
+	return nil
+}
+
+// AddItem adds an item to a checklist. A non-zero position moves the new item
+// there instead of leaving it at the end.
+func (s *checklistService) AddItem(checklistPublicID string, req ChecklistItemRequest, actorPublicID string) (*models.ChecklistItem, error) {
+	checklist, cc, err := s.loadChecklist(checklistPublicID, actorPublicID)
+	if err != nil {
+		return nil, err
+	}
+	item := &models.ChecklistItem{PublicID: uuid.New()}
+	if err := s.applyItemRequest(cc, item, req); err != nil {
+		return nil, err
+	}
+	if err := s.checklistRepo.CreateItem(checklist, item); err != nil {
+		return nil, err
+	}
+	if req.Position != 0 {
+		if err := s.checklistRepo.UpdateItem(item, req.Position); err != nil {
+			return nil, err
+		}
+	}
+	return s.checklistRepo.FindItemByPublicID(item.PublicID.String())
+}
+
+// UpdateItem replaces the title, assignee and due date of an item and
+// optionally moves it to another position.
+func (s *checklistService) UpdateItem(itemPublicID string, req ChecklistItemRequest, actorPublicID string) (*models.ChecklistItem, error) {
+	item, _, cc, err := s.loadItem(itemPublicID, actorPublicID)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.applyItemRequest(cc, item, req); err != nil {
+		return nil, err
+	}
+	if err := s.checklistRepo.UpdateItem(item, req.Position); err != nil {
+		return nil, err
+	}
+	return s.checklistRepo.FindItemByPublicID(itemPublicID)
+}
+
+// SetItemChecked checks or unchecks an item. Checking an item notifies the
+// card's watchers.
+func (s *checklistService) SetItemChecked(itemPublicID string, checked bool, actorPublicID string) (*models.ChecklistItem, error) {
+	item, checklist, cc, err := s.loadItem(itemPublicID, actorPublicID)
+	if err != nil {
+		return nil, err
+	}
+	if checked == (item.CheckedAt != nil) {
+		return item, nil
+	}
+	item.CheckedAt = nil
+	item.CheckedByID = nil
+	if checked {
+		now := time.Now()
+		item.CheckedAt = &now
+		item.CheckedByID = &cc.actor.PublicID
+	}
+	if err := s.checklistRepo.SetItemChecked(checklist, item); err != nil {
+		return nil, err
+	}
+
+	if checked {
+		s.notify(cc, cc.card, "card.checklist", cc.actor.Name+" completed \""+item.Title+"\" on card \""+cc.card.Title+"\"")
+	}
+	return item, nil
+}
+
+// DeleteItem removes an item from its checklist.
+func (s *checklistService) DeleteItem(itemPublicID, actorPublicID string) error {
+	item, checklist, _, err := s.loadItem(itemPublicID, actorPublicID)
+	if err != nil {
+		return err
+	}
+	return s.checklistRepo.DeleteItem(checklist, item)
+}
+
+// ConvertItem turns a checklist item into a card with the item's title, due
+// date and assignee, and removes the item. The card is added to the list with
+// listPublicID on the same board, or to the list of the item's card when
+// listPublicID is empty.
+func (s *checklistService) ConvertItem(itemPublicID, listPublicID, actorPublicID string) (*models.Card, error) {
+	item, checklist, cc, err := s.loadItem(itemPublicID, actorPublicID)
+	if err != nil {
+		return nil, err
+	}
+	list := cc.list
+	if listPublicID != "" {
+		list, err = s.listRepo.FindByPublicID(listPublicID)
+		if err != nil || list.BoardInternalID != cc.board.InternalID {
+			return nil, errors.New("list_id must be a list on this board")
+		}
+	}
+
+	card := &models.Card{
+		PublicID: uuid.New(),
+		ListID:   list.InternalID,
+		Title:    item.Title,
+		DueDate:  item.DueDate,
+	}
+	if err := s.checklistRepo.ConvertItem(checklist, item, card); err != nil {
+		return nil, err
+	}
+	watcherIDs := []uint{uint(cc.actor.InternalID)}
+	if item.AssigneeID != nil {
+		watcherIDs = append(watcherIDs, uint(*item.AssigneeID))
+	}
+	for _, userID := range watcherIDs {
+		if err := s.watchService.AutoWatch(userID, models.WatchEntityCard, uint(card.InternalID)); err != nil {
+			log.Println("Failed to watch converted card", err)
+		}
+	}
+
+	s.notify(cc, card, "card.created", cc.actor.Name+" converted \""+item.Title+"\" on card \""+cc.card.Title+"\" into a card on list \""+list.Title+"\"")
+	return s.cardRepo.FindByPublicID(card.PublicID.String())
+}
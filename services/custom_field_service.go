@@ -0,0 +1,353 @@
+package services
+
+import (
+	"errors"
+	"fmt"
+	"log"
+	"slices"
+	"strings"
+	"time"
+	"unicode"
+
+	"github.com/google/uuid"
+	"github.com/mohod24/go-project-management/models"
+	"github.com/mohod24/go-project-management/models/types"
+	"github.com/mohod24/go-project-management/repositories"
+)
+
+// maxCustomFieldText is the longest value a text custom field may hold.
+const maxCustomFieldText = 1000
+
+// CustomFieldRequest holds the editable fields of a custom field. Type cannot
+// be changed once the field exists; Options are required for dropdown fields
+// only.
+type CustomFieldRequest struct {
+	Name    string   `json:"name"`
+	Type    string   `json:"type"`
+	Options []string `json:"options"`
+}
+
+// CustomFieldService defines the interface for custom field-related business logic.
+type CustomFieldService interface {
+	Create(boardPublicID string, req CustomFieldRequest, actorPublicID string) (*models.CustomField, error)
+	GetByBoard(boardPublicID, actorPublicID string) ([]models.CustomField, error)
+	Update(fieldPublicID string, req CustomFieldRequest, actorPublicID string) (*models.CustomField, error)
+	Delete(fieldPublicID, actorPublicID string) error
+	SetValue(cardPublicID, fieldPublicID string, value interface{}, actorPublicID string) (*models.CardCustomFieldValue, error)
+}
+
+// customFieldService implements the CustomFieldService interface.
+type customFieldService struct {
+	customFieldRepo repositories.CustomFieldRepository
+	cardRepo        repositories.CardRepository
+	listRepo        repositories.ListRepository
+	boardRepo       repositories.BoardRepository
+	userRepo        repositories.UserRepository
+	boardMemberRepo repositories.BoardMemberRepository
+	watchService    WatchService
+}
+
+// NewCustomFieldService creates a new instance of CustomFieldService.
+func NewCustomFieldService(
+	customFieldRepo repositories.CustomFieldRepository,
+	cardRepo repositories.CardRepository,
+	listRepo repositories.ListRepository,
+	boardRepo repositories.BoardRepository,
+	userRepo repositories.UserRepository,
+	boardMemberRepo repositories.BoardMemberRepository,
+	watchService WatchService,
+) CustomFieldService {
+	return &customFieldService{customFieldRepo, cardRepo, listRepo, boardRepo, userRepo, boardMemberRepo, watchService}
+}
+
+// checkOwner checks that the actor owns a board. Only the owner administers
+// the custom fields of a board.
+func (s *customFieldService) checkOwner(board *models.Board, actorPublicID string) error {
+	actor, err := s.userRepo.FindByPublicID(actorPublicID)
+	if err != nil {
+		return errors.New("user not found")
+	}
+	if board.OwnerID != actor.InternalID {
+		return errors.New("only the board owner can manage custom fields")
+	}
+	return nil
+}
+
+// loadField retrieves a custom field and checks that the actor owns its board.
+func (s *customFieldService) loadField(fieldPublicID, actorPublicID string) (*models.CustomField, error) {
+	field, err := s.customFieldRepo.FindByPublicID(fieldPublicID)
+	if err != nil {
+		return nil, errors.New("custom field not found")
+	}
+	board, err := s.boardRepo.FindByID(uint(field.BoardID))
+	if err != nil {
+		return nil, errors.New("board not found")
+	}
+	if err := s.checkOwner(board, actorPublicID); err != nil {
+		return nil, err
+	}
+	return field, nil
+}
+
+// customFieldKey derives the key queries and sorts use from a field name:
+// "Story Points" becomes story_points.
+func customFieldKey(name string) string {
+	var b strings.Builder
+	separate := false
+	for _, r := range strings.ToLower(name) {
+		if unicode.IsLetter(r) || unicode.IsDigit(r) {
+			if separate && b.Len() > 0 {
+				b.WriteByte('_')
+			}
+			b.WriteRune(r)
+			separate = false
+			continue
+		}
+		separate = true
+	}
+	return b.String()
+}
+
+// prepare validates a request and fills in the name, key and options of field.
+// Keys must be unique within the board.
+func (s *customFieldService) prepare(field *models.CustomField, req CustomFieldRequest) error {
+	name := strings.TrimSpace(req.Name)
+	if name == "" || len(name) > 100 {
+		return errors.New("name is required and must be at most 100 characters")
+	}
+	key := customFieldKey(name)
+	if key == "" {
+		return errors.New("name must contain at least one letter or digit")
+	}
+	if !slices.Contains(models.CustomFieldTypes, field.Type) {
+		return fmt.Errorf("invalid type %q, allowed types are %s", field.Type, strings.Join(models.CustomFieldTypes, ", "))
+	}
+
+	var options types.JSONStrings
+	if field.Type == models.CustomFieldDropdown {
+		if len(req.Options) == 0 {
+			return errors.New("dropdown fields need at least one option")
+		}
+		for _, option := range req.Options {
+			option = strings.TrimSpace(option)
+			if option == "" || len(option) > 100 {
+				return errors.New("options must be non-empty and at most 100 characters")
+			}
+			if slices.ContainsFunc(options, func(o string) bool { return strings.EqualFold(o, option) }) {
+				return fmt.Errorf("option %q is listed more than once", option)
+			}
+			options = append(options, option)
+		}
+	} else if len(req.Options) > 0 {
+		return fmt.Errorf("%s fields do not have options", field.Type)
+	}
+
+	fields, err := s.customFieldRepo.FindByBoard(uint(field.BoardID))
+	if err != nil {
+		return err
+	}
+	for _, other := range fields {
+		if other.Key == key && other.InternalID != field.InternalID {
+			return fmt.Errorf("a custom field with key %q already exists on this board", key)
+		}
+	}
+
+	field.Name = name
+	field.Key = key
+	field.Options = options
+	return nil
+}
+
+// Create adds a custom field at the end of a board's fields.
+func (s *customFieldService) Create(boardPublicID string, req CustomFieldRequest, actorPublicID string) (*models.CustomField, error) {
+	board, err := s.boardRepo.FindByPublicID(boardPublicID)
+	if err != nil {
+		return nil, errors.New("board not found")
+	}
+	if err := s.checkOwner(board, actorPublicID); err != nil {
+		return nil, err
+	}
+
+	field := &models.CustomField{
+		PublicID: uuid.New(),
+		BoardID:  board.InternalID,
+		Type:     strings.ToLower(strings.TrimSpace(req.Type)),
+	}
+	if err := s.prepare(field, req); err != nil {
+		return nil, err
+	}
+	if err := s.customFieldRepo.Create(field); err != nil {
+		return nil, err
+	}
+	return field, nil
+}
+
+// GetByBoard retrieves the custom fields of a board. Every member may read them.
+func (s *customFieldService) GetByBoard(boardPublicID, actorPublicID string) ([]models.CustomField, error) {
+	board, err := s.boardRepo.FindByPublicID(boardPublicID)
+	if err != nil {
+		return nil, errors.New("board not found")
+	}
+	actor, err := s.userRepo.FindByPublicID(actorPublicID)
+	if err != nil {
+		return nil, errors.New("user not found")
+	}
+	allowed, err := canAccessBoard(s.boardMemberRepo, board, uint(actor.InternalID))
+	if err != nil {
+		return nil, err
+	}
+	if !allowed {
+		return nil, errors.New("you are not a member of this board")
+	}
+	return s.customFieldRepo.FindByBoard(uint(board.InternalID))
+}
+
+// Update renames a custom field or replaces its dropdown options. Card values
+// that are no longer an option are cleared.
+func (s *customFieldService) Update(fieldPublicID string, req CustomFieldRequest, actorPublicID string) (*models.CustomField, error) {
+	field, err := s.loadField(fieldPublicID, actorPublicID)
+	if err != nil {
+		return nil, err
+	}
+	if req.Type != "" && !strings.EqualFold(strings.TrimSpace(req.Type), field.Type) {
+		return nil, errors.New("the type of a custom field cannot be changed")
+	}
+	if err := s.prepare(field, req); err != nil {
+		return nil, err
+	}
+	if err := s.customFieldRepo.Update(field); err != nil {
+		return nil, err
+	}
+	return field, nil
+}
+
+// Delete removes a custom field and its values from every card.
+func (s *customFieldService) Delete(fieldPublicID, actorPublicID string) error {
+	field, err := s.loadField(fieldPublicID, actorPublicID)
+	if err != nil {
+		return err
+	}
+	return s.customFieldRepo.Delete(field)
+}
+
+// SetValue sets the value of a custom field on a card, or clears it when value
+// is nil. Any member of the card's board may set values.
+func (s *customFieldService) SetValue(cardPublicID, fieldPublicID string, value interface{}, actorPublicID string) (*models.CardCustomFieldValue, error) {
+	card, err := s.cardRepo.FindByPublicID(cardPublicID)
+	if err != nil {
+		return nil, errors.New("card not found")
+	}
+	field, err := s.customFieldRepo.FindByPublicID(fieldPublicID)
+	if err != nil {
+		return nil, errors.New("custom field not found")
+	}
+	actor, err := s.userRepo.FindByPublicID(actorPublicID)
+	if err != nil {
+		return nil, errors.New("user not found")
+	}
+	list, err := s.listRepo.FindByID(uint(card.ListID))
+	if err != nil {
+		return nil, errors.New("list not found")
+	}
+	if list.BoardInternalID != field.BoardID {
+		return nil, errors.New("custom field does not belong to the card's board")
+	}
+	board, err := s.boardRepo.FindByID(uint(list.BoardInternalID))
+	if err != nil {
+		return nil, errors.New("board not found")
+	}
+	allowed, err := canAccessBoard(s.boardMemberRepo, board, uint(actor.InternalID))
+	if err != nil {
+		return nil, err
+	}
+	if !allowed {
+		return nil, errors.New("you are not a member of this board")
+	}
+
+	cardValue := &models.CardCustomFieldValue{
+		CardID:        card.InternalID,
+		FieldID:       field.InternalID,
+		FieldPublicID: field.PublicID,
+	}
+	if value == nil {
+		if err := s.customFieldRepo.ClearValue(uint(card.InternalID), uint(field.InternalID)); err != nil {
+			return nil, err
+		}
+	} else {
+		if err := customFieldValue(field, value, cardValue); err != nil {
+			return nil, err
+		}
+		if err := s.customFieldRepo.SetValue(cardValue); err != nil {
+			return nil, err
+		}
+	}
+
+	if err := s.watchService.Notify(WatchEvent{
+		ActorID:        uint(actor.InternalID),
+		EntityType:     models.WatchEntityCard,
+		EntityID:       uint(card.InternalID),
+		EntityPublicID: card.PublicID,
+		ListID:         uint(card.ListID),
+		BoardID:        uint(board.InternalID),
+		Action:         "card.updated",
+		Message:        fmt.Sprintf("%s updated %s on card %q", actor.Name, field.Name, card.Title),
+	}); err != nil {
+		log.Println("Failed to notify card watchers", err)
+	}
+	return cardValue, nil
+}
+
+// customFieldValue checks a JSON value against the field's type and stores it
+// in the matching column of v.
+func customFieldValue(field *models.CustomField, value interface{}, v *models.CardCustomFieldValue) error {
+	switch field.Type {
+	case models.CustomFieldText, models.CustomFieldDropdown:
+		text, ok := value.(string)
+		if !ok {
+			return fmt.Errorf("%q expects a string", field.Name)
+		}
+		text = strings.TrimSpace(text)
+		if field.Type == models.CustomFieldText {
+			if len(text) > maxCustomFieldText {
+				return fmt.Errorf("%q must be at most %d characters", field.Name, maxCustomFieldText)
+			}
+		} else {
+			i := slices.IndexFunc(field.Options, func(option string) bool { return strings.EqualFold(option, text) })
+			if i < 0 {
+				return fmt.Errorf("%q must be one of %s", field.Name, strings.Join(field.Options, ", "))
+			}
+			text = field.Options[i]
+		}
+		v.TextValue = &text
+		v.Value = text
+	case models.CustomFieldNumber:
+		number, ok := value.(float64)
+		if !ok {
+			return fmt.Errorf("%q expects a number", field.Name)
+		}
+		v.NumberValue = &number
+		v.Value = number
+	case models.CustomFieldDate:
+		text, ok := value.(string)
+		if !ok {
+			return fmt.Errorf("%q expects a date such as 2025-01-31 or 2025-01-31T09:00:00Z", field.Name)
+		}
+		date, err := time.Parse(time.RFC3339, text)
+		if err != nil {
+			date, err = time.Parse("2006-01-02", text)
+		}
+		if err != nil {
+			return fmt.Errorf("%q expects a date such as 2025-01-31 or 2025-01-31T09:00:00Z", field.Name)
+		}
+		v.DateValue = &date
+		v.Value = date
+	case models.CustomFieldCheckbox:
+		checked, ok := value.(bool)
+		if !ok {
+			return fmt.Errorf("%q expects true or false", field.Name)
+		}
+		v.BoolValue = &checked
+		v.Value = checked
+	}
+	return nil
+}
@@ -0,0 +1,187 @@
+package services
+
+import (
+	"errors"
+	"log"
+	"net/mail"
+	"strings"
+	"time"
+
+	"github.com/mohod24/go-project-management/config"
+	"github.com/mohod24/go-project-management/mailer"
+	"github.com/mohod24/go-project-management/models"
+	"github.com/mohod24/go-project-management/repositories"
+	"github.com/mohod24/go-project-management/utils"
+)
+
+// Purposes that scope signed verification tokens.
+const (
+	verifyEmailTokenPurpose = "verify-email"
+	changeEmailTokenPurpose = "change-email"
+)
+
+// EmailVerificationService defines the interface for verifying email addresses.
+type EmailVerificationService interface {
+	SendVerification(user *models.User) error
+	Verify(token string) error
+	Resend(email string) error
+	RequestEmailChange(userPublicID, password, newEmail string) error
+	CancelEmailChange(userPublicID string) error
+	ConfirmEmailChange(token string) error
+}
+
+// emailVerificationService implements the EmailVerificationService interface.
+type emailVerificationService struct {
+	userRepo repositories.UserRepository
+	sender   mailer.Sender
+}
+
+// NewEmailVerificationService creates a new instance of EmailVerificationService.
+func NewEmailVerificationService(userRepo repositories.UserRepository, sender mailer.Sender) EmailVerificationService {
+	return &emailVerificationService{userRepo, sender}
+}
+
+// SendVerification emails a signed verification link to the user's address.
+func (s *emailVerificationService) SendVerification(user *models.User) error {
+	ttl, err := time.ParseDuration(config.AppConfig.EmailVerificationTTL)
+	if err != nil {
+		return err
+	}
+	now := time.Now()
+	expiresAt := now.Add(ttl)
+	// token terikat ke email saat ini, sehingga tidak berlaku lagi jika email diganti
+	token := utils.SignToken(verifyEmailTokenPurpose, user.PublicID.String()+" "+user.Email, expiresAt)
+
+	if err := s.userRepo.UpdateVerificationSentAt(uint(user.InternalID), now); err != nil {
+		return err
+	}
+	if err := s.sender.SendTemplate(user.Email, user.Locale, "verify_email", map[string]interface{}{
+		"Name":      user.Name,
+		"URL":       config.AppConfig.APPURL + "/v1/auth/verify-email?token=" + token,
+		"ExpiresAt": expiresAt.Format("2006-01-02 15:04 MST"),
+	}); err != nil {
+		log.Println("Failed to queue verification email", err)
+	}
+	return nil
+}
+
+// Verify marks the email address carried by a signed token as verified.
+func (s *emailVerificationService) Verify(token string) error {
+	value, err := utils.VerifySignedToken(verifyEmailTokenPurpose, token)
+	if err != nil {
+		return err
+	}
+	publicID, email, ok := strings.Cut(value, " ")
+	if !ok {
+		return utils.ErrInvalidSignedToken
+	}
+	user, err := s.userRepo.FindByPublicID(publicID)
+	if err != nil || user.Email != email {
+		return utils.ErrInvalidSignedToken
+	}
+	if user.EmailVerified {
+		return nil
+	}
+	return s.userRepo.MarkEmailVerified(uint(user.InternalID), time.Now())
+}
+
+// Resend sends a new verification link, at most once per
+// EMAIL_VERIFICATION_RESEND_INTERVAL. Unknown and already verified addresses
+// are ignored, and throttled requests succeed without sending, so the response
+// does not reveal which addresses have an unverified account.
+func (s *emailVerificationService) Resend(email string) error {
+	user, err := s.userRepo.FindByEmail(email)
+	if err != nil || user.InternalID == 0 || user.EmailVerified {
+		return nil
+	}
+	interval, err := time.ParseDuration(config.AppConfig.EmailVerificationResendInterval)
+	if err != nil {
+		return err
+	}
+	if user.VerificationSentAt != nil && time.Since(*user.VerificationSentAt) < interval {
+		log.Println("Verification email for user", user.PublicID, "was sent recently, skipping resend")
+		return nil
+	}
+	return s.SendVerification(user)
+}
+
+// RequestEmailChange emails a confirmation link to the new address. The
+// current address stays in use until the link is opened.
+func (s *emailVerificationService) RequestEmailChange(userPublicID, password, newEmail string) error {
+	user, err := s.userRepo.FindByPublicID(userPublicID)
+	if err != nil {
+		return errors.New("user not found")
+	}
+	if !utils.CheckPasswordHash(password, user.Password) {
+		return errors.New("password is incorrect")
+	}
+	newEmail = strings.ToLower(strings.TrimSpace(newEmail))
+	if _, err := mail.ParseAddress(newEmail); err != nil {
+		return errors.New("invalid email: " + newEmail)
+	}
+	if strings.EqualFold(newEmail, user.Email) {
+		return errors.New("new email is the same as the current email")
+	}
+	if existing, err := s.userRepo.FindByEmail(newEmail); err == nil && existing.InternalID != 0 {
+		return errors.New("email already registered")
+	}
+
+	ttl, err := time.ParseDuration(config.AppConfig.EmailVerificationTTL)
+	if err != nil {
+		return err
+	}
+	expiresAt := time.Now().Add(ttl)
+	token := utils.SignToken(changeEmailTokenPurpose, user.PublicID.String()+" "+newEmail, expiresAt)
+	// hanya permintaan terakhir yang berlaku, link lama jadi tidak valid
+	if err := s.userRepo.SetPendingEmail(uint(user.InternalID), &newEmail); err != nil {
+		return err
+	}
+	if err := s.sender.SendTemplate(newEmail, user.Locale, "change_email", map[string]interface{}{
+		"Name":      user.Name,
+		"URL":       config.AppConfig.APPURL + "/v1/auth/email/confirm?token=" + token,
+		"ExpiresAt": expiresAt.Format("2006-01-02 15:04 MST"),
+	}); err != nil {
+		log.Println("Failed to queue email change confirmation", err)
+	}
+	return nil
+}
+
+// CancelEmailChange discards a pending email change.
+func (s *emailVerificationService) CancelEmailChange(userPublicID string) error {
+	user, err := s.userRepo.FindByPublicID(userPublicID)
+	if err != nil {
+		return errors.New("user not found")
+	}
+	return s.userRepo.SetPendingEmail(uint(user.InternalID), nil)
+}
+
+// ConfirmEmailChange switches the user to the confirmed address and lets the
+// old address know about the change.
+func (s *emailVerificationService) ConfirmEmailChange(token string) error {
+	value, err := utils.VerifySignedToken(changeEmailTokenPurpose, token)
+	if err != nil {
+		return err
+	}
+	publicID, newEmail, ok := strings.Cut(value, " ")
+	if !ok {
+		return utils.ErrInvalidSignedToken
+	}
+	user, err := s.userRepo.FindByPublicID(publicID)
+	if err != nil || user.PendingEmail == nil || *user.PendingEmail != newEmail {
+		return utils.ErrInvalidSignedToken
+	}
+	if existing, err := s.userRepo.FindByEmail(newEmail); err == nil && existing.InternalID != 0 {
+		return errors.New("email already registered")
+	}
+	if err := s.userRepo.ChangeEmail(uint(user.InternalID), newEmail, time.Now()); err != nil {
+		return err
+	}
+
+	if err := s.sender.SendTemplate(user.Email, user.Locale, "email_changed", map[string]interface{}{
+		"Name":     user.Name,
+		"NewEmail": newEmail,
+	}); err != nil {
+		log.Println("Failed to queue email changed notice", err)
+	}
+	return nil
+}
@@ -0,0 +1,123 @@
+package services
+
+import (
+	"errors"
+	"log"
+	"time"
+
+	"github.com/mohod24/go-project-management/config"
+	"github.com/mohod24/go-project-management/models"
+	"github.com/mohod24/go-project-management/repositories"
+)
+
+var (
+	// ErrIdempotencyKeyReused is returned when a key is sent again with a
+	// different request than the one it was first used for.
+	ErrIdempotencyKeyReused = errors.New("idempotency key has already been used for a different request")
+	// ErrIdempotencyInProgress is returned when a key is sent again while the
+	// first request with that key is still being handled.
+	ErrIdempotencyInProgress = errors.New("a request with this idempotency key is still being processed")
+)
+
+// IdempotencyService defines the interface for replaying the responses of
+// requests sent with an Idempotency-Key header.
+type IdempotencyService interface {
+	Begin(userPublicID, key, requestHash string, now time.Time) (record *models.IdempotencyKey, replay bool, err error)
+	Complete(record *models.IdempotencyKey, statusCode int, contentType string, body []byte) error
+	Abandon(record *models.IdempotencyKey) error
+	Start(interval time.Duration) (stop func())
+	Purge(now time.Time) error
+}
+
+// idempotencyService implements the IdempotencyService interface.
+type idempotencyService struct {
+	idempotencyRepo repositories.IdempotencyKeyRepository
+	userRepo        repositories.UserRepository
+}
+
+// NewIdempotencyService creates a new instance of IdempotencyService. Keys are
+// scoped to a user and remembered for IDEMPOTENCY_KEY_TTL.
+func NewIdempotencyService(idempotencyRepo repositories.IdempotencyKeyRepository, userRepo repositories.UserRepository) IdempotencyService {
+	return &idempotencyService{idempotencyRepo, userRepo}
+}
+
+// Begin claims a user's key for a request identified by requestHash. When the
+// key was already used for the same request and its response is stored, that
+// record is returned with replay set. Otherwise the returned pending record
+// must be finished with Complete or Abandon once the request is handled.
+func (s *idempotencyService) Begin(userPublicID, key, requestHash string, now time.Time) (*models.IdempotencyKey, bool, error) {
+	ttl, err := time.ParseDuration(config.AppConfig.IdempotencyKeyTTL)
+	if err != nil {
+		return nil, false, err
+	}
+	user, err := s.userRepo.FindByPublicID(userPublicID)
+	if err != nil {
+		return nil, false, errors.New("user not found")
+	}
+
+	record := &models.IdempotencyKey{
+		UserID:      user.InternalID,
+		Key:         key,
+		RequestHash: requestHash,
+		ExpiresAt:   now.Add(ttl),
+	}
+	reserved, err := s.idempotencyRepo.Reserve(record)
+	if err != nil {
+		return nil, false, err
+	}
+	if reserved {
+		return record, false, nil
+	}
+
+	existing, err := s.idempotencyRepo.Find(user.InternalID, key)
+	if err != nil {
+		return nil, false, err
+	}
+	if existing.RequestHash != requestHash {
+		return nil, false, ErrIdempotencyKeyReused
+	}
+	if existing.StatusCode == nil {
+		return nil, false, ErrIdempotencyInProgress
+	}
+	return existing, true, nil
+}
+
+// Complete stores the response of a request so retries can replay it.
+func (s *idempotencyService) Complete(record *models.IdempotencyKey, statusCode int, contentType string, body []byte) error {
+	record.StatusCode = &statusCode
+	record.ContentType = contentType
+	record.ResponseBody = body
+	return s.idempotencyRepo.Complete(record)
+}
+
+// Abandon releases a key whose request failed unexpectedly, so a retry runs
+// the request again instead of replaying the failure.
+func (s *idempotencyService) Abandon(record *models.IdempotencyKey) error {
+	return s.idempotencyRepo.Delete(record.InternalID)
+}
+
+// Start purges expired keys every interval until the returned stop function
+// is called.
+func (s *idempotencyService) Start(interval time.Duration) (stop func()) {
+	ticker := time.NewTicker(interval)
+	done := make(chan struct{})
+	go func() {
+		for {
+			select {
+			case now := <-ticker.C:
+				if err := s.Purge(now); err != nil {
+					log.Println("Failed to purge idempotency keys", err)
+				}
+			case <-done:
+				ticker.Stop()
+				return
+			}
+		}
+	}()
+	return func() { close(done) }
+}
+
+// Purge removes every key whose replay window has ended.
+func (s *idempotencyService) Purge(now time.Time) error {
+	return s.idempotencyRepo.DeleteExpired(now)
+}
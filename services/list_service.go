@@ -0,0 +1,150 @@
+package services
+
+import (
+	"errors"
+	"log"
+
+	"github.com/google/uuid"
+	"github.com/mohod24/go-project-management/models"
+	"github.com/mohod24/go-project-management/repositories"
+)
+
+// ListService defines the interface for list-related business logic.
+type ListService interface {
+	Create(boardPublicID string, list *models.List, actorPublicID string) error
+	Update(list *models.List, actorPublicID string) error
+	GetByPublicID(publicID string) (*models.List, error)
+	GetWithIncludes(publicID string, includes []string, actorPublicID string) (*models.List, error)
+}
+
+// listService implements the ListService interface.
+type listService struct {
+	listRepo        repositories.ListRepository
+	boardRepo       repositories.BoardRepository
+	userRepo        repositories.UserRepository
+	boardMemberRepo repositories.BoardMemberRepository
+	watchService    WatchService
+}
+
+// NewListService creates a new instance of ListService.
+func NewListService(
+	listRepo repositories.ListRepository,
+	boardRepo repositories.BoardRepository,
+	userRepo repositories.UserRepository,
+	boardMemberRepo repositories.BoardMemberRepository,
+	watchService WatchService,
+) ListService {
+	return &listService{listRepo, boardRepo, userRepo, boardMemberRepo, watchService}
+}
+
+// Create adds a new list to a board the actor has access to.
+func (s *listService) Create(boardPublicID string, list *models.List, actorPublicID string) error {
+	board, err := s.boardRepo.FindByPublicID(boardPublicID)
+	if err != nil {
+		return errors.New("board not found")
+	}
+	actor, err := s.userRepo.FindByPublicID(actorPublicID)
+	if err != nil {
+		return errors.New("user not found")
+	}
+	allowed, err := canAccessBoard(s.boardMemberRepo, board, uint(actor.InternalID))
+	if err != nil {
+		return err
+	}
+	if !allowed {
+		return errors.New("you are not a member of this board")
+	}
+
+	list.PublicID = uuid.New()
+	list.BoardInternalID = board.InternalID
+	list.BoardPublicID = board.PublicID
+	if err := s.listRepo.Create(list); err != nil {
+		return err
+	}
+
+	if err := s.watchService.Notify(WatchEvent{
+		ActorID:        uint(actor.InternalID),
+		EntityType:     models.WatchEntityList,
+		EntityID:       uint(list.InternalID),
+		EntityPublicID: list.PublicID,
+		BoardID:        uint(board.InternalID),
+		Action:         "list.created",
+		Message:        actor.Name + " added list \"" + list.Title + "\" to board \"" + board.Title + "\"",
+	}); err != nil {
+		log.Println("Failed to notify list watchers", err)
+	}
+	return nil
+}
+
+// loadBoard resolves the actor and the list's board, and checks that the actor
+// has access to that board.
+func (s *listService) loadBoard(list *models.List, actorPublicID string) (*models.Board, *models.User, error) {
+	board, err := s.boardRepo.FindByID(uint(list.BoardInternalID))
+	if err != nil {
+		return nil, nil, errors.New("board not found")
+	}
+	actor, err := s.userRepo.FindByPublicID(actorPublicID)
+	if err != nil {
+		return nil, nil, errors.New("user not found")
+	}
+	allowed, err := canAccessBoard(s.boardMemberRepo, board, uint(actor.InternalID))
+	if err != nil {
+		return nil, nil, err
+	}
+	if !allowed {
+		return nil, nil, errors.New("you are not a member of this board")
+	}
+	return board, actor, nil
+}
+
+// Update renames a list on a board the actor has access to and notifies its
+// watchers. list.Version is the version the change was made against.
+func (s *listService) Update(list *models.List, actorPublicID string) error {
+	existing, err := s.listRepo.FindByPublicID(list.PublicID.String())
+	if err != nil {
+		return errors.New("list not found")
+	}
+	board, actor, err := s.loadBoard(existing, actorPublicID)
+	if err != nil {
+		return err
+	}
+
+	if err := s.listRepo.Update(list); err != nil {
+		return err
+	}
+	list.InternalID = existing.InternalID
+	list.BoardInternalID = existing.BoardInternalID
+	list.BoardPublicID = existing.BoardPublicID
+	list.CreatedAt = existing.CreatedAt
+
+	if err := s.watchService.Notify(WatchEvent{
+		ActorID:        uint(actor.InternalID),
+		EntityType:     models.WatchEntityList,
+		EntityID:       uint(list.InternalID),
+		EntityPublicID: list.PublicID,
+		BoardID:        uint(board.InternalID),
+		Action:         "list.updated",
+		Message:        actor.Name + " renamed list \"" + existing.Title + "\" to \"" + list.Title + "\"",
+	}); err != nil {
+		log.Println("Failed to notify list watchers", err)
+	}
+	return nil
+}
+
+// GetByPublicID retrieves a list by its public ID.
+func (s *listService) GetByPublicID(publicID string) (*models.List, error) {
+	return s.listRepo.FindByPublicID(publicID)
+}
+
+// GetWithIncludes retrieves a list on a board the actor has access to,
+// together with the requested relations.
+func (s *listService) GetWithIncludes(publicID string, includes []string, actorPublicID string) (*models.List, error) {
+	list, err := s.listRepo.FindByPublicIDWith(publicID, includes)
+	if err != nil {
+		return nil, errors.New("list not found")
+	}
+	if _, _, err := s.loadBoard(list, actorPublicID); err != nil {
+		return nil, err
+	}
+	return list, nil
+}
@@ -0,0 +1,189 @@
+package services
+
+import (
+	"errors"
+	"strings"
+	"time"
+
+	"github.com/mohod24/go-project-management/config"
+	"github.com/mohod24/go-project-management/models"
+	"github.com/mohod24/go-project-management/repositories"
+	"gorm.io/gorm"
+)
+
+// LoginThrottledError is returned when login attempts for an account or IP
+// address are temporarily blocked.
+type LoginThrottledError struct {
+	RetryAfter time.Duration
+}
+
+func (e *LoginThrottledError) Error() string {
+	return "too many failed login attempts, please try again later"
+}
+
+// LoginThrottleService defines the interface for brute-force protection on login.
+type LoginThrottleService interface {
+	Check(email, ip string, now time.Time) error
+	RecordFailure(email, ip string, now time.Time) error
+	RecordSuccess(email string) error
+	ListLocked() ([]models.LoginThrottle, error)
+	AccountStatus(userPublicID string) (*models.LoginLockoutStatus, error)
+	UnlockAccount(userPublicID string) error
+	UnlockIP(ip string) error
+}
+
+// loginThrottleService implements the LoginThrottleService interface.
+type loginThrottleService struct {
+	throttleRepo repositories.LoginThrottleRepository
+	userRepo     repositories.UserRepository
+}
+
+// NewLoginThrottleService creates a new instance of LoginThrottleService.
+// Failed logins are counted per account and per client IP. Every failure on
+// an account doubles the wait before the next attempt, and reaching the
+// configured threshold locks the account or IP for LOGIN_LOCKOUT_DURATION.
+func NewLoginThrottleService(throttleRepo repositories.LoginThrottleRepository, userRepo repositories.UserRepository) LoginThrottleService {
+	return &loginThrottleService{throttleRepo, userRepo}
+}
+
+// Check returns a *LoginThrottledError when the account or IP may not try to
+// log in yet. Accounts are keyed by email whether they exist or not, so the
+// response does not reveal which emails are registered.
+func (s *loginThrottleService) Check(email, ip string, now time.Time) error {
+	delayBase, delayMax, err := s.delays()
+	if err != nil {
+		return err
+	}
+
+	var retryAfter time.Duration
+	for _, target := range s.targets(email, ip) {
+		throttle, err := s.throttleRepo.Find(target[0], target[1])
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			continue
+		}
+		if err != nil {
+			return err
+		}
+		if throttle.LockedUntil != nil && throttle.LockedUntil.After(now) {
+			retryAfter = max(retryAfter, throttle.LockedUntil.Sub(now))
+		}
+		// progressive delay hanya untuk akun, bukan IP (banyak user bisa berbagi IP)
+		if target[0] == models.LoginThrottleAccount && throttle.Failures > 0 {
+			delay := delayBase << min(throttle.Failures-1, 30)
+			if delay <= 0 || delay > delayMax {
+				delay = delayMax
+			}
+			retryAfter = max(retryAfter, throttle.LastFailedAt.Add(delay).Sub(now))
+		}
+	}
+	if retryAfter > 0 {
+		return &LoginThrottledError{RetryAfter: retryAfter}
+	}
+	return nil
+}
+
+// RecordFailure counts a failed login and locks the account or IP address
+// once it reaches its threshold within LOGIN_FAILURE_WINDOW.
+func (s *loginThrottleService) RecordFailure(email, ip string, now time.Time) error {
+	window, err := time.ParseDuration(config.AppConfig.LoginFailureWindow)
+	if err != nil {
+		return err
+	}
+	lockout, err := time.ParseDuration(config.AppConfig.LoginLockoutDuration)
+	if err != nil {
+		return err
+	}
+	limits := map[string]int{
+		models.LoginThrottleAccount: config.AppConfig.LoginMaxFailuresPerAccount,
+		models.LoginThrottleIP:      config.AppConfig.LoginMaxFailuresPerIP,
+	}
+
+	for _, target := range s.targets(email, ip) {
+		throttle, err := s.throttleRepo.RecordFailure(target[0], target[1], now, now.Add(-window))
+		if err != nil {
+			return err
+		}
+		if limit := limits[target[0]]; limit > 0 && throttle.Failures >= limit {
+			if err := s.throttleRepo.Lock(target[0], target[1], now.Add(lockout)); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// RecordSuccess resets the account's failure counter. The IP counter is kept
+// so that one valid account does not reset a password spraying attempt.
+func (s *loginThrottleService) RecordSuccess(email string) error {
+	return s.throttleRepo.Clear(models.LoginThrottleAccount, normalizeEmail(email))
+}
+
+// ListLocked returns every account and IP address that is currently locked.
+func (s *loginThrottleService) ListLocked() ([]models.LoginThrottle, error) {
+	return s.throttleRepo.FindLocked(time.Now())
+}
+
+// AccountStatus returns the failure count and lockout state of a user.
+func (s *loginThrottleService) AccountStatus(userPublicID string) (*models.LoginLockoutStatus, error) {
+	user, err := s.userRepo.FindByPublicID(userPublicID)
+	if err != nil {
+		return nil, errors.New("user not found")
+	}
+	status := &models.LoginLockoutStatus{}
+	throttle, err := s.throttleRepo.Find(models.LoginThrottleAccount, normalizeEmail(user.Email))
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return status, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	status.Failures = throttle.Failures
+	status.LastFailedAt = &throttle.LastFailedAt
+	if throttle.LockedUntil != nil && throttle.LockedUntil.After(time.Now()) {
+		status.Locked = true
+		status.LockedUntil = throttle.LockedUntil
+	}
+	return status, nil
+}
+
+// UnlockAccount lifts a user's lockout and resets their failure counter.
+func (s *loginThrottleService) UnlockAccount(userPublicID string) error {
+	user, err := s.userRepo.FindByPublicID(userPublicID)
+	if err != nil {
+		return errors.New("user not found")
+	}
+	return s.throttleRepo.Clear(models.LoginThrottleAccount, normalizeEmail(user.Email))
+}
+
+// UnlockIP lifts the lockout of a client IP address.
+func (s *loginThrottleService) UnlockIP(ip string) error {
+	return s.throttleRepo.Clear(models.LoginThrottleIP, ip)
+}
+
+// targets returns the (scope, subject) pairs a login attempt is counted against.
+func (s *loginThrottleService) targets(email, ip string) [][2]string {
+	targets := [][2]string{{models.LoginThrottleAccount, normalizeEmail(email)}}
+	if ip != "" {
+		targets = append(targets, [2]string{models.LoginThrottleIP, ip})
+	}
+	return targets
+}
+
+// delays parses the progressive delay settings.
+func (s *loginThrottleService) delays() (time.Duration, time.Duration, error) {
+	base, err := time.ParseDuration(config.AppConfig.LoginDelayBase)
+	if err != nil {
+		return 0, 0, err
+	}
+	maxDelay, err := time.ParseDuration(config.AppConfig.LoginDelayMax)
+	if err != nil {
+		return 0, 0, err
+	}
+	return base, maxDelay, nil
+}
+
+// normalizeEmail lowercases and trims an email so that "A@x.com " and
+// "a@x.com" share one failure counter.
+func normalizeEmail(email string) string {
+	return strings.ToLower(strings.TrimSpace(email))
+}
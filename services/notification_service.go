@@ -0,0 +1,63 @@
+package services
+
+import (
+	"errors"
+
+	"github.com/mohod24/go-project-management/models"
+	"github.com/mohod24/go-project-management/repositories"
+)
+
+// NotificationService defines the interface for reading a user's notifications.
+type NotificationService interface {
+	GetAllPagination(userPublicID string, unreadOnly bool, limit, offset int) ([]models.Notification, int64, error)
+	GetAllCursor(userPublicID string, unreadOnly bool, page models.CursorPage) ([]models.Notification, models.CursorResult, error)
+	MarkRead(userPublicID, notificationPublicID string) error
+	MarkAllRead(userPublicID string) error
+}
+
+// notificationService implements the NotificationService interface.
+type notificationService struct {
+	notificationRepo repositories.NotificationRepository
+	userRepo         repositories.UserRepository
+}
+
+// NewNotificationService creates a new instance of NotificationService.
+func NewNotificationService(notificationRepo repositories.NotificationRepository, userRepo repositories.UserRepository) NotificationService {
+	return &notificationService{notificationRepo, userRepo}
+}
+
+// GetAllPagination retrieves the user's notifications, newest first.
+func (s *notificationService) GetAllPagination(userPublicID string, unreadOnly bool, limit, offset int) ([]models.Notification, int64, error) {
+	user, err := s.userRepo.FindByPublicID(userPublicID)
+	if err != nil {
+		return nil, 0, errors.New("user not found")
+	}
+	return s.notificationRepo.FindByUser(uint(user.InternalID), unreadOnly, limit, offset)
+}
+
+// GetAllCursor retrieves the user's notifications, newest first, with keyset pagination.
+func (s *notificationService) GetAllCursor(userPublicID string, unreadOnly bool, page models.CursorPage) ([]models.Notification, models.CursorResult, error) {
+	user, err := s.userRepo.FindByPublicID(userPublicID)
+	if err != nil {
+		return nil, models.CursorResult{}, errors.New("user not found")
+	}
+	return s.notificationRepo.FindByUserCursor(uint(user.InternalID), unreadOnly, page)
+}
+
+// MarkRead marks one of the user's notifications as read.
+func (s *notificationService) MarkRead(userPublicID, notificationPublicID string) error {
+	user, err := s.userRepo.FindByPublicID(userPublicID)
+	if err != nil {
+		return errors.New("user not found")
+	}
+	return s.notificationRepo.MarkRead(uint(user.InternalID), notificationPublicID)
+}
+
+// MarkAllRead marks all of the user's notifications as read.
+func (s *notificationService) MarkAllRead(userPublicID string) error {
+	user, err := s.userRepo.FindByPublicID(userPublicID)
+	if err != nil {
+		return errors.New("user not found")
+	}
+	return s.notificationRepo.MarkAllRead(uint(user.InternalID))
+}
@@ -0,0 +1,316 @@
+package services
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"net/http"
+	"net/url"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/MicahParks/keyfunc/v2"
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/google/uuid"
+	"github.com/mohod24/go-project-management/config"
+	"github.com/mohod24/go-project-management/models"
+	"github.com/mohod24/go-project-management/repositories"
+	"github.com/mohod24/go-project-management/utils"
+)
+
+// oidcFlowTTL is how long a user has to finish signing in at the provider.
+const oidcFlowTTL = 10 * time.Minute
+
+// ErrOIDCDisabled is returned when no identity provider is configured.
+var ErrOIDCDisabled = errors.New("single sign-on is not configured")
+
+// OIDCService defines the interface for OpenID Connect single sign-on.
+type OIDCService interface {
+	AuthorizationURL() (redirectURL, flowState string, err error)
+	Exchange(code, state, flowState string) (*models.User, error)
+}
+
+// oidcFlow is kept encrypted on the client between the redirect to the
+// provider and the callback, so no server-side session is needed.
+type oidcFlow struct {
+	State        string `json:"state"`
+	Nonce        string `json:"nonce"`
+	CodeVerifier string `json:"code_verifier"`
+	ExpiresAt    int64  `json:"exp"`
+}
+
+// oidcProvider holds the endpoints from the provider's discovery document.
+type oidcProvider struct {
+	Issuer                string `json:"issuer"`
+	AuthorizationEndpoint string `json:"authorization_endpoint"`
+	TokenEndpoint         string `json:"token_endpoint"`
+	JWKSURI               string `json:"jwks_uri"`
+
+	jwks *keyfunc.JWKS
+}
+
+// oidcService implements the OIDCService interface.
+type oidcService struct {
+	userRepo repositories.UserRepository
+	client   *http.Client
+
+	mu       sync.Mutex
+	provider *oidcProvider
+}
+
+// NewOIDCService creates a new instance of OIDCService. The provider's
+// discovery document and signing keys are fetched on first use, so the API
+// still starts when the provider is unreachable.
+func NewOIDCService(userRepo repositories.UserRepository) OIDCService {
+	return &oidcService{userRepo: userRepo, client: &http.Client{Timeout: 10 * time.Second}}
+}
+
+// AuthorizationURL starts an authorization-code flow with PKCE. flowState
+// must be returned to Exchange together with the provider's callback.
+func (s *oidcService) AuthorizationURL() (string, string, error) {
+	provider, err := s.discover()
+	if err != nil {
+		return "", "", err
+	}
+
+	flow := oidcFlow{ExpiresAt: time.Now().Add(oidcFlowTTL).Unix()}
+	for _, value := range []*string{&flow.State, &flow.Nonce, &flow.CodeVerifier} {
+		if *value, err = utils.GenerateRandomToken(32); err != nil {
+			return "", "", err
+		}
+	}
+	payload, _ := json.Marshal(flow)
+	flowState, err := utils.Encrypt(string(payload))
+	if err != nil {
+		return "", "", err
+	}
+
+	challenge := sha256.Sum256([]byte(flow.CodeVerifier))
+	query := url.Values{
+		"response_type":         {"code"},
+		"client_id":             {config.AppConfig.OIDCClientID},
+		"redirect_uri":          {config.AppConfig.OIDCRedirectURL},
+		"scope":                 {config.AppConfig.OIDCScopes},
+		"state":                 {flow.State},
+		"nonce":                 {flow.Nonce},
+		"code_challenge":        {base64.RawURLEncoding.EncodeToString(challenge[:])},
+		"code_challenge_method": {"S256"},
+	}
+	separator := "?"
+	if strings.Contains(provider.AuthorizationEndpoint, "?") {
+		separator = "&"
+	}
+	return provider.AuthorizationEndpoint + separator + query.Encode(), flowState, nil
+}
+
+// Exchange redeems the authorization code, validates the ID token and
+// returns the local user, linking or provisioning one by verified email.
+func (s *oidcService) Exchange(code, state, flowState string) (*models.User, error) {
+	provider, err := s.discover()
+	if err != nil {
+		return nil, err
+	}
+
+	payload, err := utils.Decrypt(flowState)
+	if err != nil {
+		return nil, errors.New("sign-in session is missing or invalid, please start again")
+	}
+	var flow oidcFlow
+	if err := json.Unmarshal([]byte(payload), &flow); err != nil || time.Now().Unix() > flow.ExpiresAt {
+		return nil, errors.New("sign-in session has expired, please start again")
+	}
+	if state == "" || state != flow.State {
+		return nil, errors.New("state does not match")
+	}
+
+	idToken, err := s.redeemCode(provider, code, flow.CodeVerifier)
+	if err != nil {
+		return nil, err
+	}
+	claims, err := s.validateIDToken(provider, idToken, flow.Nonce)
+	if err != nil {
+		return nil, err
+	}
+	return s.findOrProvision(provider.Issuer, claims)
+}
+
+// discover loads and caches the provider's discovery document and JWKS.
+func (s *oidcService) discover() (*oidcProvider, error) {
+	if config.AppConfig.OIDCClientID == "" {
+		return nil, ErrOIDCDisabled
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.provider != nil {
+		return s.provider, nil
+	}
+
+	issuer := strings.TrimSuffix(config.AppConfig.OIDCIssuerURL, "/")
+	resp, err := s.client.Get(issuer + "/.well-known/openid-configuration")
+	if err != nil {
+		return nil, fmt.Errorf("failed to reach identity provider: %w", err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("identity provider discovery failed with status %d", resp.StatusCode)
+	}
+	var provider oidcProvider
+	if err := json.NewDecoder(resp.Body).Decode(&provider); err != nil {
+		return nil, fmt.Errorf("invalid discovery document: %w", err)
+	}
+	if provider.Issuer != issuer {
+		return nil, fmt.Errorf("discovery issuer %q does not match %q", provider.Issuer, issuer)
+	}
+
+	provider.jwks, err = keyfunc.Get(provider.JWKSURI, keyfunc.Options{
+		Client:            s.client,
+		Ctx:               context.Background(),
+		RefreshInterval:   time.Hour,
+		RefreshRateLimit:  time.Minute,
+		RefreshUnknownKID: true,
+		RefreshErrorHandler: func(err error) {
+			log.Println("Failed to refresh identity provider keys", err)
+		},
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to load identity provider keys: %w", err)
+	}
+	s.provider = &provider
+	return s.provider, nil
+}
+
+// redeemCode exchanges the authorization code for an ID token.
+func (s *oidcService) redeemCode(provider *oidcProvider, code, codeVerifier string) (string, error) {
+	form := url.Values{
+		"grant_type":    {"authorization_code"},
+		"code":          {code},
+		"redirect_uri":  {config.AppConfig.OIDCRedirectURL},
+		"client_id":     {config.AppConfig.OIDCClientID},
+		"code_verifier": {codeVerifier},
+	}
+	if config.AppConfig.OIDCClientSecret != "" {
+		form.Set("client_secret", config.AppConfig.OIDCClientSecret)
+	}
+	resp, err := s.client.PostForm(provider.TokenEndpoint, form)
+	if err != nil {
+		return "", fmt.Errorf("failed to reach identity provider: %w", err)
+	}
+	defer resp.Body.Close()
+
+	var body struct {
+		IDToken          string `json:"id_token"`
+		Error            string `json:"error"`
+		ErrorDescription string `json:"error_description"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&body); err != nil {
+		return "", fmt.Errorf("invalid token response: %w", err)
+	}
+	if resp.StatusCode != http.StatusOK || body.IDToken == "" {
+		return "", fmt.Errorf("code exchange failed: %s %s", body.Error, body.ErrorDescription)
+	}
+	return body.IDToken, nil
+}
+
+// validateIDToken checks the ID token's signature, issuer, audience,
+// expiry and nonce.
+func (s *oidcService) validateIDToken(provider *oidcProvider, idToken, nonce string) (jwt.MapClaims, error) {
+	claims := jwt.MapClaims{}
+	_, err := jwt.ParseWithClaims(idToken, claims, provider.jwks.Keyfunc,
+		jwt.WithIssuer(provider.Issuer),
+		jwt.WithAudience(config.AppConfig.OIDCClientID),
+		jwt.WithExpirationRequired(),
+		jwt.WithValidMethods([]string{"RS256", "RS384", "RS512", "ES256", "ES384", "ES512", "EdDSA"}),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("invalid ID token: %w", err)
+	}
+	if claimNonce, _ := claims["nonce"].(string); claimNonce != nonce {
+		return nil, errors.New("invalid ID token: nonce does not match")
+	}
+	return claims, nil
+}
+
+// findOrProvision returns the user linked to the provider account. Otherwise
+// an existing user with the same verified email is linked, or a new user is
+// created when OIDC_ALLOW_SIGNUP is enabled.
+func (s *oidcService) findOrProvision(issuer string, claims jwt.MapClaims) (*models.User, error) {
+	subject, _ := claims["sub"].(string)
+	if subject == "" {
+		return nil, errors.New("invalid ID token: missing subject")
+	}
+	if user, err := s.userRepo.FindByOIDCSubject(issuer, subject); err == nil {
+		return user, nil
+	}
+
+	email, _ := claims["email"].(string)
+	email = strings.ToLower(strings.TrimSpace(email))
+	// sebagian provider mengirim email_verified sebagai string
+	verified, _ := claims["email_verified"].(bool)
+	if v, ok := claims["email_verified"].(string); ok {
+		verified = v == "true"
+	}
+	if email == "" || !verified {
+		return nil, errors.New("identity provider did not return a verified email address")
+	}
+
+	if user, err := s.userRepo.FindByEmail(email); err == nil {
+		if user.OIDCSubject != nil {
+			return nil, errors.New("this account is already linked to another identity")
+		}
+		if err := s.userRepo.LinkOIDC(uint(user.InternalID), issuer, subject); err != nil {
+			return nil, err
+		}
+		if !user.EmailVerified {
+			if err := s.userRepo.MarkEmailVerified(uint(user.InternalID), time.Now()); err != nil {
+				return nil, err
+			}
+			user.EmailVerified = true
+		}
+		return user, nil
+	}
+
+	if !config.AppConfig.OIDCAllowSignup {
+		return nil, errors.New("no account exists for this email address")
+	}
+	// user SSO tidak punya password lokal yang bisa dipakai login
+	randomPassword, err := utils.GenerateRandomToken(32)
+	if err != nil {
+		return nil, err
+	}
+	hashed, err := utils.HashPassword(randomPassword)
+	if err != nil {
+		return nil, err
+	}
+	name, _ := claims["name"].(string)
+	if name == "" {
+		name = email
+	}
+	locale, _ := claims["locale"].(string)
+	if !slices.Contains(models.SupportedLocales, locale) {
+		locale = models.SupportedLocales[0]
+	}
+	now := time.Now()
+	user := &models.User{
+		PublicID:           uuid.New(),
+		Name:               name,
+		Email:              email,
+		Password:           hashed,
+		Role:               "user",
+		ReminderPreference: models.ReminderAll,
+		Locale:             locale,
+		EmailVerified:      true,
+		EmailVerifiedAt:    &now,
+		OIDCIssuer:         &issuer,
+		OIDCSubject:        &subject,
+	}
+	if err := s.userRepo.Create(user); err != nil {
+		return nil, err
+	}
+	return user, nil
+}
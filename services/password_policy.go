@@ -0,0 +1,104 @@
+package services
+
+import (
+	"bufio"
+	"crypto/sha1"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"os"
+	"regexp"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/mohod24/go-project-management/config"
+	"github.com/mohod24/go-project-management/models"
+	"github.com/mohod24/go-project-management/repositories"
+	"github.com/mohod24/go-project-management/utils"
+)
+
+// sha1Line matches a line of a Have I Been Pwned style hash list, e.g.
+// "5BAA61E4C9B93F3F0682250B6CF8331B7EE68FD8:3861493".
+var sha1Line = regexp.MustCompile(`^[0-9A-Fa-f]{40}(:\d+)?$`)
+
+// PasswordPolicy defines the rules a new password must satisfy.
+type PasswordPolicy interface {
+	Validate(password string, user *models.User) error
+}
+
+// passwordPolicy implements the PasswordPolicy interface.
+type passwordPolicy struct {
+	historyRepo repositories.PasswordHistoryRepository
+	breached    map[string]struct{} // upper-case SHA-1 hex of breached passwords
+}
+
+// NewPasswordPolicy creates a new instance of PasswordPolicy. When
+// PASSWORD_BREACH_LIST_FILE is set, the file is loaded into memory; each line
+// holds either a password or its SHA-1 hash, optionally followed by ":count".
+func NewPasswordPolicy(historyRepo repositories.PasswordHistoryRepository) (PasswordPolicy, error) {
+	policy := &passwordPolicy{historyRepo: historyRepo, breached: make(map[string]struct{})}
+	path := config.AppConfig.PasswordBreachListFile
+	if path == "" {
+		return policy, nil
+	}
+
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+	scanner := bufio.NewScanner(file)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		switch {
+		case line == "" || strings.HasPrefix(line, "#"):
+		case sha1Line.MatchString(line):
+			policy.breached[strings.ToUpper(line[:40])] = struct{}{}
+		default:
+			policy.breached[sha1Hex(line)] = struct{}{}
+		}
+	}
+	return policy, scanner.Err()
+}
+
+// Validate checks the length limits and the breach list, and for an existing
+// user, that the password is not the current or a recently used one.
+func (p *passwordPolicy) Validate(password string, user *models.User) error {
+	minLength := config.AppConfig.PasswordMinLength
+	if utf8.RuneCountInString(password) < minLength {
+		return fmt.Errorf("password must be at least %d characters", minLength)
+	}
+	// bcrypt hanya memakai 72 byte pertama
+	if len(password) > 72 {
+		return errors.New("password must be at most 72 bytes")
+	}
+	if _, ok := p.breached[sha1Hex(password)]; ok {
+		return errors.New("this password appears in a list of breached passwords, please choose another one")
+	}
+	if user == nil || user.InternalID == 0 {
+		return nil
+	}
+
+	if utils.CheckPasswordHash(password, user.Password) {
+		return errors.New("new password must be different from the current password")
+	}
+	historyCount := config.AppConfig.PasswordHistoryCount
+	if historyCount <= 0 {
+		return nil
+	}
+	hashes, err := p.historyRepo.FindRecentHashes(uint(user.InternalID), historyCount)
+	if err != nil {
+		return err
+	}
+	for _, hash := range hashes {
+		if utils.CheckPasswordHash(password, hash) {
+			return fmt.Errorf("password was used recently, please choose one you have not used in your last %d passwords", historyCount)
+		}
+	}
+	return nil
+}
+
+func sha1Hex(s string) string {
+	sum := sha1.Sum([]byte(s))
+	return strings.ToUpper(hex.EncodeToString(sum[:]))
+}
@@ -0,0 +1,97 @@
+package services
+
+import (
+	"errors"
+	"log"
+	"time"
+
+	"github.com/mohod24/go-project-management/config"
+	"github.com/mohod24/go-project-management/mailer"
+	"github.com/mohod24/go-project-management/models"
+	"github.com/mohod24/go-project-management/repositories"
+	"github.com/mohod24/go-project-management/utils"
+)
+
+// PasswordResetService defines the interface for the forgot-password flow.
+type PasswordResetService interface {
+	RequestReset(email string) error
+	ConfirmReset(token, newPassword string) error
+}
+
+// passwordResetService implements the PasswordResetService interface.
+type passwordResetService struct {
+	resetRepo repositories.PasswordResetRepository
+	userRepo  repositories.UserRepository
+	sender    mailer.Sender
+	policy    PasswordPolicy
+}
+
+// NewPasswordResetService creates a new instance of PasswordResetService.
+func NewPasswordResetService(
+	resetRepo repositories.PasswordResetRepository,
+	userRepo repositories.UserRepository,
+	sender mailer.Sender,
+	policy PasswordPolicy,
+) PasswordResetService {
+	return &passwordResetService{resetRepo, userRepo, sender, policy}
+}
+
+// RequestReset emails a reset link if the address belongs to a user. The link
+// opens the reset page of the web app, which posts the token to ConfirmReset.
+// It returns nil for unknown addresses so callers cannot tell them apart.
+func (s *passwordResetService) RequestReset(email string) error {
+	user, err := s.userRepo.FindByEmail(email)
+	if err != nil || user.InternalID == 0 {
+		return nil
+	}
+
+	ttl, err := time.ParseDuration(config.AppConfig.PasswordResetTTL)
+	if err != nil {
+		return err
+	}
+	token, err := utils.GenerateRandomToken(32)
+	if err != nil {
+		return err
+	}
+	resetToken := &models.PasswordResetToken{
+		UserID:    user.InternalID,
+		TokenHash: utils.HashToken(token),
+		ExpiresAt: time.Now().Add(ttl),
+	}
+	if err := s.resetRepo.Create(resetToken); err != nil {
+		return err
+	}
+
+	if err := s.sender.SendTemplate(user.Email, user.Locale, "password_reset", map[string]interface{}{
+		"Name":      user.Name,
+		"URL":       config.AppConfig.WebAppURL + "/reset-password?token=" + token,
+		"ExpiresAt": resetToken.ExpiresAt.Format("2006-01-02 15:04 MST"),
+	}); err != nil {
+		log.Println("Failed to queue password reset email", err)
+	}
+	return nil
+}
+
+// ConfirmReset sets a new password using a reset token. The token can only be
+// used once and every existing session of the user is revoked.
+func (s *passwordResetService) ConfirmReset(token, newPassword string) error {
+	resetToken, err := s.resetRepo.FindValidByHash(utils.HashToken(token))
+	if err != nil {
+		return errors.New("invalid or expired token")
+	}
+	user, err := s.userRepo.FindByID(uint(resetToken.UserID))
+	if err != nil {
+		return errors.New("invalid or expired token")
+	}
+	if err := s.policy.Validate(newPassword, user); err != nil {
+		return err
+	}
+	hashed, err := utils.HashPassword(newPassword)
+	if err != nil {
+		return err
+	}
+	if err := s.resetRepo.ResetPassword(resetToken, hashed); err != nil {
+		return errors.New("invalid or expired token")
+	}
+	return nil
+}
@@ -0,0 +1,126 @@
+package services
+
+import (
+	"errors"
+	"log"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/mohod24/go-project-management/models"
+	"github.com/mohod24/go-project-management/repositories"
+	"github.com/mohod24/go-project-management/utils"
+)
+
+// PersonalAccessTokenPrefix marks personal access tokens so they can be told
+// apart from JWTs in the Authorization header.
+const PersonalAccessTokenPrefix = "gpm_"
+
+// PersonalAccessTokenService defines the interface for managing personal access tokens.
+type PersonalAccessTokenService interface {
+	Create(userPublicID, name string, scopes []string, expiresInDays int) (*models.PersonalAccessToken, string, error)
+	List(userPublicID string) ([]models.PersonalAccessToken, error)
+	Revoke(userPublicID, tokenPublicID string) error
+	Authenticate(rawToken string) (*models.User, *models.PersonalAccessToken, error)
+}
+
+// personalAccessTokenService implements the PersonalAccessTokenService interface.
+type personalAccessTokenService struct {
+	tokenRepo repositories.PersonalAccessTokenRepository
+	userRepo  repositories.UserRepository
+}
+
+// NewPersonalAccessTokenService creates a new instance of PersonalAccessTokenService.
+func NewPersonalAccessTokenService(tokenRepo repositories.PersonalAccessTokenRepository, userRepo repositories.UserRepository) PersonalAccessTokenService {
+	return &personalAccessTokenService{tokenRepo, userRepo}
+}
+
+// Create issues a new token for the user. The plain token is returned once
+// and cannot be retrieved again. expiresInDays 0 means the token never expires.
+func (s *personalAccessTokenService) Create(userPublicID, name string, scopes []string, expiresInDays int) (*models.PersonalAccessToken, string, error) {
+	user, err := s.userRepo.FindByPublicID(userPublicID)
+	if err != nil {
+		return nil, "", errors.New("user not found")
+	}
+	name = strings.TrimSpace(name)
+	if name == "" || len(name) > 100 {
+		return nil, "", errors.New("name is required and must be at most 100 characters")
+	}
+	if len(scopes) == 0 {
+		return nil, "", errors.New("at least one scope is required")
+	}
+	for _, scope := range scopes {
+		if !slices.Contains(models.PersonalAccessTokenScopes, scope) {
+			return nil, "", errors.New("unsupported scope: " + scope)
+		}
+	}
+	// token tidak boleh memberi akses lebih dari pemiliknya
+	if slices.Contains(scopes, models.ScopeAdminUsers) && user.Role != "admin" {
+		return nil, "", errors.New("only admins can create tokens with the admin:users scope")
+	}
+	if expiresInDays < 0 {
+		return nil, "", errors.New("expires_in_days must not be negative")
+	}
+
+	secret, err := utils.GenerateRandomToken(32)
+	if err != nil {
+		return nil, "", err
+	}
+	raw := PersonalAccessTokenPrefix + secret
+	token := &models.PersonalAccessToken{
+		PublicID:    uuid.New(),
+		UserID:      user.InternalID,
+		Name:        name,
+		TokenHash:   utils.HashToken(raw),
+		TokenPrefix: raw[:len(PersonalAccessTokenPrefix)+8],
+		Scopes:      slices.Compact(slices.Sorted(slices.Values(scopes))),
+		CreatedAt:   time.Now(),
+	}
+	if expiresInDays > 0 {
+		expiresAt := token.CreatedAt.AddDate(0, 0, expiresInDays)
+		token.ExpiresAt = &expiresAt
+	}
+	if err := s.tokenRepo.Create(token); err != nil {
+		return nil, "", err
+	}
+	return token, raw, nil
+}
+
+// List returns the user's active tokens without their secrets.
+func (s *personalAccessTokenService) List(userPublicID string) ([]models.PersonalAccessToken, error) {
+	user, err := s.userRepo.FindByPublicID(userPublicID)
+	if err != nil {
+		return nil, errors.New("user not found")
+	}
+	return s.tokenRepo.FindByUser(uint(user.InternalID))
+}
+
+// Revoke permanently disables one of the user's tokens.
+func (s *personalAccessTokenService) Revoke(userPublicID, tokenPublicID string) error {
+	user, err := s.userRepo.FindByPublicID(userPublicID)
+	if err != nil {
+		return errors.New("user not found")
+	}
+	if err := s.tokenRepo.Revoke(uint(user.InternalID), tokenPublicID); err != nil {
+		return errors.New("token not found")
+	}
+	return nil
+}
+
+// Authenticate resolves a raw token to its owner and records its use.
+func (s *personalAccessTokenService) Authenticate(rawToken string) (*models.User, *models.PersonalAccessToken, error) {
+	now := time.Now()
+	token, err := s.tokenRepo.FindActiveByHash(utils.HashToken(rawToken), now)
+	if err != nil {
+		return nil, nil, errors.New("invalid or expired personal access token")
+	}
+	user, err := s.userRepo.FindByID(uint(token.UserID))
+	if err != nil {
+		return nil, nil, errors.New("invalid or expired personal access token")
+	}
+	if err := s.tokenRepo.TouchLastUsed(uint(token.InternalID), now); err != nil {
+		log.Println("Failed to update token last used time", err)
+	}
+	return user, token, nil
+}
@@ -0,0 +1,131 @@
+package services
+
+import (
+	"fmt"
+	"log"
+	"sort"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/mohod24/go-project-management/config"
+	"github.com/mohod24/go-project-management/mailer"
+	"github.com/mohod24/go-project-management/models"
+	"github.com/mohod24/go-project-management/repositories"
+)
+
+// ReminderService defines the interface for the due-date reminder scheduler.
+type ReminderService interface {
+	Start(interval time.Duration) (stop func())
+	RunOnce(now time.Time) error
+}
+
+// reminderService implements the ReminderService interface.
+type reminderService struct {
+	reminderRepo    repositories.ReminderRepository
+	sender          mailer.Sender
+	windows         []time.Duration
+	overdueLookback time.Duration
+}
+
+// NewReminderService creates a new instance of ReminderService that reminds
+// assignees once for every window before a card's due date (e.g. 24h and 1h)
+// and once more when the card is overdue. Cards that became overdue more than
+// overdueLookback ago are left alone, so a first deploy does not remind about
+// every card that was ever overdue.
+func NewReminderService(reminderRepo repositories.ReminderRepository, sender mailer.Sender, windows []time.Duration, overdueLookback time.Duration) ReminderService {
+	sorted := append([]time.Duration(nil), windows...)
+	sort.Slice(sorted, func(i, j int) bool { return sorted[i] > sorted[j] })
+	return &reminderService{reminderRepo, sender, sorted, overdueLookback}
+}
+
+// Start runs the scheduler in the background until stop is called.
+func (s *reminderService) Start(interval time.Duration) (stop func()) {
+	ticker := time.NewTicker(interval)
+	done := make(chan struct{})
+	go func() {
+		for {
+			select {
+			case now := <-ticker.C:
+				if err := s.RunOnce(now); err != nil {
+					log.Println("Failed to send due-date reminders", err)
+				}
+			case <-done:
+				ticker.Stop()
+				return
+			}
+		}
+	}()
+	return func() { close(done) }
+}
+
+// RunOnce sends every reminder that is due at the given time.
+func (s *reminderService) RunOnce(now time.Time) error {
+	// Each window only covers cards that are not yet inside the next smaller
+	// window, so a card created 30 minutes before its due date gets the 1h
+	// reminder but not the 24h one.
+	for i, window := range s.windows {
+		var lower time.Duration
+		if i+1 < len(s.windows) {
+			lower = s.windows[i+1]
+		}
+		kind := fmt.Sprintf("before_%s", window)
+		pending, err := s.reminderRepo.FindPending(kind, now.Add(lower), now.Add(window), []string{models.ReminderAll})
+		if err != nil {
+			return err
+		}
+		for _, p := range pending {
+			message := fmt.Sprintf("Card \"%s\" is due at %s", p.Title, p.DueDate.Format("2006-01-02 15:04 MST"))
+			if err := s.send(p, kind, "card.due_soon", message, false, now); err != nil {
+				return err
+			}
+		}
+	}
+
+	pending, err := s.reminderRepo.FindPending("overdue", now.Add(-s.overdueLookback), now, []string{models.ReminderAll, models.ReminderOverdue})
+	if err != nil {
+		return err
+	}
+	for _, p := range pending {
+		message := fmt.Sprintf("Card \"%s\" is overdue since %s", p.Title, p.DueDate.Format("2006-01-02 15:04 MST"))
+		if err := s.send(p, "overdue", "card.overdue", message, true, now); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// send claims a reminder and notifies the assignee, in the app and by email,
+// if no other instance has sent it already.
+func (s *reminderService) send(p models.PendingReminder, kind, action, message string, overdue bool, now time.Time) error {
+	reminder := &models.CardReminder{
+		CardID:  p.CardID,
+		UserID:  p.UserID,
+		Kind:    kind,
+		DueDate: p.DueDate,
+		SentAt:  now,
+	}
+	notification := &models.Notification{
+		PublicID:       uuid.New(),
+		UserID:         p.UserID,
+		EntityType:     models.WatchEntityCard,
+		EntityPublicID: p.CardPublicID,
+		Action:         action,
+		Message:        message,
+		CreatedAt:      now,
+	}
+	claimed, err := s.reminderRepo.Claim(reminder, notification)
+	if err != nil || !claimed {
+		return err
+	}
+
+	if err := s.sender.SendTemplate(p.Email, p.Locale, "due_reminder", map[string]interface{}{
+		"Name":      p.Name,
+		"CardTitle": p.Title,
+		"DueDate":   p.DueDate.Format("2006-01-02 15:04 MST"),
+		"CardURL":   config.AppConfig.WebAppURL + "/cards/" + p.CardPublicID.String(),
+		"Overdue":   overdue,
+	}); err != nil {
+		log.Println("Failed to queue reminder email", err)
+	}
+	return nil
+}
@@ -0,0 +1,60 @@
+package services
+
+import (
+	"errors"
+	"html"
+	"slices"
+	"strings"
+
+	"github.com/mohod24/go-project-management/models"
+	"github.com/mohod24/go-project-management/repositories"
+)
+
+// SearchService defines the interface for full-text search across boards,
+// cards and comments.
+type SearchService interface {
+	Search(userPublicID, query string, types []string, limit, offset int) ([]models.SearchResult, int64, error)
+}
+
+// searchService implements the SearchService interface.
+type searchService struct {
+	searchRepo repositories.SearchRepository
+	userRepo   repositories.UserRepository
+}
+
+// NewSearchService creates a new instance of SearchService.
+func NewSearchService(searchRepo repositories.SearchRepository, userRepo repositories.UserRepository) SearchService {
+	return &searchService{searchRepo, userRepo}
+}
+
+// Search finds the boards, cards and comments matching query in the boards
+// the user can access. Without types every kind of result is searched.
+func (s *searchService) Search(userPublicID, query string, types []string, limit, offset int) ([]models.SearchResult, int64, error) {
+	query = strings.TrimSpace(query)
+	if query == "" {
+		return nil, 0, errors.New("search query is required")
+	}
+	if len(types) == 0 {
+		types = models.SearchTypes
+	}
+	for _, t := range types {
+		if !slices.Contains(models.SearchTypes, t) {
+			return nil, 0, errors.New("unsupported search type: " + t)
+		}
+	}
+	user, err := s.userRepo.FindByPublicID(userPublicID)
+	if err != nil {
+		return nil, 0, errors.New("user not found")
+	}
+
+	results, total, err := s.searchRepo.Search(uint(user.InternalID), query, types, limit, offset)
+	if err != nil {
+		return nil, 0, err
+	}
+	// escape isi board/card/komentar dulu, baru pasang tag <mark>
+	highlight := strings.NewReplacer(repositories.SearchMatchStart, "<mark>", repositories.SearchMatchStop, "</mark>")
+	for i := range results {
+		results[i].Headline = highlight.Replace(html.EscapeString(results[i].Headline))
+	}
+	return results, total, nil
+}
@@ -0,0 +1,204 @@
+package services
+
+import (
+	"errors"
+	"log"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/mohod24/go-project-management/config"
+	"github.com/mohod24/go-project-management/models"
+	"github.com/mohod24/go-project-management/repositories"
+	"github.com/mohod24/go-project-management/utils"
+	"gorm.io/gorm"
+)
+
+// IssuedTokens is the result of a login or a token refresh.
+type IssuedTokens struct {
+	AccessToken            string
+	RefreshToken           string
+	TwoFactorSetupRequired bool
+}
+
+// SessionService defines the interface for login sessions and token refresh.
+type SessionService interface {
+	Start(user *models.User, userAgent, ip string) (*IssuedTokens, error)
+	Refresh(refreshToken, userAgent, ip string) (*IssuedTokens, error)
+	IsActive(sessionPublicID string) (bool, error)
+	List(userPublicID, currentSessionID string) ([]models.Session, error)
+	Revoke(userPublicID, sessionPublicID string) error
+}
+
+// sessionService implements the SessionService interface.
+type sessionService struct {
+	sessionRepo      repositories.SessionRepository
+	userRepo         repositories.UserRepository
+	twoFactorService TwoFactorService
+}
+
+// NewSessionService creates a new instance of SessionService.
+func NewSessionService(
+	sessionRepo repositories.SessionRepository,
+	userRepo repositories.UserRepository,
+	twoFactorService TwoFactorService,
+) SessionService {
+	return &sessionService{sessionRepo, userRepo, twoFactorService}
+}
+
+// Start records a new session for the device and issues its tokens. Both
+// tokens carry the session ID, so revoking the session invalidates them.
+func (s *sessionService) Start(user *models.User, userAgent, ip string) (*IssuedTokens, error) {
+	ttl, err := time.ParseDuration(config.AppConfig.JWTRefreshToken)
+	if err != nil {
+		return nil, err
+	}
+	tokenID, err := utils.GenerateRandomToken(16)
+	if err != nil {
+		return nil, err
+	}
+	now := time.Now()
+	session := &models.Session{
+		PublicID:         uuid.New(),
+		UserID:           user.InternalID,
+		RefreshTokenHash: utils.HashToken(tokenID),
+		UserAgent:        truncate(userAgent, 255),
+		IPAddress:        truncate(ip, 45),
+		CreatedAt:        now,
+		LastUsedAt:       now,
+		ExpiresAt:        now.Add(ttl),
+	}
+	if err := s.sessionRepo.Create(session); err != nil {
+		return nil, err
+	}
+	return s.issue(user, session, tokenID)
+}
+
+// Refresh rotates the session's refresh token and issues new tokens. Using a
+// refresh token that was already rotated ends the session, since it means
+// the token has leaked.
+func (s *sessionService) Refresh(refreshToken, userAgent, ip string) (*IssuedTokens, error) {
+	claims, err := utils.ParseRefreshToken(refreshToken)
+	if err != nil {
+		return nil, errors.New("invalid or expired refresh token")
+	}
+	sessionID, _ := claims["sid"].(string)
+	tokenID, _ := claims["jti"].(string)
+	if sessionID == "" || tokenID == "" {
+		return nil, errors.New("invalid or expired refresh token")
+	}
+
+	now := time.Now()
+	session, err := s.sessionRepo.FindActiveByPublicID(sessionID, now)
+	if err != nil {
+		return nil, errors.New("session has been revoked or has expired")
+	}
+	user, err := s.userRepo.FindByID(uint(session.UserID))
+	if err != nil {
+		return nil, errors.New("user not found")
+	}
+	if version, _ := claims["ver"].(float64); int(version) != user.TokenVersion {
+		return nil, errors.New("session has been revoked")
+	}
+
+	ttl, err := time.ParseDuration(config.AppConfig.JWTRefreshToken)
+	if err != nil {
+		return nil, err
+	}
+	newTokenID, err := utils.GenerateRandomToken(16)
+	if err != nil {
+		return nil, err
+	}
+	rotated, err := s.sessionRepo.Rotate(uint(session.InternalID), utils.HashToken(tokenID), utils.HashToken(newTokenID), now.Add(ttl), now)
+	if err != nil {
+		return nil, err
+	}
+	if !rotated {
+		// refresh token lama dipakai ulang: anggap bocor dan akhiri sesi
+		if err := s.sessionRepo.RevokeByID(uint(session.InternalID)); err != nil {
+			log.Println("Failed to revoke session after refresh token reuse", err)
+		}
+		return nil, errors.New("refresh token has already been used, the session has been revoked")
+	}
+	return s.issue(user, session, newTokenID)
+}
+
+// IsActive reports whether a session may still be used, and records its use.
+func (s *sessionService) IsActive(sessionPublicID string) (bool, error) {
+	now := time.Now()
+	session, err := s.sessionRepo.FindActiveByPublicID(sessionPublicID, now)
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return false, nil
+	}
+	if err != nil {
+		return false, err
+	}
+	if err := s.sessionRepo.Touch(uint(session.InternalID), now); err != nil {
+		log.Println("Failed to update session last used time", err)
+	}
+	return true, nil
+}
+
+// List returns the user's active sessions, flagging the one making the request.
+func (s *sessionService) List(userPublicID, currentSessionID string) ([]models.Session, error) {
+	user, err := s.userRepo.FindByPublicID(userPublicID)
+	if err != nil {
+		return nil, errors.New("user not found")
+	}
+	sessions, err := s.sessionRepo.FindActiveByUser(uint(user.InternalID), time.Now())
+	if err != nil {
+		return nil, err
+	}
+	for i := range sessions {
+		sessions[i].Current = sessions[i].PublicID.String() == currentSessionID
+	}
+	return sessions, nil
+}
+
+// Revoke ends one of the user's sessions. Its tokens stop working immediately.
+func (s *sessionService) Revoke(userPublicID, sessionPublicID string) error {
+	user, err := s.userRepo.FindByPublicID(userPublicID)
+	if err != nil {
+		return errors.New("user not found")
+	}
+	if err := s.sessionRepo.Revoke(uint(user.InternalID), sessionPublicID); err != nil {
+		return errors.New("session not found")
+	}
+	return nil
+}
+
+// issue signs an access and a refresh token for the session. If two-factor
+// authentication is required but not set up yet, the access token only
+// grants access to the enrolment endpoints.
+func (s *sessionService) issue(user *models.User, session *models.Session, tokenID string) (*IssuedTokens, error) {
+	sessionID := session.PublicID.String()
+	accessClaims := map[string]interface{}{"sid": sessionID}
+	setupRequired := !user.TOTPEnabled && s.twoFactorService.IsRequired(user)
+	if setupRequired {
+		accessClaims["mfa_setup"] = true
+	}
+
+	accessToken, err := utils.GenerateToken(user.InternalID, user.Role, user.Email, user.PublicID, user.TokenVersion, accessClaims)
+	if err != nil {
+		return nil, err
+	}
+	refreshToken, err := utils.GenerateRefreshToken(user.InternalID, user.TokenVersion, map[string]interface{}{
+		"sid": sessionID,
+		"jti": tokenID,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &IssuedTokens{
+		AccessToken:            accessToken,
+		RefreshToken:           refreshToken,
+		TwoFactorSetupRequired: setupRequired,
+	}, nil
+}
+
+// truncate shortens s to at most n bytes so it fits its column.
+func truncate(s string, n int) string {
+	if len(s) > n {
+		return s[:n]
+	}
+	return s
+}
@@ -0,0 +1,148 @@
+package services
+
+import (
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/mohod24/go-project-management/config"
+	"github.com/mohod24/go-project-management/models"
+	"github.com/mohod24/go-project-management/repositories"
+	"github.com/mohod24/go-project-management/utils"
+)
+
+// SigningKeyService defines the interface for managing JWT signing keys.
+type SigningKeyService interface {
+	EnsureKey() error
+	Load() error
+	Start(interval time.Duration) (stop func())
+	Rotate(alg string, activationDelay time.Duration) (*models.SigningKey, error)
+	List() ([]models.SigningKey, error)
+	Prune() (int64, error)
+}
+
+// signingKeyService implements the SigningKeyService interface.
+type signingKeyService struct {
+	keyRepo repositories.SigningKeyRepository
+}
+
+// NewSigningKeyService creates a new instance of SigningKeyService.
+func NewSigningKeyService(keyRepo repositories.SigningKeyRepository) SigningKeyService {
+	return &signingKeyService{keyRepo}
+}
+
+// EnsureKey creates a key that is active right away when none exists yet,
+// so a fresh installation can issue tokens.
+func (s *signingKeyService) EnsureKey() error {
+	keys, err := s.keyRepo.FindUsable(time.Now())
+	if err != nil {
+		return err
+	}
+	if len(keys) > 0 {
+		return nil
+	}
+	_, err = s.Rotate(config.AppConfig.JWTSigningAlg, 0)
+	return err
+}
+
+// Load decrypts the usable keys and hands them to the token signer.
+func (s *signingKeyService) Load() error {
+	keys, err := s.keyRepo.FindUsable(time.Now())
+	if err != nil {
+		return err
+	}
+	parsed := make([]utils.SigningKey, 0, len(keys))
+	for _, key := range keys {
+		pemKey, err := utils.Decrypt(key.PrivateKey)
+		if err != nil {
+			return fmt.Errorf("failed to decrypt signing key %s: %w", key.KID, err)
+		}
+		private, err := utils.ParsePrivateKey(pemKey)
+		if err != nil {
+			return fmt.Errorf("failed to parse signing key %s: %w", key.KID, err)
+		}
+		parsed = append(parsed, utils.SigningKey{
+			KID:         key.KID,
+			Algorithm:   key.Algorithm,
+			PrivateKey:  private,
+			ActivatesAt: key.ActivatesAt,
+			ExpiresAt:   key.ExpiresAt,
+		})
+	}
+	utils.SetSigningKeys(parsed)
+	return nil
+}
+
+// Start reloads the keys in the background until stop is called, so keys
+// rotated by another process are picked up without a restart.
+func (s *signingKeyService) Start(interval time.Duration) (stop func()) {
+	ticker := time.NewTicker(interval)
+	done := make(chan struct{})
+	go func() {
+		for {
+			select {
+			case <-ticker.C:
+				if err := s.Load(); err != nil {
+					log.Println("Failed to reload JWT signing keys", err)
+				}
+			case <-done:
+				ticker.Stop()
+				return
+			}
+		}
+	}()
+	return func() { close(done) }
+}
+
+// Rotate creates a new key that starts signing after activationDelay. The
+// delay gives every instance time to load the key before tokens signed with
+// it arrive. Older keys keep verifying until the longest-lived token signed
+// before the switch has expired, so nobody is logged out.
+func (s *signingKeyService) Rotate(alg string, activationDelay time.Duration) (*models.SigningKey, error) {
+	accessTTL, err := time.ParseDuration(config.AppConfig.JWTExpire)
+	if err != nil {
+		return nil, err
+	}
+	refreshTTL, err := time.ParseDuration(config.AppConfig.JWTRefreshToken)
+	if err != nil {
+		return nil, err
+	}
+
+	private, err := utils.GenerateSigningKey(alg)
+	if err != nil {
+		return nil, err
+	}
+	pemKey, err := utils.MarshalPrivateKey(private)
+	if err != nil {
+		return nil, err
+	}
+	encrypted, err := utils.Encrypt(pemKey)
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	key := &models.SigningKey{
+		KID:         uuid.NewString(),
+		Algorithm:   alg,
+		PrivateKey:  encrypted,
+		CreatedAt:   now,
+		ActivatesAt: now.Add(activationDelay),
+	}
+	retireAt := key.ActivatesAt.Add(max(accessTTL, refreshTTL))
+	if err := s.keyRepo.Rotate(key, retireAt); err != nil {
+		return nil, err
+	}
+	return key, nil
+}
+
+// List returns every key that can still verify tokens.
+func (s *signingKeyService) List() ([]models.SigningKey, error) {
+	return s.keyRepo.FindUsable(time.Now())
+}
+
+// Prune deletes expired keys and returns how many were removed.
+func (s *signingKeyService) Prune() (int64, error) {
+	return s.keyRepo.DeleteExpired(time.Now())
+}
@@ -0,0 +1,311 @@
+package services
+
+import (
+	"errors"
+	"log"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/mohod24/go-project-management/config"
+	"github.com/mohod24/go-project-management/models"
+	"github.com/mohod24/go-project-management/repositories"
+	"github.com/mohod24/go-project-management/utils"
+)
+
+// recoveryCodeCount is the number of recovery codes issued at a time.
+const recoveryCodeCount = 10
+
+// TwoFactorSetup is returned when a user starts TOTP enrolment.
+type TwoFactorSetup struct {
+	Secret string `json:"secret"`
+	URI    string `json:"otpauth_uri"`
+}
+
+// TwoFactorService defines the interface for TOTP two-factor authentication.
+type TwoFactorService interface {
+	Setup(userPublicID string) (*TwoFactorSetup, error)
+	Confirm(userPublicID, code string) ([]string, error)
+	Disable(userPublicID, password, code string) error
+	RegenerateRecoveryCodes(userPublicID, code string) ([]string, error)
+	IsRequired(user *models.User) bool
+	SetRequired(userPublicID string, required bool) error
+	CreateChallenge(user *models.User) (string, error)
+	VerifyChallenge(challengeToken, code, ip string) (*models.User, error)
+}
+
+// twoFactorService implements the TwoFactorService interface.
+type twoFactorService struct {
+	userRepo         repositories.UserRepository
+	recoveryCodeRepo repositories.RecoveryCodeRepository
+	challengeRepo    repositories.TwoFactorChallengeRepository
+	throttleService  LoginThrottleService
+}
+
+// NewTwoFactorService creates a new instance of TwoFactorService.
+func NewTwoFactorService(
+	userRepo repositories.UserRepository,
+	recoveryCodeRepo repositories.RecoveryCodeRepository,
+	challengeRepo repositories.TwoFactorChallengeRepository,
+	throttleService LoginThrottleService,
+) TwoFactorService {
+	return &twoFactorService{userRepo, recoveryCodeRepo, challengeRepo, throttleService}
+}
+
+// Setup generates a new TOTP secret for the user. Two-factor authentication
+// stays disabled until the secret is confirmed with a valid code.
+func (s *twoFactorService) Setup(userPublicID string) (*TwoFactorSetup, error) {
+	user, err := s.userRepo.FindByPublicID(userPublicID)
+	if err != nil {
+		return nil, errors.New("user not found")
+	}
+	if user.TOTPEnabled {
+		return nil, errors.New("two-factor authentication is already enabled")
+	}
+	secret, err := utils.GenerateTOTPSecret()
+	if err != nil {
+		return nil, err
+	}
+	encrypted, err := utils.Encrypt(secret)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.userRepo.UpdateTwoFactor(uint(user.InternalID), map[string]interface{}{
+		"totp_secret":    encrypted,
+		"totp_last_step": 0,
+	}); err != nil {
+		return nil, err
+	}
+	return &TwoFactorSetup{
+		Secret: secret,
+		URI:    utils.TOTPURI(config.AppConfig.TOTPIssuer, user.Email, secret),
+	}, nil
+}
+
+// Confirm enables two-factor authentication once the user proves their
+// authenticator works, and returns a fresh set of recovery codes.
+func (s *twoFactorService) Confirm(userPublicID, code string) ([]string, error) {
+	user, err := s.userRepo.FindByPublicID(userPublicID)
+	if err != nil {
+		return nil, errors.New("user not found")
+	}
+	if user.TOTPEnabled {
+		return nil, errors.New("two-factor authentication is already enabled")
+	}
+	if user.TOTPSecret == nil {
+		return nil, errors.New("two-factor setup has not been started")
+	}
+	if err := s.checkTOTP(user, code); err != nil {
+		return nil, err
+	}
+	codes, err := s.issueRecoveryCodes(user)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.userRepo.UpdateTwoFactor(uint(user.InternalID), map[string]interface{}{
+		"totp_enabled": true,
+	}); err != nil {
+		return nil, err
+	}
+	return codes, nil
+}
+
+// Disable turns two-factor authentication off after re-checking the
+// password and a current code. It is refused while 2FA is required.
+func (s *twoFactorService) Disable(userPublicID, password, code string) error {
+	user, err := s.userRepo.FindByPublicID(userPublicID)
+	if err != nil {
+		return errors.New("user not found")
+	}
+	if !user.TOTPEnabled {
+		return errors.New("two-factor authentication is not enabled")
+	}
+	if s.IsRequired(user) {
+		return errors.New("two-factor authentication is required for your account")
+	}
+	if !utils.CheckPasswordHash(password, user.Password) {
+		return errors.New("invalid credential")
+	}
+	if err := s.checkCode(user, code); err != nil {
+		return err
+	}
+	if err := s.recoveryCodeRepo.DeleteByUser(uint(user.InternalID)); err != nil {
+		return err
+	}
+	return s.userRepo.UpdateTwoFactor(uint(user.InternalID), map[string]interface{}{
+		"totp_enabled":   false,
+		"totp_secret":    nil,
+		"totp_last_step": 0,
+	})
+}
+
+// RegenerateRecoveryCodes replaces the user's recovery codes after checking a current TOTP code.
+func (s *twoFactorService) RegenerateRecoveryCodes(userPublicID, code string) ([]string, error) {
+	user, err := s.userRepo.FindByPublicID(userPublicID)
+	if err != nil {
+		return nil, errors.New("user not found")
+	}
+	if !user.TOTPEnabled {
+		return nil, errors.New("two-factor authentication is not enabled")
+	}
+	if err := s.checkTOTP(user, code); err != nil {
+		return nil, err
+	}
+	return s.issueRecoveryCodes(user)
+}
+
+// IsRequired reports whether an admin, or the TWO_FACTOR_REQUIRED_ROLES
+// policy, requires the user to use two-factor authentication.
+func (s *twoFactorService) IsRequired(user *models.User) bool {
+	if user.TwoFactorRequired {
+		return true
+	}
+	roles := strings.Split(config.AppConfig.TwoFactorRequiredRoles, ",")
+	for i := range roles {
+		roles[i] = strings.TrimSpace(roles[i])
+	}
+	return slices.Contains(roles, user.Role)
+}
+
+// SetRequired lets an admin enforce two-factor authentication for a user.
+func (s *twoFactorService) SetRequired(userPublicID string, required bool) error {
+	user, err := s.userRepo.FindByPublicID(userPublicID)
+	if err != nil {
+		return errors.New("user not found")
+	}
+	return s.userRepo.UpdateTwoFactor(uint(user.InternalID), map[string]interface{}{
+		"two_factor_required": required,
+	})
+}
+
+// CreateChallenge returns the short-lived, single-use token that the second
+// login step exchanges, together with a code, for the real tokens.
+func (s *twoFactorService) CreateChallenge(user *models.User) (string, error) {
+	ttl, err := time.ParseDuration(config.AppConfig.TwoFactorChallengeTTL)
+	if err != nil {
+		return "", err
+	}
+	token, err := utils.GenerateRandomToken(32)
+	if err != nil {
+		return "", err
+	}
+	if err := s.challengeRepo.Create(&models.TwoFactorChallenge{
+		UserID:       user.InternalID,
+		TokenHash:    utils.HashToken(token),
+		TokenVersion: user.TokenVersion,
+		ExpiresAt:    time.Now().Add(ttl),
+	}); err != nil {
+		return "", err
+	}
+	return token, nil
+}
+
+// VerifyChallenge completes a two-step login with a TOTP code or a recovery
+// code. Wrong codes count as failed logins of the account and IP address, and
+// a challenge is dropped after TWO_FACTOR_CHALLENGE_MAX_ATTEMPTS of them.
+func (s *twoFactorService) VerifyChallenge(challengeToken, code, ip string) (*models.User, error) {
+	challenge, err := s.challengeRepo.FindValidByHash(utils.HashToken(challengeToken))
+	if err != nil {
+		return nil, utils.ErrInvalidSignedToken
+	}
+	user, err := s.userRepo.FindByID(uint(challenge.UserID))
+	if err != nil || user.TokenVersion != challenge.TokenVersion || !user.TOTPEnabled {
+		return nil, utils.ErrInvalidSignedToken
+	}
+
+	now := time.Now()
+	if err := s.throttleService.Check(user.Email, ip, now); err != nil {
+		return nil, err
+	}
+	if err := s.checkCode(user, code); err != nil {
+		s.challengeFailed(challenge, user, ip, now)
+		return nil, err
+	}
+
+	deleted, err := s.challengeRepo.Delete(challenge)
+	if err != nil {
+		return nil, err
+	}
+	if !deleted {
+		return nil, utils.ErrInvalidSignedToken
+	}
+	if err := s.throttleService.RecordSuccess(user.Email); err != nil {
+		log.Println("Failed to reset login failures", err)
+	}
+	return user, nil
+}
+
+// challengeFailed counts a wrong code against the challenge and the login
+// throttle, and drops the challenge once it has used up its attempts.
+func (s *twoFactorService) challengeFailed(challenge *models.TwoFactorChallenge, user *models.User, ip string, now time.Time) {
+	failures, err := s.challengeRepo.RecordFailure(challenge)
+	if err == nil && failures >= config.AppConfig.TwoFactorChallengeMaxAttempts {
+		_, err = s.challengeRepo.Delete(challenge)
+	}
+	if err != nil {
+		log.Println("Failed to record two-factor challenge failure", err)
+	}
+	if err := s.throttleService.RecordFailure(user.Email, ip, now); err != nil {
+		log.Println("Failed to record login failure", err)
+	}
+}
+
+// checkCode accepts either a TOTP code or an unused recovery code.
+func (s *twoFactorService) checkCode(user *models.User, code string) error {
+	code = strings.TrimSpace(code)
+	if len(code) == 6 {
+		return s.checkTOTP(user, code)
+	}
+	normalized := strings.ToLower(strings.ReplaceAll(code, "-", ""))
+	ok, err := s.recoveryCodeRepo.Consume(uint(user.InternalID), utils.HashToken(normalized))
+	if err != nil {
+		return err
+	}
+	if !ok {
+		return errors.New("invalid two-factor code")
+	}
+	return nil
+}
+
+// checkTOTP validates a TOTP code and makes sure it cannot be used twice.
+func (s *twoFactorService) checkTOTP(user *models.User, code string) error {
+	if user.TOTPSecret == nil {
+		return errors.New("two-factor authentication is not set up")
+	}
+	secret, err := utils.Decrypt(*user.TOTPSecret)
+	if err != nil {
+		return err
+	}
+	step, ok := utils.ValidateTOTP(secret, strings.TrimSpace(code), time.Now())
+	if !ok {
+		return errors.New("invalid two-factor code")
+	}
+	claimed, err := s.userRepo.ClaimTOTPStep(uint(user.InternalID), step)
+	if err != nil {
+		return err
+	}
+	if !claimed {
+		return errors.New("two-factor code has already been used")
+	}
+	return nil
+}
+
+// issueRecoveryCodes generates new recovery codes in the form xxxxx-xxxxx and
+// stores their hashes, replacing any previous codes.
+func (s *twoFactorService) issueRecoveryCodes(user *models.User) ([]string, error) {
+	codes := make([]string, 0, recoveryCodeCount)
+	hashes := make([]string, 0, recoveryCodeCount)
+	for i := 0; i < recoveryCodeCount; i++ {
+		raw, err := utils.GenerateTOTPSecret()
+		if err != nil {
+			return nil, err
+		}
+		normalized := strings.ToLower(raw[:10])
+		codes = append(codes, normalized[:5]+"-"+normalized[5:])
+		hashes = append(hashes, utils.HashToken(normalized))
+	}
+	if err := s.recoveryCodeRepo.Replace(uint(user.InternalID), hashes); err != nil {
+		return nil, err
+	}
+	return codes, nil
+}
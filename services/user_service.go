@@ -3,8 +3,12 @@ package services
 //go:generate mockgen -source=user_service.go -destination=../mocks/user_service_mock.go -package=mocks
 import (
 	"errors"
+	"log"
+	"slices"
+	"time"
 
 	"github.com/google/uuid"
+	"github.com/mohod24/go-project-management/config"
 	"github.com/mohod24/go-project-management/models"
 	"github.com/mohod24/go-project-management/repositories"
 	"github.com/mohod24/go-project-management/utils"
@@ -12,31 +16,64 @@ import (
 
 // UserService defines the interface for user-related operations.
 type UserService interface {
-	Register(user *models.User) error
-	Login(email, password string) (*models.User, error)
+	Register(user *models.User, inviteToken string) error
+	Login(email, password, ip string) (*models.User, error)
 	GetByID(id uint) (*models.User, error)
 	GetByPublicID(id string) (*models.User, error)
 	GetAllPagination(filter, sort string, limit, offset int) ([]models.User, int64, error)
+	GetAllCursor(filter string, page models.CursorPage) ([]models.User, models.CursorResult, error)
 	Update(user *models.User) error
+	UpdatePreferences(publicID, reminderPreference, locale string) error
+	ChangePassword(publicID, currentPassword, newPassword, ip string) (*models.User, error)
 	Delete(id uint) error
 }
 
 // userService is the concrete implementation of UserService.
 type userService struct {
-	repo repositories.UserRepository
+	repo                repositories.UserRepository
+	inviteService       BoardInviteService
+	verificationService EmailVerificationService
+	throttleService     LoginThrottleService
+	passwordPolicy      PasswordPolicy
 }
 
+// dummyPasswordHash is compared against when the email is unknown, so that a
+// login for a missing account takes as long as one with a wrong password.
+var dummyPasswordHash, _ = utils.HashPassword("dummy-password-for-timing")
+
 // NewUserService creates a new instance of UserService.
-func NewUserService(repo repositories.UserRepository) UserService {
-	return &userService{repo}
+func NewUserService(
+	repo repositories.UserRepository,
+	inviteService BoardInviteService,
+	verificationService EmailVerificationService,
+	throttleService LoginThrottleService,
+	passwordPolicy PasswordPolicy,
+) UserService {
+	return &userService{repo, inviteService, verificationService, throttleService, passwordPolicy}
 }
 
-// Register registers a new user.
-func (s *userService) Register(user *models.User) error {
+// Register registers a new user and sends an email verification link. When an
+// invite token is given, the email is already proven by the invite link, so
+// the user is verified and joins the invited board right away. Only the name,
+// email, password and locale of user are taken from the request; everything
+// else, such as two-factor state or a pending email change, starts out empty.
+func (s *userService) Register(user *models.User, inviteToken string) error {
+	*user = models.User{Name: user.Name, Email: user.Email, Password: user.Password, Locale: user.Locale}
 	existingUser, _ := s.repo.FindByEmail(user.Email)
 	if existingUser.InternalID != 0 {
 		return errors.New("email already registered")
 	}
+	var invite *models.BoardInvite
+	if inviteToken != "" {
+		var err error
+		invite, err = s.inviteService.CheckToken(inviteToken, user.Email)
+		if err != nil {
+			return err
+		}
+	}
+	if err := s.passwordPolicy.Validate(user.Password, nil); err != nil {
+		return err
+	}
 	hased, err := utils.HashPassword(user.Password)
 	if err != nil {
 		return err
@@ -44,26 +81,68 @@ func (s *userService) Register(user *models.User) error {
 
 	user.Password = hased
 	user.Role = "user"
+	user.ReminderPreference = models.ReminderAll
+	if !slices.Contains(models.SupportedLocales, user.Locale) {
+		user.Locale = models.SupportedLocales[0]
+	}
+	user.EmailVerified = invite != nil
+	user.EmailVerifiedAt = nil
+	if invite != nil {
+		now := time.Now()
+		user.EmailVerifiedAt = &now
+	}
 	user.PublicID = uuid.New()
 
-	return s.repo.Create(user)
+	if err := s.repo.Create(user); err != nil {
+		return err
+	}
+	if invite != nil {
+		return s.inviteService.AcceptForUser(invite, user)
+	}
+	if err := s.verificationService.SendVerification(user); err != nil {
+		log.Println("Failed to send verification email", err)
+	}
+	return nil
 }
 
-// Login authenticates a user with email and password.
-func (s *userService) Login(email, password string) (*models.User, error) {
+// Login authenticates a user with email and password. Failed attempts are
+// counted per account and per client IP, and a throttled attempt returns a
+// *LoginThrottledError before the password is checked.
+func (s *userService) Login(email, password, ip string) (*models.User, error) {
+	now := time.Now()
+	if err := s.throttleService.Check(email, ip, now); err != nil {
+		return nil, err
+	}
+
 	user, err := s.repo.FindByEmail(email)
 	// Check if user exists
 	if err != nil {
-		return nil, errors.New("invalid credential")
+		utils.CheckPasswordHash(password, dummyPasswordHash)
+		return nil, s.loginFailed(email, ip, now)
 	}
 	// Verify password
 	if !utils.CheckPasswordHash(password, user.Password) {
-		return nil, errors.New("invalid credential")
+		return nil, s.loginFailed(email, ip, now)
+	}
+	if err := s.throttleService.RecordSuccess(email); err != nil {
+		log.Println("Failed to reset login failures", err)
+	}
+	if config.AppConfig.RequireVerifiedEmailForLogin && !user.EmailVerified {
+		return nil, errors.New("email address has not been verified")
 	}
 	return user, nil
 
 }
 
+// loginFailed records a failed login and returns the uniform error shown for
+// unknown emails and wrong passwords alike.
+func (s *userService) loginFailed(email, ip string, now time.Time) error {
+	if err := s.throttleService.RecordFailure(email, ip, now); err != nil {
+		log.Println("Failed to record login failure", err)
+	}
+	return errors.New("invalid credential")
+}
+
 // GetByID retrieves a user by their internal ID.
 func (s *userService) GetByID(id uint) (*models.User, error) {
 	return s.repo.FindByID(id)
@@ -76,7 +155,16 @@ func (s *userService) GetByPublicID(id string) (*models.User, error) {
 
 // GetAllPagination retrieves users with pagination, filtering, and sorting.
 func (s *userService) GetAllPagination(filter, sort string, limit, offset int) ([]models.User, int64, error) {
-	return s.repo.FindAllPagination(filter, sort, limit, offset)
+	sortFields, err := utils.ParseSort(sort, models.UserSortFields)
+	if err != nil {
+		return nil, 0, err
+	}
+	return s.repo.FindAllPagination(filter, sortFields, limit, offset)
+}
+
+// GetAllCursor retrieves users with keyset pagination and filtering.
+func (s *userService) GetAllCursor(filter string, page models.CursorPage) ([]models.User, models.CursorResult, error) {
+	return s.repo.FindAllCursor(filter, page)
 }
 
 // Update updates user information.
@@ -84,6 +172,65 @@ func (s *userService) Update(user *models.User) error {
 	return s.repo.Update(user)
 }
 
+// UpdatePreferences changes which due-date reminders a user receives and the
+// language of their emails. Empty values are left unchanged.
+func (s *userService) UpdatePreferences(publicID, reminderPreference, locale string) error {
+	preferences := make(map[string]interface{})
+	if reminderPreference != "" {
+		switch reminderPreference {
+		case models.ReminderAll, models.ReminderOverdue, models.ReminderNone:
+		default:
+			return errors.New("reminder preference must be one of: all, overdue, none")
+		}
+		preferences["reminder_preference"] = reminderPreference
+	}
+	if locale != "" {
+		if !slices.Contains(models.SupportedLocales, locale) {
+			return errors.New("unsupported locale: " + locale)
+		}
+		preferences["locale"] = locale
+	}
+	if len(preferences) == 0 {
+		return nil
+	}
+	return s.repo.UpdatePreferences(publicID, preferences)
+}
+
+// ChangePassword replaces the user's password after checking the current one.
+// Wrong passwords count as failed logins, so a stolen access token cannot be
+// used to guess the password. Every session and personal access token is
+// ended; the returned user carries the new token version for a fresh session.
+func (s *userService) ChangePassword(publicID, currentPassword, newPassword, ip string) (*models.User, error) {
+	user, err := s.repo.FindByPublicID(publicID)
+	if err != nil {
+		return nil, errors.New("user not found")
+	}
+	now := time.Now()
+	if err := s.throttleService.Check(user.Email, ip, now); err != nil {
+		return nil, err
+	}
+	if !utils.CheckPasswordHash(currentPassword, user.Password) {
+		if err := s.throttleService.RecordFailure(user.Email, ip, now); err != nil {
+			log.Println("Failed to record login failure", err)
+		}
+		return nil, errors.New("current password is incorrect")
+	}
+	if err := s.throttleService.RecordSuccess(user.Email); err != nil {
+		log.Println("Failed to reset login failures", err)
+	}
+	if err := s.passwordPolicy.Validate(newPassword, user); err != nil {
+		return nil, err
+	}
+	hashed, err := utils.HashPassword(newPassword)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.repo.ChangePassword(uint(user.InternalID), hashed); err != nil {
+		return nil, err
+	}
+	return s.repo.FindByPublicID(publicID)
+}
+
 // Delete removes a user by their internal ID.
 func (s *userService) Delete(id uint) error {
 	return s.repo.Delete(id)
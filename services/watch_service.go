@@ -0,0 +1,218 @@
+package services
+
+import (
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/mohod24/go-project-management/models"
+	"github.com/mohod24/go-project-management/repositories"
+)
+
+// WatchEvent describes a change that the watchers of an entity, and of its
+// parent list and board, should be notified about.
+type WatchEvent struct {
+	ActorID        uint
+	EntityType     string
+	EntityID       uint
+	EntityPublicID uuid.UUID
+	ListID         uint // parent list of a card, 0 otherwise
+	BoardID        uint // board the entity belongs to
+	Action         string
+	Message        string
+}
+
+// WatchService defines the interface for watching boards, lists and cards.
+type WatchService interface {
+	Watch(userPublicID, entityType, entityPublicID string) error
+	Unwatch(userPublicID, entityType, entityPublicID string) error
+	IsWatching(userPublicID, entityType, entityPublicID string) (bool, error)
+	AutoWatch(userID uint, entityType string, entityID uint) error
+	UnwatchBoard(boardID uint, userIDs []uint) error
+	Notify(event WatchEvent) error
+}
+
+// watchService implements the WatchService interface.
+type watchService struct {
+	watcherRepo      repositories.WatcherRepository
+	notificationRepo repositories.NotificationRepository
+	userRepo         repositories.UserRepository
+	boardRepo        repositories.BoardRepository
+	listRepo         repositories.ListRepository
+	cardRepo         repositories.CardRepository
+	boardMemberRepo  repositories.BoardMemberRepository
+}
+
+// NewWatchService creates a new instance of WatchService.
+func NewWatchService(
+	watcherRepo repositories.WatcherRepository,
+	notificationRepo repositories.NotificationRepository,
+	userRepo repositories.UserRepository,
+	boardRepo repositories.BoardRepository,
+	listRepo repositories.ListRepository,
+	cardRepo repositories.CardRepository,
+	boardMemberRepo repositories.BoardMemberRepository,
+) WatchService {
+	return &watchService{watcherRepo, notificationRepo, userRepo, boardRepo, listRepo, cardRepo, boardMemberRepo}
+}
+
+// Watch subscribes the user to a board, list or card they have access to.
+func (s *watchService) Watch(userPublicID, entityType, entityPublicID string) error {
+	userID, entityID, err := s.resolve(userPublicID, entityType, entityPublicID)
+	if err != nil {
+		return err
+	}
+	return s.watcherRepo.Watch(userID, entityType, entityID)
+}
+
+// Unwatch removes the user's subscription to a board, list or card.
+func (s *watchService) Unwatch(userPublicID, entityType, entityPublicID string) error {
+	userID, entityID, err := s.resolve(userPublicID, entityType, entityPublicID)
+	if err != nil {
+		return err
+	}
+	return s.watcherRepo.Unwatch(userID, entityType, entityID)
+}
+
+// IsWatching reports whether the user watches a board, list or card.
+func (s *watchService) IsWatching(userPublicID, entityType, entityPublicID string) (bool, error) {
+	userID, entityID, err := s.resolve(userPublicID, entityType, entityPublicID)
+	if err != nil {
+		return false, err
+	}
+	return s.watcherRepo.IsWatching(userID, entityType, entityID)
+}
+
+// AutoWatch subscribes a user to an entity as a side effect of working on it.
+func (s *watchService) AutoWatch(userID uint, entityType string, entityID uint) error {
+	return s.watcherRepo.Watch(userID, entityType, entityID)
+}
+
+// UnwatchBoard removes the users' subscriptions to a board and everything on
+// it, as when they stop being members.
+func (s *watchService) UnwatchBoard(boardID uint, userIDs []uint) error {
+	return s.watcherRepo.DeleteForBoard(boardID, userIDs)
+}
+
+// Notify creates a notification for everyone watching the entity, its list or
+// its board. The actor is never notified about their own change, and watchers
+// who lost access to the board are skipped.
+func (s *watchService) Notify(event WatchEvent) error {
+	targets := []struct {
+		entityType string
+		entityID   uint
+	}{
+		{event.EntityType, event.EntityID},
+		{models.WatchEntityList, event.ListID},
+		{models.WatchEntityBoard, event.BoardID},
+	}
+
+	// kumpulkan watcher tanpa duplikat
+	recipients := make(map[uint]bool)
+	for _, target := range targets {
+		if target.entityID == 0 {
+			continue
+		}
+		userIDs, err := s.watcherRepo.FindWatcherIDs(target.entityType, target.entityID)
+		if err != nil {
+			return err
+		}
+		for _, userID := range userIDs {
+			if userID != event.ActorID {
+				recipients[userID] = true
+			}
+		}
+	}
+
+	if len(recipients) > 0 {
+		board, err := s.boardRepo.FindByID(event.BoardID)
+		if err != nil {
+			return err
+		}
+		for userID := range recipients {
+			allowed, err := canAccessBoard(s.boardMemberRepo, board, userID)
+			if err != nil {
+				return err
+			}
+			if !allowed {
+				delete(recipients, userID)
+			}
+		}
+	}
+
+	var actorID *int64
+	if event.ActorID != 0 {
+		id := int64(event.ActorID)
+		actorID = &id
+	}
+	now := time.Now()
+	var notifications []models.Notification
+	for userID := range recipients {
+		notifications = append(notifications, models.Notification{
+			PublicID:       uuid.New(),
+			UserID:         int64(userID),
+			ActorID:        actorID,
+			EntityType:     event.EntityType,
+			EntityPublicID: event.EntityPublicID,
+			Action:         event.Action,
+			Message:        event.Message,
+			CreatedAt:      now,
+		})
+	}
+	return s.notificationRepo.CreateBulk(notifications)
+}
+
+// resolve looks up the user and entity internal IDs and checks that the user
+// can access the board the entity belongs to.
+func (s *watchService) resolve(userPublicID, entityType, entityPublicID string) (uint, uint, error) {
+	user, err := s.userRepo.FindByPublicID(userPublicID)
+	if err != nil {
+		return 0, 0, errors.New("user not found")
+	}
+
+	var entityID uint
+	var board *models.Board
+	switch entityType {
+	case models.WatchEntityBoard:
+		board, err = s.boardRepo.FindByPublicID(entityPublicID)
+		if err != nil {
+			return 0, 0, errors.New("board not found")
+		}
+		entityID = uint(board.InternalID)
+	case models.WatchEntityList:
+		list, err := s.listRepo.FindByPublicID(entityPublicID)
+		if err != nil {
+			return 0, 0, errors.New("list not found")
+		}
+		entityID = uint(list.InternalID)
+		board, err = s.boardRepo.FindByID(uint(list.BoardInternalID))
+		if err != nil {
+			return 0, 0, errors.New("board not found")
+		}
+	case models.WatchEntityCard:
+		card, err := s.cardRepo.FindByPublicID(entityPublicID)
+		if err != nil {
+			return 0, 0, errors.New("card not found")
+		}
+		entityID = uint(card.InternalID)
+		list, err := s.listRepo.FindByID(uint(card.ListID))
+		if err != nil {
+			return 0, 0, errors.New("list not found")
+		}
+		board, err = s.boardRepo.FindByID(uint(list.BoardInternalID))
+		if err != nil {
+			return 0, 0, errors.New("board not found")
+		}
+	default:
+		return 0, 0, errors.New("unsupported entity type: " + entityType)
+	}
+
+	allowed, err := canAccessBoard(s.boardMemberRepo, board, uint(user.InternalID))
+	if err != nil {
+		return 0, 0, err
+	}
+	if !allowed {
+		return 0, 0, errors.New("you are not a member of this board")
+	}
+	return uint(user.InternalID), entityID, nil
+}
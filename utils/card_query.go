@@ -0,0 +1,301 @@
+package utils
+
+import (
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+	"unicode"
+
+	"github.com/mohod24/go-project-management/models"
+)
+
+// CardQueryError reports invalid card query syntax. Pos is the 1-based
+// character position the problem was found at.
+type CardQueryError struct {
+	Pos int
+	Msg string
+}
+
+func (e *CardQueryError) Error() string {
+	return fmt.Sprintf("invalid query at position %d: %s", e.Pos, e.Msg)
+}
+
+// cardQueryFields are the field names accepted before a colon.
+var cardQueryFields = []string{
+	models.CardQueryLabel,
+	models.CardQueryAssignee,
+	models.CardQueryList,
+	models.CardQueryDue,
+	models.CardQueryIs,
+}
+
+// ParseCardQuery parses a card filter such as
+//
+//	label:bug assignee:@me due:<7d list:"In Progress" -is:archived
+//
+// Terms are separated by spaces, a leading "-" negates a term and values with
+// spaces are quoted. Words without a field are matched against the card title
+// and description. Relative due dates (h, d or w) are resolved against now.
+func ParseCardQuery(query string, now time.Time) ([]models.CardQueryTerm, error) {
+	p := &cardQueryParser{input: []rune(query), now: now}
+	var terms []models.CardQueryTerm
+	for {
+		p.skipSpaces()
+		if p.done() {
+			return terms, nil
+		}
+		term, err := p.term()
+		if err != nil {
+			return nil, err
+		}
+		terms = append(terms, term)
+	}
+}
+
+type cardQueryParser struct {
+	input []rune
+	pos   int
+	now   time.Time
+}
+
+func (p *cardQueryParser) done() bool {
+	return p.pos >= len(p.input)
+}
+
+func (p *cardQueryParser) skipSpaces() {
+	for !p.done() && unicode.IsSpace(p.input[p.pos]) {
+		p.pos++
+	}
+}
+
+func (p *cardQueryParser) errorf(pos int, format string, args ...interface{}) error {
+	return &CardQueryError{Pos: pos + 1, Msg: fmt.Sprintf(format, args...)}
+}
+
+// term parses one, possibly negated, field term or free text word.
+func (p *cardQueryParser) term() (models.CardQueryTerm, error) {
+	var term models.CardQueryTerm
+	start := p.pos
+	if p.input[p.pos] == '-' {
+		term.Negated = true
+		p.pos++
+		if p.done() || unicode.IsSpace(p.input[p.pos]) {
+			return term, p.errorf(start, "\"-\" must be followed by a term")
+		}
+	}
+
+	// field:value, selain itu dianggap teks bebas
+	fieldStart := p.pos
+	for !p.done() && (unicode.IsLetter(p.input[p.pos]) || p.input[p.pos] == '_') {
+		p.pos++
+	}
+	if p.pos-fieldStart == 2 && strings.EqualFold(string(p.input[fieldStart:p.pos]), models.CardQueryCustomField) &&
+		p.pos+1 < len(p.input) && p.input[p.pos] == '.' {
+		return p.customFieldTerm(term, fieldStart)
+	}
+	if p.pos > fieldStart && !p.done() && p.input[p.pos] == ':' {
+		term.Field = strings.ToLower(string(p.input[fieldStart:p.pos]))
+		if !slices.Contains(cardQueryFields, term.Field) {
+			return term, p.errorf(fieldStart, "unknown field %q, expected one of %s", term.Field, strings.Join(cardQueryFields, ", "))
+		}
+		p.pos++
+	} else {
+		p.pos = fieldStart
+	}
+
+	valueStart := p.pos
+	value, err := p.value()
+	if err != nil {
+		return term, err
+	}
+	if value == "" {
+		if term.Field == models.CardQueryText {
+			return term, p.errorf(valueStart, "empty search text")
+		}
+		return term, p.errorf(valueStart, "missing value for %q", term.Field)
+	}
+	term.Value = value
+
+	switch term.Field {
+	case models.CardQueryAssignee:
+		if strings.HasPrefix(value, "@") && value != "@me" {
+			return term, p.errorf(valueStart, "unknown assignee %q, use @me, none, an email or a user ID", value)
+		}
+	case models.CardQueryIs:
+		term.Value = strings.ToLower(value)
+		switch term.Value {
+		case "archived":
+		case "overdue":
+			now := p.now
+			term.Time = &now
+		default:
+			return term, p.errorf(valueStart, "unknown state %q, expected archived or overdue", value)
+		}
+	case models.CardQueryDue:
+		if err := p.due(&term, valueStart); err != nil {
+			return term, err
+		}
+	}
+	return term, nil
+}
+
+// customFieldTerm parses the rest of a cf.<key>:<value> term. The value is
+// kept as written: its meaning depends on the field's type, see
+// ResolveCustomFieldTerm.
+func (p *cardQueryParser) customFieldTerm(term models.CardQueryTerm, fieldStart int) (models.CardQueryTerm, error) {
+	term.Field = models.CardQueryCustomField
+	p.pos++
+	keyStart := p.pos
+	for !p.done() && (unicode.IsLetter(p.input[p.pos]) || unicode.IsDigit(p.input[p.pos]) || p.input[p.pos] == '_') {
+		p.pos++
+	}
+	if p.pos == keyStart {
+		return term, p.errorf(keyStart, "missing custom field name after \"cf.\"")
+	}
+	if p.done() || p.input[p.pos] != ':' {
+		return term, p.errorf(fieldStart, "expected \":\" after custom field %q", string(p.input[keyStart:p.pos]))
+	}
+	term.Key = strings.ToLower(string(p.input[keyStart:p.pos]))
+	p.pos++
+
+	valueStart := p.pos
+	value, err := p.value()
+	if err != nil {
+		return term, err
+	}
+	if value == "" {
+		return term, p.errorf(valueStart, "missing value for %q", "cf."+term.Key)
+	}
+	term.Value = value
+	term.Pos = valueStart + 1
+	return term, nil
+}
+
+// ResolveCustomFieldTerm checks the value of a cf.<key> term against the
+// type of the custom field it names and fills in the term's bounds:
+//
+//	text      cf.customer:acme        contains, case insensitive
+//	dropdown  cf.env:production       equals one of the options
+//	number    cf.points:>=3           <, <=, >, >= or = a number
+//	date      cf.release:<2w          like due:
+//	checkbox  cf.blocked:true         true or false
+//
+// Every type accepts none for cards without a value.
+func ResolveCustomFieldTerm(term *models.CardQueryTerm, field *models.CustomField, now time.Time) error {
+	p := &cardQueryParser{now: now}
+	valueStart := term.Pos - 1
+	term.CustomField = field
+	if strings.EqualFold(term.Value, "none") && field.Type != models.CustomFieldDate {
+		term.Value = "none"
+		return nil
+	}
+
+	switch field.Type {
+	case models.CustomFieldDate:
+		return p.due(term, valueStart)
+	case models.CustomFieldNumber:
+		term.Op = "="
+		value := term.Value
+		for _, op := range []string{"<=", ">=", "<", ">", "="} {
+			if strings.HasPrefix(value, op) {
+				term.Op = op
+				value = strings.TrimPrefix(value, op)
+				break
+			}
+		}
+		number, err := strconv.ParseFloat(value, 64)
+		if err != nil {
+			return p.errorf(valueStart, "invalid number %q for %q", term.Value, "cf."+term.Key)
+		}
+		term.Number = &number
+	case models.CustomFieldCheckbox:
+		checked, err := strconv.ParseBool(term.Value)
+		if err != nil {
+			return p.errorf(valueStart, "invalid value %q for %q, expected true or false", term.Value, "cf."+term.Key)
+		}
+		term.Value = strconv.FormatBool(checked)
+	case models.CustomFieldDropdown:
+		if !slices.ContainsFunc(field.Options, func(option string) bool { return strings.EqualFold(option, term.Value) }) {
+			return p.errorf(valueStart, "unknown option %q for %q, expected one of %s", term.Value, "cf."+term.Key, strings.Join(field.Options, ", "))
+		}
+	}
+	return nil
+}
+
+// value reads a quoted or bare value.
+func (p *cardQueryParser) value() (string, error) {
+	if p.done() {
+		return "", nil
+	}
+	if p.input[p.pos] == '"' {
+		start := p.pos
+		p.pos++
+		valueStart := p.pos
+		for !p.done() && p.input[p.pos] != '"' {
+			p.pos++
+		}
+		if p.done() {
+			return "", p.errorf(start, "unterminated quote")
+		}
+		value := string(p.input[valueStart:p.pos])
+		p.pos++
+		if !p.done() && !unicode.IsSpace(p.input[p.pos]) {
+			return "", p.errorf(p.pos, "expected a space after closing quote")
+		}
+		return value, nil
+	}
+	start := p.pos
+	for !p.done() && !unicode.IsSpace(p.input[p.pos]) {
+		if p.input[p.pos] == '"' {
+			return "", p.errorf(p.pos, "unexpected quote inside a value")
+		}
+		p.pos++
+	}
+	return string(p.input[start:p.pos]), nil
+}
+
+// due resolves a due term: an optional comparison followed by none, a date
+// (YYYY-MM-DD) or a relative duration such as 7d, 12h, 2w or -3d.
+func (p *cardQueryParser) due(term *models.CardQueryTerm, valueStart int) error {
+	value := term.Value
+	if strings.EqualFold(value, "none") {
+		term.Value = "none"
+		return nil
+	}
+	term.Op = "="
+	for _, op := range []string{"<=", ">=", "<", ">", "="} {
+		if strings.HasPrefix(value, op) {
+			term.Op = op
+			value = strings.TrimPrefix(value, op)
+			break
+		}
+	}
+	if value == "" {
+		return p.errorf(valueStart, "missing date after %q", term.Op)
+	}
+
+	if date, err := time.ParseInLocation("2006-01-02", value, p.now.Location()); err == nil {
+		term.Time = &date
+		return nil
+	}
+	unit := value[len(value)-1]
+	amount, err := strconv.Atoi(value[:len(value)-1])
+	if err != nil || !strings.ContainsRune("hdw", rune(unit)) {
+		return p.errorf(valueStart, "invalid due date %q, use none, YYYY-MM-DD or a relative time such as 7d, 12h or 2w", term.Value)
+	}
+	if term.Op == "=" {
+		return p.errorf(valueStart, "relative due date %q needs <, <=, > or >=", term.Value)
+	}
+	d := time.Duration(amount) * time.Hour
+	switch unit {
+	case 'd':
+		d *= 24
+	case 'w':
+		d *= 24 * 7
+	}
+	t := p.now.Add(d)
+	term.Time = &t
+	return nil
+}
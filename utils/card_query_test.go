@@ -0,0 +1,205 @@
+package utils
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/mohod24/go-project-management/models"
+)
+
+var cardQueryNow = time.Date(2025, 3, 10, 9, 30, 0, 0, time.UTC)
+
+func cardQueryTime(t time.Time) *time.Time {
+	return &t
+}
+
+func TestParseCardQuery(t *testing.T) {
+	tests := []struct {
+		name  string
+		query string
+		want  []models.CardQueryTerm
+	}{
+		{
+			name:  "empty",
+			query: "   ",
+			want:  nil,
+		},
+		{
+			name:  "example from the docs",
+			query: `label:bug assignee:@me due:<7d list:"In Progress" -is:archived`,
+			want: []models.CardQueryTerm{
+				{Field: models.CardQueryLabel, Value: "bug"},
+				{Field: models.CardQueryAssignee, Value: "@me"},
+				{Field: models.CardQueryDue, Value: "<7d", Op: "<", Time: cardQueryTime(cardQueryNow.Add(7 * 24 * time.Hour))},
+				{Field: models.CardQueryList, Value: "In Progress"},
+				{Field: models.CardQueryIs, Value: "archived", Negated: true},
+			},
+		},
+		{
+			name:  "free text and negated free text",
+			query: `login "error page" -draft`,
+			want: []models.CardQueryTerm{
+				{Field: models.CardQueryText, Value: "login"},
+				{Field: models.CardQueryText, Value: "error page"},
+				{Field: models.CardQueryText, Value: "draft", Negated: true},
+			},
+		},
+		{
+			name:  "field names are case insensitive",
+			query: "LABEL:UI Is:Overdue",
+			want: []models.CardQueryTerm{
+				{Field: models.CardQueryLabel, Value: "UI"},
+				{Field: models.CardQueryIs, Value: "overdue", Time: cardQueryTime(cardQueryNow)},
+			},
+		},
+		{
+			name:  "assignee by email and none",
+			query: "assignee:ana@example.com -assignee:none",
+			want: []models.CardQueryTerm{
+				{Field: models.CardQueryAssignee, Value: "ana@example.com"},
+				{Field: models.CardQueryAssignee, Value: "none", Negated: true},
+			},
+		},
+		{
+			name:  "due dates",
+			query: "due:2025-03-31 due:>=-2w due:>12h due:NONE",
+			want: []models.CardQueryTerm{
+				{Field: models.CardQueryDue, Value: "2025-03-31", Op: "=", Time: cardQueryTime(time.Date(2025, 3, 31, 0, 0, 0, 0, time.UTC))},
+				{Field: models.CardQueryDue, Value: ">=-2w", Op: ">=", Time: cardQueryTime(cardQueryNow.Add(-14 * 24 * time.Hour))},
+				{Field: models.CardQueryDue, Value: ">12h", Op: ">", Time: cardQueryTime(cardQueryNow.Add(12 * time.Hour))},
+				{Field: models.CardQueryDue, Value: "none"},
+			},
+		},
+		{
+			name:  "custom fields keep their raw value",
+			query: `cf.Story_Points:>=3 -cf.customer:"Acme Corp" cf.release:none`,
+			want: []models.CardQueryTerm{
+				{Field: models.CardQueryCustomField, Key: "story_points", Value: ">=3", Pos: 17},
+				{Field: models.CardQueryCustomField, Key: "customer", Value: "Acme Corp", Pos: 34, Negated: true},
+				{Field: models.CardQueryCustomField, Key: "release", Value: "none", Pos: 57},
+			},
+		},
+		{
+			name:  "cf without a dot is free text",
+			query: "cf",
+			want: []models.CardQueryTerm{
+				{Field: models.CardQueryText, Value: "cf"},
+			},
+		},
+		{
+			name:  "quoted value with a colon",
+			query: `"a:b"`,
+			want: []models.CardQueryTerm{
+				{Field: models.CardQueryText, Value: "a:b"},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ParseCardQuery(tt.query, cardQueryNow)
+			if err != nil {
+				t.Fatalf("ParseCardQuery(%q) returned error: %v", tt.query, err)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("ParseCardQuery(%q)\n got %+v\nwant %+v", tt.query, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestParseCardQueryErrors(t *testing.T) {
+	tests := []struct {
+		query string
+		pos   int
+		msg   string
+	}{
+		{`status:done`, 1, `unknown field "status", expected one of label, assignee, list, due, is`},
+		{`label:`, 7, `missing value for "label"`},
+		{`list:"In Progress`, 6, `unterminated quote`},
+		{`list:"In"Progress`, 10, `expected a space after closing quote`},
+		{`label:b"ug`, 8, `unexpected quote inside a value`},
+		{`bug -`, 5, `"-" must be followed by a term`},
+		{`""`, 1, `empty search text`},
+		{`is:closed`, 4, `unknown state "closed", expected archived or overdue`},
+		{`assignee:@you`, 10, `unknown assignee "@you", use @me, none, an email or a user ID`},
+		{`due:soon`, 5, `invalid due date "soon", use none, YYYY-MM-DD or a relative time such as 7d, 12h or 2w`},
+		{`due:7d`, 5, `relative due date "7d" needs <, <=, > or >=`},
+		{`due:<`, 5, `missing date after "<"`},
+		{`cf.:3`, 4, `missing custom field name after "cf."`},
+		{`cf.points`, 1, `expected ":" after custom field "points"`},
+		{`cf.points:`, 11, `missing value for "cf.points"`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.query, func(t *testing.T) {
+			_, err := ParseCardQuery(tt.query, cardQueryNow)
+			var queryErr *CardQueryError
+			if !errors.As(err, &queryErr) {
+				t.Fatalf("ParseCardQuery(%q) error = %v, want a *CardQueryError", tt.query, err)
+			}
+			if queryErr.Pos != tt.pos || queryErr.Msg != tt.msg {
+				t.Errorf("ParseCardQuery(%q) error = %d %q, want %d %q", tt.query, queryErr.Pos, queryErr.Msg, tt.pos, tt.msg)
+			}
+		})
+	}
+}
+
+func TestResolveCustomFieldTerm(t *testing.T) {
+	fields := map[string]*models.CustomField{
+		"points":  {Key: "points", Type: models.CustomFieldNumber},
+		"release": {Key: "release", Type: models.CustomFieldDate},
+		"blocked": {Key: "blocked", Type: models.CustomFieldCheckbox},
+		"env":     {Key: "env", Type: models.CustomFieldDropdown, Options: []string{"Staging", "Production"}},
+	}
+	number := func(n float64) *float64 { return &n }
+
+	tests := []struct {
+		query  string
+		op     string
+		value  string
+		number *float64
+		time   *time.Time
+		err    string
+	}{
+		{query: "cf.points:>=3", op: ">=", value: ">=3", number: number(3)},
+		{query: "cf.points:2.5", op: "=", value: "2.5", number: number(2.5)},
+		{query: "cf.points:none", value: "none"},
+		{query: "cf.points:many", err: `invalid number "many" for "cf.points"`},
+		{query: "cf.release:<2w", op: "<", value: "<2w", time: cardQueryTime(cardQueryNow.Add(14 * 24 * time.Hour))},
+		{query: "cf.release:NONE", value: "none"},
+		{query: "cf.blocked:TRUE", value: "true"},
+		{query: "cf.blocked:maybe", err: `invalid value "maybe" for "cf.blocked", expected true or false`},
+		{query: "cf.env:production", value: "production"},
+		{query: "cf.env:dev", err: `unknown option "dev" for "cf.env", expected one of Staging, Production`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.query, func(t *testing.T) {
+			terms, err := ParseCardQuery(tt.query, cardQueryNow)
+			if err != nil {
+				t.Fatalf("ParseCardQuery(%q) returned error: %v", tt.query, err)
+			}
+			term := terms[0]
+			err = ResolveCustomFieldTerm(&term, fields[term.Key], cardQueryNow)
+			if tt.err != "" {
+				var queryErr *CardQueryError
+				if !errors.As(err, &queryErr) || queryErr.Msg != tt.err {
+					t.Fatalf("ResolveCustomFieldTerm(%q) error = %v, want %q", tt.query, err, tt.err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("ResolveCustomFieldTerm(%q) returned error: %v", tt.query, err)
+			}
+			if term.Op != tt.op || term.Value != tt.value || !reflect.DeepEqual(term.Number, tt.number) || !reflect.DeepEqual(term.Time, tt.time) {
+				t.Errorf("ResolveCustomFieldTerm(%q) = op %q value %q number %v time %v", tt.query, term.Op, term.Value, term.Number, term.Time)
+			}
+			if term.CustomField != fields[term.Key] {
+				t.Errorf("ResolveCustomFieldTerm(%q) did not set the custom field", tt.query)
+			}
+		})
+	}
+}
@@ -0,0 +1,56 @@
+package utils
+
+import (
+	"errors"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/golang-jwt/jwt/v4"
+)
+
+// GetClaims returns the JWT claims stored in the context by the auth middleware.
+func GetClaims(ctx *fiber.Ctx) (jwt.MapClaims, error) {
+	user, ok := ctx.Locals("user").(*jwt.Token)
+	if !ok {
+		return nil, errors.New("missing token")
+	}
+	claims, ok := user.Claims.(jwt.MapClaims)
+	if !ok {
+		return nil, errors.New("invalid token claims")
+	}
+	return claims, nil
+}
+
+// GetUserPublicID returns the public ID of the authenticated user stored in
+// the JWT claims by the auth middleware.
+func GetUserPublicID(ctx *fiber.Ctx) (string, error) {
+	claims, err := GetClaims(ctx)
+	if err != nil {
+		return "", err
+	}
+	publicID, ok := claims["pub_id"].(string)
+	if !ok || publicID == "" {
+		return "", errors.New("invalid token claims")
+	}
+	return publicID, nil
+}
+
+// GetScopes returns the scopes of a personal access token. scoped is false
+// for interactive JWTs, which are not limited by scopes.
+func GetScopes(ctx *fiber.Ctx) (scopes []string, scoped bool) {
+	claims, err := GetClaims(ctx)
+	if err != nil {
+		return nil, false
+	}
+	switch v := claims["scopes"].(type) {
+	case []string:
+		return v, true
+	case []interface{}:
+		for _, scope := range v {
+			if s, ok := scope.(string); ok {
+				scopes = append(scopes, s)
+			}
+		}
+		return scopes, true
+	}
+	return nil, false
+}
@@ -0,0 +1,80 @@
+package utils
+
+import (
+	"encoding/json"
+	"errors"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/mohod24/go-project-management/models"
+)
+
+// cursorTTL bounds how long a pagination cursor can be used.
+const cursorTTL = 24 * time.Hour
+
+// Page sizes of cursor-paginated listings.
+const (
+	defaultCursorLimit = 10
+	maxCursorLimit     = 100
+)
+
+// ErrInvalidCursor is returned for tampered, expired or foreign cursors.
+var ErrInvalidCursor = errors.New("invalid or expired cursor")
+
+// EncodeCursor returns an opaque, signed token for a cursor of the given
+// listing, e.g. "users" or "board-cards:<board id>".
+func EncodeCursor(listing string, cursor *models.Cursor) string {
+	if cursor == nil {
+		return ""
+	}
+	value, _ := json.Marshal(cursor)
+	return SignToken("cursor:"+listing, string(value), time.Now().Add(cursorTTL))
+}
+
+// DecodeCursor verifies a token created by EncodeCursor for the same listing.
+func DecodeCursor(listing, token string) (*models.Cursor, error) {
+	value, err := VerifySignedToken("cursor:"+listing, token)
+	if err != nil {
+		return nil, ErrInvalidCursor
+	}
+	var cursor models.Cursor
+	if err := json.Unmarshal([]byte(value), &cursor); err != nil {
+		return nil, ErrInvalidCursor
+	}
+	if cursor.Direction != models.CursorNext && cursor.Direction != models.CursorPrev {
+		return nil, ErrInvalidCursor
+	}
+	return &cursor, nil
+}
+
+// CursorParams reads ?cursor= and ?limit= of a listing. It returns nil when
+// the request uses page numbers instead; ?cursor= with an empty value asks
+// for the first page of a cursor-paginated listing.
+func CursorParams(ctx *fiber.Ctx, listing string) (*models.CursorPage, error) {
+	if !ctx.Context().QueryArgs().Has("cursor") {
+		return nil, nil
+	}
+	page := &models.CursorPage{Limit: ctx.QueryInt("limit", defaultCursorLimit)}
+	if page.Limit < 1 {
+		page.Limit = defaultCursorLimit
+	} else if page.Limit > maxCursorLimit {
+		page.Limit = maxCursorLimit
+	}
+	if token := ctx.Query("cursor"); token != "" {
+		cursor, err := DecodeCursor(listing, token)
+		if err != nil {
+			return nil, err
+		}
+		page.Cursor = cursor
+	}
+	return page, nil
+}
+
+// CursorMeta builds the pagination meta of a cursor-paginated page.
+func CursorMeta(listing string, limit int, result models.CursorResult) PaginationMeta {
+	return PaginationMeta{
+		Limit:      limit,
+		NextCursor: EncodeCursor(listing, result.Next),
+		PrevCursor: EncodeCursor(listing, result.Prev),
+	}
+}
@@ -0,0 +1,23 @@
+package utils
+
+import (
+	"strings"
+	"time"
+)
+
+// ParseDurations parses a comma-separated list of durations such as "24h,1h".
+func ParseDurations(value string) ([]time.Duration, error) {
+	var durations []time.Duration
+	for _, part := range strings.Split(value, ",") {
+		part = strings.TrimSpace(part)
+		if part == "" {
+			continue
+		}
+		d, err := time.ParseDuration(part)
+		if err != nil {
+			return nil, err
+		}
+		durations = append(durations, d)
+	}
+	return durations, nil
+}
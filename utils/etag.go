@@ -0,0 +1,98 @@
+package utils
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"hash/crc32"
+	"strconv"
+	"strings"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/mohod24/go-project-management/models"
+)
+
+// ErrIfMatchRequired is returned by IfMatchVersion when an update is sent
+// without an If-Match header.
+var ErrIfMatchRequired = errors.New("the If-Match header is required, send the ETag of the resource being updated")
+
+// ETags of boards, lists and cards look like "3-9a1f02c4": the version of the
+// resource followed by a checksum of the response body. If-Match only compares
+// the version, so changes to included relations or ?fields= do not cause
+// conflicts, while If-None-Match compares the whole tag so a 304 is never sent
+// for a representation that changed.
+
+// SuccessETag responds like SuccessFields and tags the response with an ETag
+// for the given version. GET and HEAD requests whose If-None-Match holds the
+// same ETag get 304 Not Modified without a body.
+func SuccessETag(c *fiber.Ctx, message string, data interface{}, version int64) error {
+	data, err := SparseFields(c, data)
+	if err != nil {
+		return BadRequest(c, "Parameter fields tidak valid", err.Error())
+	}
+	body, err := json.Marshal(Response{
+		Status:       "Success",
+		ResponseCode: fiber.StatusOK,
+		Message:      message,
+		Data:         data,
+	})
+	if err != nil {
+		return err
+	}
+
+	etag := fmt.Sprintf(`"%d-%08x"`, version, crc32.ChecksumIEEE(body))
+	c.Set(fiber.HeaderETag, etag)
+	if (c.Method() == fiber.MethodGet || c.Method() == fiber.MethodHead) && noneMatch(c.Get(fiber.HeaderIfNoneMatch), etag) {
+		return c.SendStatus(fiber.StatusNotModified)
+	}
+	c.Set(fiber.HeaderContentType, fiber.MIMEApplicationJSON)
+	return c.Status(fiber.StatusOK).Send(body)
+}
+
+// noneMatch reports whether an If-None-Match header matches etag, using the
+// weak comparison RFC 9110 prescribes for it.
+func noneMatch(header, etag string) bool {
+	for _, tag := range strings.Split(header, ",") {
+		tag = strings.TrimSpace(tag)
+		if tag == "*" || strings.TrimPrefix(tag, "W/") == etag {
+			return true
+		}
+	}
+	return false
+}
+
+// IfMatchVersion returns the version an update is made against, taken from the
+// ETag in the If-Match header. "*" matches any version and yields 0. A tag that
+// was not issued by SuccessETag, or a weak one, can never match and yields
+// models.ErrVersionConflict.
+func IfMatchVersion(c *fiber.Ctx) (int64, error) {
+	header := strings.TrimSpace(c.Get(fiber.HeaderIfMatch))
+	if header == "" {
+		return 0, ErrIfMatchRequired
+	}
+	if header == "*" {
+		return 0, nil
+	}
+	tag, ok := strings.CutPrefix(header, `"`)
+	if ok {
+		tag, ok = strings.CutSuffix(tag, `"`)
+	}
+	if !ok || strings.ContainsAny(tag, `",`) {
+		return 0, models.ErrVersionConflict
+	}
+	version, _, _ := strings.Cut(tag, "-")
+	n, err := strconv.ParseInt(version, 10, 64)
+	if err != nil || n <= 0 {
+		return 0, models.ErrVersionConflict
+	}
+	return n, nil
+}
+
+// VersionConflict responds to a failed If-Match precondition: 428 when the
+// header is missing and 412 when it no longer matches the stored version.
+func VersionConflict(c *fiber.Ctx, message string, err error) error {
+	if errors.Is(err, ErrIfMatchRequired) {
+		return PreconditionRequired(c, "Header If-Match wajib diisi", err.Error())
+	}
+	return PreconditionFailed(c, message, err.Error())
+}
@@ -0,0 +1,188 @@
+package utils
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"slices"
+	"strings"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+// FieldsError reports an unknown field or relation in ?fields= or ?include=.
+type FieldsError struct {
+	Msg string
+}
+
+func (e *FieldsError) Error() string {
+	return e.Msg
+}
+
+// ParseIncludes parses a comma-separated ?include= value such as
+// "lists,lists.cards,members" against the relations a resource allows. A
+// nested relation implies its parents.
+func ParseIncludes(value string, allowed []string) ([]string, error) {
+	var includes []string
+	for _, name := range strings.Split(value, ",") {
+		name = strings.TrimSpace(name)
+		if name == "" {
+			continue
+		}
+		if !slices.Contains(allowed, name) {
+			return nil, &FieldsError{Msg: fmt.Sprintf("cannot include %q, allowed relations are %s", name, strings.Join(allowed, ", "))}
+		}
+		parts := strings.Split(name, ".")
+		for i := range parts {
+			parent := strings.Join(parts[:i+1], ".")
+			if !slices.Contains(includes, parent) {
+				includes = append(includes, parent)
+			}
+		}
+	}
+	return includes, nil
+}
+
+// fieldTree is a parsed ?fields= value. An empty tree keeps every field.
+type fieldTree map[string]fieldTree
+
+// SparseFields applies ?fields= to a response payload. Fields are the JSON
+// names of the payload, nested fields of included relations are written as
+// paths like "lists.title", and a relation listed without nested fields is
+// returned whole. The payload is returned unchanged without ?fields=.
+func SparseFields(ctx *fiber.Ctx, data interface{}) (interface{}, error) {
+	value := ctx.Query("fields")
+	if strings.TrimSpace(value) == "" {
+		return data, nil
+	}
+	tree := fieldTree{}
+	for _, path := range strings.Split(value, ",") {
+		path = strings.TrimSpace(path)
+		if path == "" {
+			continue
+		}
+		if err := checkFieldPath(reflect.TypeOf(data), strings.Split(path, ".")); err != nil {
+			return nil, &FieldsError{Msg: fmt.Sprintf("unknown field %q", path)}
+		}
+		node := tree
+		for _, name := range strings.Split(path, ".") {
+			if node[name] == nil {
+				node[name] = fieldTree{}
+			}
+			node = node[name]
+		}
+	}
+
+	// lewat JSON supaya nama field sama persis dengan respons biasa
+	raw, err := json.Marshal(data)
+	if err != nil {
+		return nil, err
+	}
+	decoder := json.NewDecoder(bytes.NewReader(raw))
+	decoder.UseNumber()
+	var generic interface{}
+	if err := decoder.Decode(&generic); err != nil {
+		return nil, err
+	}
+	return tree.prune(generic), nil
+}
+
+// prune keeps only the fields of the tree in a decoded JSON value.
+func (t fieldTree) prune(value interface{}) interface{} {
+	if len(t) == 0 {
+		return value
+	}
+	switch v := value.(type) {
+	case []interface{}:
+		for i := range v {
+			v[i] = t.prune(v[i])
+		}
+		return v
+	case map[string]interface{}:
+		pruned := make(map[string]interface{}, len(t))
+		for name, child := range t {
+			if field, ok := v[name]; ok {
+				pruned[name] = child.prune(field)
+			}
+		}
+		return pruned
+	}
+	return value
+}
+
+// checkFieldPath reports whether a JSON field path exists in a type.
+func checkFieldPath(t reflect.Type, path []string) error {
+	for t.Kind() == reflect.Pointer || t.Kind() == reflect.Slice || t.Kind() == reflect.Array {
+		t = t.Elem()
+	}
+	if len(path) == 0 {
+		return nil
+	}
+	if t.Kind() != reflect.Struct {
+		return fmt.Errorf("%s has no fields", t)
+	}
+	field, ok := jsonField(t, path[0])
+	if !ok {
+		return fmt.Errorf("%s has no field %s", t, path[0])
+	}
+	return checkFieldPath(field.Type, path[1:])
+}
+
+// jsonField finds the struct field encoded under a JSON name. Like
+// encoding/json, fields of the struct itself win over promoted fields of
+// embedded structs.
+func jsonField(t reflect.Type, name string) (reflect.StructField, bool) {
+	var embedded []reflect.Type
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		tag, _, _ := strings.Cut(field.Tag.Get("json"), ",")
+		if tag == "-" {
+			continue
+		}
+		if field.Anonymous && tag == "" {
+			ft := field.Type
+			if ft.Kind() == reflect.Pointer {
+				ft = ft.Elem()
+			}
+			if ft.Kind() == reflect.Struct {
+				embedded = append(embedded, ft)
+				continue
+			}
+		}
+		if !field.IsExported() {
+			continue
+		}
+		if tag == "" {
+			tag = field.Name
+		}
+		if tag == name {
+			return field, true
+		}
+	}
+	for _, et := range embedded {
+		if field, ok := jsonField(et, name); ok {
+			return field, true
+		}
+	}
+	return reflect.StructField{}, false
+}
+
+// SuccessFields responds like Success with ?fields= applied to data.
+func SuccessFields(c *fiber.Ctx, message string, data interface{}) error {
+	data, err := SparseFields(c, data)
+	if err != nil {
+		return BadRequest(c, "Parameter fields tidak valid", err.Error())
+	}
+	return Success(c, message, data)
+}
+
+// SuccessPaginationFields responds like SuccessPagination with ?fields=
+// applied to every item of data.
+func SuccessPaginationFields(c *fiber.Ctx, message string, data interface{}, meta PaginationMeta) error {
+	data, err := SparseFields(c, data)
+	if err != nil {
+		return BadRequest(c, "Parameter fields tidak valid", err.Error())
+	}
+	return SuccessPagination(c, message, data, meta)
+}
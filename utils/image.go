@@ -0,0 +1,41 @@
+package utils
+
+import (
+	"image"
+	"image/color"
+)
+
+// ResizeSquare center-crops img to a square and scales it to size x size.
+// Each destination pixel averages the source pixels it covers, which gives
+// clean results when shrinking photos to avatar sizes.
+func ResizeSquare(img image.Image, size int) *image.RGBA {
+	bounds := img.Bounds()
+	side := min(bounds.Dx(), bounds.Dy())
+	crop := image.Rect(0, 0, side, side).Add(image.Pt(
+		bounds.Min.X+(bounds.Dx()-side)/2,
+		bounds.Min.Y+(bounds.Dy()-side)/2,
+	))
+
+	dst := image.NewRGBA(image.Rect(0, 0, size, size))
+	for y := 0; y < size; y++ {
+		y0 := crop.Min.Y + y*side/size
+		y1 := max(crop.Min.Y+(y+1)*side/size, y0+1)
+		for x := 0; x < size; x++ {
+			x0 := crop.Min.X + x*side/size
+			x1 := max(crop.Min.X+(x+1)*side/size, x0+1)
+
+			var r, g, b, a, n uint64
+			for sy := y0; sy < y1; sy++ {
+				for sx := x0; sx < x1; sx++ {
+					cr, cg, cb, ca := img.At(sx, sy).RGBA()
+					r, g, b, a = r+uint64(cr), g+uint64(cg), b+uint64(cb), a+uint64(ca)
+					n++
+				}
+			}
+			dst.Set(x, y, color.RGBA64{
+				R: uint16(r / n), G: uint16(g / n), B: uint16(b / n), A: uint16(a / n),
+			})
+		}
+	}
+	return dst
+}
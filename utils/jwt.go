@@ -1,6 +1,7 @@
 package utils
 
 import (
+	"errors"
 	"time"
 
 	"github.com/golang-jwt/jwt/v5"
@@ -11,8 +12,7 @@ import (
 //generatetoken jwt
 //generate refresh token
 
-func GenerateToken(userID int64, role, email string, publicID uuid.UUID) (string, error) {
-	secret := config.AppConfig.JWTSecret
+func GenerateToken(userID int64, role, email string, publicID uuid.UUID, tokenVersion int, extraClaims map[string]interface{}) (string, error) {
 	duration, _ := time.ParseDuration(config.AppConfig.JWTExpire)
 
 	claims := jwt.MapClaims{
@@ -20,22 +20,41 @@ func GenerateToken(userID int64, role, email string, publicID uuid.UUID) (string
 		"role":    role,
 		"pub_id":  publicID,
 		"email":   email,
+		"ver":     tokenVersion,
 		"exp":     time.Now().Add(duration).Unix(),
 	}
+	for key, value := range extraClaims {
+		claims[key] = value
+	}
 
-	token := jwt.NewWithClaims(jwt.SigningMethodHS256, claims)
-	return token.SignedString([]byte(secret))
+	return signToken(claims)
 }
 
-func GenerateRefreshToken(userID int64) (string, error) {
-	secret := config.AppConfig.JWTSecret
+func GenerateRefreshToken(userID int64, tokenVersion int, extraClaims map[string]interface{}) (string, error) {
 	duration, _ := time.ParseDuration(config.AppConfig.JWTRefreshToken)
 
 	claims := jwt.MapClaims{
 		"user_id": userID,
+		"ver":     tokenVersion,
+		"typ":     "refresh",
 		"exp":     time.Now().Add(duration).Unix(),
 	}
+	for key, value := range extraClaims {
+		claims[key] = value
+	}
+
+	return signToken(claims)
+}
 
-	token := jwt.NewWithClaims(jwt.SigningMethodHS256, claims)
-	return token.SignedString([]byte(secret))
-}
\ No newline at end of file
+// ParseRefreshToken verifies a refresh token and returns its claims.
+func ParseRefreshToken(tokenString string) (jwt.MapClaims, error) {
+	claims := jwt.MapClaims{}
+	_, err := jwt.ParseWithClaims(tokenString, claims, JWTKeyfunc, jwt.WithExpirationRequired())
+	if err != nil {
+		return nil, err
+	}
+	if typ, _ := claims["typ"].(string); typ != "refresh" {
+		return nil, errors.New("not a refresh token")
+	}
+	return claims, nil
+}
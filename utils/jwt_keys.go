@@ -0,0 +1,193 @@
+package utils
+
+import (
+	"crypto"
+	"crypto/ed25519"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"math/big"
+	"sync"
+	"time"
+
+	jwtv4 "github.com/golang-jwt/jwt/v4"
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/mohod24/go-project-management/config"
+)
+
+// Supported JWT signing algorithms.
+const (
+	AlgRS256 = "RS256"
+	AlgEdDSA = "EdDSA"
+)
+
+// SigningKey is a parsed JWT key pair. A key signs new tokens from
+// ActivatesAt on and verifies tokens until ExpiresAt, if set.
+type SigningKey struct {
+	KID         string
+	Algorithm   string
+	PrivateKey  crypto.Signer
+	ActivatesAt time.Time
+	ExpiresAt   *time.Time
+}
+
+// keyRing holds the keys loaded from the database by the signing key service.
+var keyRing struct {
+	sync.RWMutex
+	keys map[string]SigningKey
+}
+
+// SetSigningKeys replaces the keys used to sign and verify tokens.
+func SetSigningKeys(keys []SigningKey) {
+	byKID := make(map[string]SigningKey, len(keys))
+	for _, key := range keys {
+		byKID[key.KID] = key
+	}
+	keyRing.Lock()
+	keyRing.keys = byKID
+	keyRing.Unlock()
+}
+
+// currentSigningKey returns the most recently activated key.
+func currentSigningKey(now time.Time) (SigningKey, error) {
+	keyRing.RLock()
+	defer keyRing.RUnlock()
+	var current SigningKey
+	for _, key := range keyRing.keys {
+		if key.ActivatesAt.After(now) || (key.ExpiresAt != nil && !key.ExpiresAt.After(now)) {
+			continue
+		}
+		if current.KID == "" || key.ActivatesAt.After(current.ActivatesAt) {
+			current = key
+		}
+	}
+	if current.KID == "" {
+		return SigningKey{}, errors.New("no active JWT signing key")
+	}
+	return current, nil
+}
+
+// signToken signs claims with the current key and sets the kid header.
+func signToken(claims jwt.MapClaims) (string, error) {
+	key, err := currentSigningKey(time.Now())
+	if err != nil {
+		return "", err
+	}
+	method := jwt.GetSigningMethod(key.Algorithm)
+	if method == nil {
+		return "", fmt.Errorf("unsupported signing algorithm %s", key.Algorithm)
+	}
+	token := jwt.NewWithClaims(method, claims)
+	token.Header["kid"] = key.KID
+	return token.SignedString(key.PrivateKey)
+}
+
+// verificationKey returns the public key for kid if it may still verify
+// tokens signed with alg.
+func verificationKey(kid, alg string) (interface{}, error) {
+	keyRing.RLock()
+	key, ok := keyRing.keys[kid]
+	keyRing.RUnlock()
+	if !ok || (key.ExpiresAt != nil && !key.ExpiresAt.After(time.Now())) {
+		return nil, fmt.Errorf("unknown signing key %q", kid)
+	}
+	// cegah algorithm confusion: alg harus sama dengan algoritma kunci
+	if alg != key.Algorithm {
+		return nil, fmt.Errorf("unexpected signing method %s", alg)
+	}
+	return key.PrivateKey.Public(), nil
+}
+
+// JWTKeyfunc looks up the verification key of a token by its kid header.
+// With JWT_ACCEPT_LEGACY_HS256, kid-less tokens signed with JWT_SECRET before
+// the switch to asymmetric keys are accepted until JWT_LEGACY_HS256_UNTIL.
+func JWTKeyfunc(token *jwt.Token) (interface{}, error) {
+	kid, _ := token.Header["kid"].(string)
+	return keyFor(kid, token.Method.Alg())
+}
+
+// JWTKeyfuncV4 is JWTKeyfunc for the golang-jwt v4 parser used by the JWT middleware.
+func JWTKeyfuncV4(token *jwtv4.Token) (interface{}, error) {
+	kid, _ := token.Header["kid"].(string)
+	return keyFor(kid, token.Method.Alg())
+}
+
+func keyFor(kid, alg string) (interface{}, error) {
+	if alg == jwt.SigningMethodHS256.Alg() && kid == "" && config.AppConfig.JWTAcceptLegacyHS256 {
+		if !time.Now().Before(config.AppConfig.JWTLegacyHS256Until) {
+			return nil, errors.New("legacy HS256 tokens are no longer accepted")
+		}
+		return []byte(config.AppConfig.JWTSecret), nil
+	}
+	return verificationKey(kid, alg)
+}
+
+// JWKS returns the public verification keys as a JSON Web Key Set.
+func JWKS() map[string]interface{} {
+	keyRing.RLock()
+	defer keyRing.RUnlock()
+	now := time.Now()
+	keys := make([]map[string]string, 0, len(keyRing.keys))
+	for _, key := range keyRing.keys {
+		if key.ExpiresAt != nil && !key.ExpiresAt.After(now) {
+			continue
+		}
+		jwk := map[string]string{"kid": key.KID, "alg": key.Algorithm, "use": "sig"}
+		switch public := key.PrivateKey.Public().(type) {
+		case *rsa.PublicKey:
+			jwk["kty"] = "RSA"
+			jwk["n"] = base64.RawURLEncoding.EncodeToString(public.N.Bytes())
+			jwk["e"] = base64.RawURLEncoding.EncodeToString(big.NewInt(int64(public.E)).Bytes())
+		case ed25519.PublicKey:
+			jwk["kty"] = "OKP"
+			jwk["crv"] = "Ed25519"
+			jwk["x"] = base64.RawURLEncoding.EncodeToString(public)
+		default:
+			continue
+		}
+		keys = append(keys, jwk)
+	}
+	return map[string]interface{}{"keys": keys}
+}
+
+// GenerateSigningKey creates a new private key for the algorithm.
+func GenerateSigningKey(alg string) (crypto.Signer, error) {
+	switch alg {
+	case AlgRS256:
+		return rsa.GenerateKey(rand.Reader, 2048)
+	case AlgEdDSA:
+		_, private, err := ed25519.GenerateKey(rand.Reader)
+		return private, err
+	}
+	return nil, fmt.Errorf("unsupported signing algorithm %s, use %s or %s", alg, AlgRS256, AlgEdDSA)
+}
+
+// MarshalPrivateKey encodes a private key as a PKCS #8 PEM block.
+func MarshalPrivateKey(key crypto.Signer) (string, error) {
+	der, err := x509.MarshalPKCS8PrivateKey(key)
+	if err != nil {
+		return "", err
+	}
+	return string(pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: der})), nil
+}
+
+// ParsePrivateKey decodes a private key produced by MarshalPrivateKey.
+func ParsePrivateKey(data string) (crypto.Signer, error) {
+	block, _ := pem.Decode([]byte(data))
+	if block == nil {
+		return nil, errors.New("invalid PEM private key")
+	}
+	key, err := x509.ParsePKCS8PrivateKey(block.Bytes)
+	if err != nil {
+		return nil, err
+	}
+	signer, ok := key.(crypto.Signer)
+	if !ok {
+		return nil, errors.New("unsupported private key type")
+	}
+	return signer, nil
+}
@@ -33,6 +33,10 @@ type PaginationMeta struct {
 	TotalPage int    `json:"total_pages" example:"10"`
 	Filter    string `json:"filter" example:"nama=triady"`
 	Sort      string `json:"sort" example:"-id"`
+
+	// Keyset pagination, used instead of page numbers when ?cursor= is given
+	NextCursor string `json:"next_cursor,omitempty"`
+	PrevCursor string `json:"prev_cursor,omitempty"`
 }
 
 func Success(c *fiber.Ctx, message string, data interface{}) error {
@@ -98,6 +102,24 @@ func Unauthorized(c *fiber.Ctx, message string, err string) error {
 		Error:        err,
 	})
 }
+func Forbidden(c *fiber.Ctx, message string, err string) error {
+	return c.Status(fiber.StatusForbidden).JSON(Response{
+		Status:       "Error Forbidden",
+		ResponseCode: fiber.StatusForbidden,
+		Message:      message,
+		Error:        err,
+	})
+}
+
+func TooManyRequests(c *fiber.Ctx, message string, err string) error {
+	return c.Status(fiber.StatusTooManyRequests).JSON(Response{
+		Status:       "Error Too Many Requests",
+		ResponseCode: fiber.StatusTooManyRequests,
+		Message:      message,
+		Error:        err,
+	})
+}
+
 func InternalServerError(c *fiber.Ctx, message string, err string) error {
 	return c.Status(fiber.StatusInternalServerError).JSON(Response{
 		Status:       "Internal Server Error",
@@ -105,4 +127,49 @@ func InternalServerError(c *fiber.Ctx, message string, err string) error {
 		Message:      message,
 		Error:        err,
 	})
-}
\ No newline at end of file
+}
+func PreconditionFailed(c *fiber.Ctx, message string, err string) error {
+	return c.Status(fiber.StatusPreconditionFailed).JSON(Response{
+		Status:       "Error Precondition Failed",
+		ResponseCode: fiber.StatusPreconditionFailed,
+		Message:      message,
+		Error:        err,
+	})
+}
+
+func PreconditionRequired(c *fiber.Ctx, message string, err string) error {
+	return c.Status(fiber.StatusPreconditionRequired).JSON(Response{
+		Status:       "Error Precondition Required",
+		ResponseCode: fiber.StatusPreconditionRequired,
+		Message:      message,
+		Error:        err,
+	})
+}
+
+func Conflict(c *fiber.Ctx, message string, err string) error {
+	return c.Status(fiber.StatusConflict).JSON(Response{
+		Status:       "Error Conflict",
+		ResponseCode: fiber.StatusConflict,
+		Message:      message,
+		Error:        err,
+	})
+}
+
+func UnprocessableEntityResult(c *fiber.Ctx, message string, err string, data interface{}) error {
+	return c.Status(fiber.StatusUnprocessableEntity).JSON(Response{
+		Status:       "Error Unprocessable Entity",
+		ResponseCode: fiber.StatusUnprocessableEntity,
+		Message:      message,
+		Data:         data,
+		Error:        err,
+	})
+}
+
+func UnprocessableEntity(c *fiber.Ctx, message string, err string) error {
+	return c.Status(fiber.StatusUnprocessableEntity).JSON(Response{
+		Status:       "Error Unprocessable Entity",
+		ResponseCode: fiber.StatusUnprocessableEntity,
+		Message:      message,
+		Error:        err,
+	})
+}
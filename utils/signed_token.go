@@ -0,0 +1,56 @@
+package utils
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"errors"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/mohod24/go-project-management/config"
+)
+
+// ErrInvalidSignedToken is returned for tampered, malformed or expired tokens.
+var ErrInvalidSignedToken = errors.New("invalid or expired token")
+
+// SignToken returns a URL-safe token that carries value and is only valid
+// for the given purpose until expiresAt.
+func SignToken(purpose, value string, expiresAt time.Time) string {
+	payload := value + "|" + strconv.FormatInt(expiresAt.Unix(), 10)
+	return base64.RawURLEncoding.EncodeToString([]byte(payload)) + "." + signPayload(purpose, payload)
+}
+
+// VerifySignedToken checks the signature and expiry of a token created by
+// SignToken for the same purpose and returns the value it carries.
+func VerifySignedToken(purpose, token string) (string, error) {
+	encoded, signature, ok := strings.Cut(token, ".")
+	if !ok {
+		return "", ErrInvalidSignedToken
+	}
+	raw, err := base64.RawURLEncoding.DecodeString(encoded)
+	if err != nil {
+		return "", ErrInvalidSignedToken
+	}
+	payload := string(raw)
+	if !hmac.Equal([]byte(signature), []byte(signPayload(purpose, payload))) {
+		return "", ErrInvalidSignedToken
+	}
+
+	sep := strings.LastIndex(payload, "|")
+	if sep < 0 {
+		return "", ErrInvalidSignedToken
+	}
+	expiresAt, err := strconv.ParseInt(payload[sep+1:], 10, 64)
+	if err != nil || time.Now().Unix() > expiresAt {
+		return "", ErrInvalidSignedToken
+	}
+	return payload[:sep], nil
+}
+
+func signPayload(purpose, payload string) string {
+	mac := hmac.New(sha256.New, []byte(config.AppConfig.AppSecret))
+	mac.Write([]byte(purpose + ":" + payload))
+	return base64.RawURLEncoding.EncodeToString(mac.Sum(nil))
+}
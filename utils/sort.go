@@ -0,0 +1,57 @@
+package utils
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/mohod24/go-project-management/models"
+)
+
+// SortError reports a sort parameter that names a field outside the
+// listing's whitelist or is otherwise malformed.
+type SortError struct {
+	Msg string
+}
+
+func (e *SortError) Error() string {
+	return "invalid sort: " + e.Msg
+}
+
+// ParseSort parses a comma-separated sort parameter such as
+// "-created_at,name", where a leading "-" sorts descending. Only the public
+// field names in fields are accepted; they are mapped to their columns.
+func ParseSort(value string, fields map[string]string) ([]models.SortField, error) {
+	if strings.TrimSpace(value) == "" {
+		return nil, nil
+	}
+	var sort []models.SortField
+	seen := make(map[string]bool)
+	for _, part := range strings.Split(value, ",") {
+		part = strings.TrimSpace(part)
+		desc := strings.HasPrefix(part, "-")
+		name := strings.TrimPrefix(part, "-")
+		if name == "" {
+			return nil, &SortError{Msg: "empty field name"}
+		}
+		column, ok := fields[name]
+		if !ok {
+			return nil, &SortError{Msg: fmt.Sprintf("cannot sort by %q, allowed fields are %s", name, strings.Join(sortFieldNames(fields), ", "))}
+		}
+		if seen[name] {
+			return nil, &SortError{Msg: fmt.Sprintf("field %q is listed more than once", name)}
+		}
+		seen[name] = true
+		sort = append(sort, models.SortField{Column: column, Desc: desc})
+	}
+	return sort, nil
+}
+
+func sortFieldNames(fields map[string]string) []string {
+	names := make([]string, 0, len(fields))
+	for name := range fields {
+		names = append(names, name)
+	}
+	slices.Sort(names)
+	return names
+}